@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Registro de auditoría: una línea JSON por serie escrita, solo se agregan líneas
+const auditFile = "auditoria_autocopiado.jsonl"
+
+// AuditEntry registra quién escribió una serie y cuándo. Hash encadena la entrada con la
+// anterior, así que modificar o borrar una línea rompe todas las siguientes.
+type AuditEntry struct {
+	Fecha    time.Time
+	Serie    string
+	Equipo   string
+	Usuario  string
+	Anterior string
+	Hash     string
+}
+
+func (e AuditEntry) computeHash() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		e.Anterior,
+		e.Fecha.UTC().Format(time.RFC3339Nano),
+		e.Serie,
+		e.Equipo,
+		e.Usuario,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// auditTrail agrega entradas al archivo recordando el último hash de la cadena
+type auditTrail struct {
+	path    string
+	mu      sync.Mutex
+	last    string
+	loaded  bool
+	machine string
+	user    string
+}
+
+var audit = &auditTrail{path: auditFile}
+
+// record agrega la serie a la cadena; se llama una vez por serie copiada
+func (t *auditTrail) record(serie string, when time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.loaded {
+		last, err := lastAuditHash(t.path)
+		if err != nil {
+			return err
+		}
+		t.last = last
+		t.machine, t.user = auditIdentity()
+		t.loaded = true
+	}
+
+	entry := AuditEntry{
+		Fecha:    when,
+		Serie:    serie,
+		Equipo:   t.machine,
+		Usuario:  t.user,
+		Anterior: t.last,
+	}
+	entry.Hash = entry.computeHash()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	t.last = entry.Hash
+	return nil
+}
+
+// auditIdentity devuelve el nombre del equipo y del usuario de la sesión
+func auditIdentity() (string, string) {
+	machine, err := os.Hostname()
+	if err != nil {
+		machine = "desconocido"
+	}
+	name := "desconocido"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	return machine, name
+}
+
+// lastAuditHash lee el hash de la última entrada; un archivo inexistente empieza la cadena
+func lastAuditHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	last := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			last = line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if last == "" {
+		return "", nil
+	}
+
+	var entry AuditEntry
+	if err := json.Unmarshal([]byte(last), &entry); err != nil {
+		return "", fmt.Errorf("última entrada de auditoría ilegible: %v", err)
+	}
+	return entry.Hash, nil
+}
+
+// verifyAudit recorre la cadena y devuelve cuántas entradas son válidas; el error indica
+// la primera línea alterada
+func verifyAudit(r io.Reader) (int, error) {
+	prev := ""
+	count := 0
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return count, fmt.Errorf("línea %d ilegible: %v", n, err)
+		}
+		if entry.Anterior != prev {
+			return count, fmt.Errorf("línea %d: no continúa la entrada anterior (se borró o reordenó una línea)", n)
+		}
+		if entry.computeHash() != entry.Hash {
+			return count, fmt.Errorf("línea %d: el contenido no coincide con su hash (serie %q)", n, entry.Serie)
+		}
+		prev = entry.Hash
+		count++
+	}
+	return count, scanner.Err()
+}
+
+// showAuditCheck verifica el archivo de auditoría y muestra el resultado
+func (a *Autocopiador) showAuditCheck() {
+	f, err := os.Open(auditFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			dialog.ShowInformation("Auditoría", "Todavía no hay series registradas.", a.window)
+			return
+		}
+		dialog.ShowError(err, a.window)
+		return
+	}
+	defer f.Close()
+
+	count, err := verifyAudit(f)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("la auditoría fue alterada después de %d entradas válidas: %v", count, err), a.window)
+		return
+	}
+	dialog.ShowInformation("🔐 Auditoría íntegra",
+		fmt.Sprintf("Las %d series registradas en %s mantienen la cadena intacta.", count, auditFile), a.window)
+}
+
+// recordAudit registra una serie copiada; un fallo no detiene el autocopiado
+func recordAudit(serie string) {
+	if err := audit.record(serie, time.Now()); err != nil {
+		log.Printf("Error registrando auditoría: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditTrailChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auditoria.jsonl")
+	when := time.Date(2024, 3, 5, 18, 5, 0, 123, time.Local)
+
+	trail := &auditTrail{path: path}
+	for i, serie := range []string{"ZET001", "ZET002"} {
+		if err := trail.record(serie, when.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("record(%q) error = %v", serie, err)
+		}
+	}
+	// Una nueva ejecución retoma la cadena desde el archivo
+	if err := (&auditTrail{path: path}).record("ZET003", when.Add(time.Minute)); err != nil {
+		t.Fatalf("record() tras reiniciar error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := verifyAudit(strings.NewReader(string(data)))
+	if err != nil || count != 3 {
+		t.Fatalf("verifyAudit() = %d, %v; se esperaban 3 entradas válidas", count, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	tests := []struct {
+		name  string
+		lines []string
+		valid int
+	}{
+		{"serie modificada", []string{lines[0], strings.Replace(lines[1], "ZET002", "ZET999", 1), lines[2]}, 1},
+		{"línea borrada", []string{lines[0], lines[2]}, 1},
+		{"orden cambiado", []string{lines[1], lines[0], lines[2]}, 0},
+	}
+	for _, tt := range tests {
+		count, err := verifyAudit(strings.NewReader(strings.Join(tt.lines, "\n")))
+		if err == nil || count != tt.valid {
+			t.Errorf("%s: verifyAudit() = %d, %v; se esperaba error tras %d entradas", tt.name, count, err, tt.valid)
+		}
+	}
+}
+
+func TestLastAuditHashMissingFile(t *testing.T) {
+	got, err := lastAuditHash(filepath.Join(t.TempDir(), "no_existe.jsonl"))
+	if err != nil || got != "" {
+		t.Errorf("lastAuditHash() = %q, %v; se esperaba una cadena vacía", got, err)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	historyFile       = "historial_autocopiado.json"
+	historyMaxRecords = 100
+	historyDetailHint = "Selecciona una ejecución para ver el detalle"
+)
+
+// AutocopyRecord registra el resultado de una ejecución del autocopiador
+type AutocopyRecord struct {
+	Inicio     time.Time
+	Fin        time.Time
+	Job        AutocopyJob
+	Copiadas   int
+	Total      int
+	Completado bool
+	Pendientes []string // Series que no llegaron a copiarse
+}
+
+func (rec AutocopyRecord) resultado() string {
+	if rec.Completado {
+		return "Completado"
+	}
+	return "Interrumpido"
+}
+
+func (a *Autocopiador) loadHistory() {
+	data, err := ioutil.ReadFile(historyFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Error cargando historial: %v", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &a.history); err != nil {
+		log.Printf("Error leyendo historial: %v", err)
+	}
+}
+
+func (a *Autocopiador) saveHistory() {
+	data, err := json.MarshalIndent(a.history, "", "  ")
+	if err != nil {
+		log.Printf("Error serializando historial: %v", err)
+		return
+	}
+
+	if err := ioutil.WriteFile(historyFile, data, 0644); err != nil {
+		log.Printf("Error guardando historial: %v", err)
+	}
+}
+
+// newAutocopyRecord arma el registro de una ejecución con sus series pendientes
+func newAutocopyRecord(job *AutocopyJob, series []string, started, finished time.Time, copied int, completed bool) AutocopyRecord {
+	rec := AutocopyRecord{
+		Inicio:     started,
+		Fin:        finished,
+		Job:        *job,
+		Copiadas:   copied,
+		Total:      len(series),
+		Completado: completed,
+	}
+	rec.Job.Programado = time.Time{}
+	if copied < len(series) {
+		rec.Pendientes = append([]string(nil), series[copied:]...)
+	}
+	return rec
+}
+
+// recordRun agrega la ejecución al inicio del historial y lo persiste.
+// Se llama desde el goroutine del autocopiado, por eso todo ocurre dentro de fyne.Do.
+func (a *Autocopiador) recordRun(job *AutocopyJob, series []string, started time.Time, copied int, completed bool) {
+	rec := newAutocopyRecord(job, series, started, time.Now(), copied, completed)
+
+	fyne.Do(func() {
+		a.history = append([]AutocopyRecord{rec}, a.history...)
+		if len(a.history) > historyMaxRecords {
+			a.history = a.history[:historyMaxRecords]
+		}
+		a.saveHistory()
+
+		// Los índices se desplazaron: descartar la selección anterior
+		a.historySelected = -1
+		if a.historyList != nil {
+			a.historyList.UnselectAll()
+			a.historyList.Refresh()
+		}
+		if a.historyDetail != nil {
+			a.historyDetail.SetText(historyDetailHint)
+		}
+	})
+}
+
+func (a *Autocopiador) createHistoryCard() *widget.Card {
+	a.historySelected = -1
+
+	a.historyDetail = widget.NewLabel(historyDetailHint)
+	a.historyDetail.Wrapping = fyne.TextWrapWord
+
+	// selectedRecord devuelve la ejecución seleccionada, si la hay
+	selectedRecord := func() (AutocopyRecord, bool) {
+		if a.historySelected < 0 || a.historySelected >= len(a.history) {
+			return AutocopyRecord{}, false
+		}
+		return a.history[a.historySelected], true
+	}
+
+	loadButton := widget.NewButton("📥 Cargar", func() {
+		rec, ok := selectedRecord()
+		if !ok {
+			return
+		}
+		job := rec.Job
+		a.loadJob(&job)
+		a.statusLabel.SetText("Estado: Ejecución cargada desde el historial")
+	})
+
+	rerunButton := widget.NewButton("🔁 Re-ejecutar", func() {
+		rec, ok := selectedRecord()
+		if !ok {
+			return
+		}
+		job := rec.Job
+		a.loadJob(&job)
+		a.start(&job)
+	})
+	rerunButton.Importance = widget.HighImportance
+	a.runControls = append(a.runControls, rerunButton)
+
+	resumeButton := widget.NewButton("⏭️ Reanudar pendientes", func() {
+		rec, ok := selectedRecord()
+		if !ok {
+			return
+		}
+		if len(rec.Pendientes) == 0 {
+			dialog.ShowInformation("Sin pendientes", "Esta ejecución copió todas sus series.", a.window)
+			return
+		}
+		job := rec.Job
+		job.Series = strings.Join(rec.Pendientes, "\n")
+		// Las pendientes ya están en el orden en que se iban escribiendo
+		job.Orden = orderOriginal
+		a.loadJob(&job)
+		a.start(&job)
+	})
+	a.runControls = append(a.runControls, resumeButton)
+
+	a.historyList = widget.NewList(
+		func() int {
+			return len(a.history)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			rec := a.history[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s · %d/%d · %s",
+				rec.Inicio.Format("02/01 15:04"), rec.Copiadas, rec.Total, rec.resultado()))
+		},
+	)
+	a.historyList.OnSelected = func(id widget.ListItemID) {
+		a.historySelected = id
+		rec := a.history[id]
+		a.historyDetail.SetText(fmt.Sprintf("Inicio: %s\nDuración: %s\nFecha tecleada: %s\nVelocidad: %s\nResultado: %s (%d de %d)\nPendientes: %d",
+			rec.Inicio.Format("02/01/2006 15:04:05"),
+			rec.Fin.Sub(rec.Inicio).Round(time.Second),
+			rec.Job.Fecha,
+			rec.Job.Velocidad,
+			rec.resultado(), rec.Copiadas, rec.Total,
+			len(rec.Pendientes)))
+	}
+
+	auditButton := widget.NewButton("🔐 Verificar auditoría", a.showAuditCheck)
+
+	listScroll := container.NewScroll(a.historyList)
+	listScroll.SetMinSize(fyne.NewSize(350, 150))
+
+	return widget.NewCard("🕘 Historial", "",
+		container.NewVBox(
+			listScroll,
+			a.historyDetail,
+			container.NewGridWithColumns(3, loadButton, rerunButton, resumeButton),
+			auditButton,
+		),
+	)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAutocopyRecordPendientes(t *testing.T) {
+	series := []string{"A1", "A2", "A3", "A4"}
+	started := time.Date(2025, 5, 27, 18, 0, 0, 0, time.Local)
+	finished := started.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		copied    int
+		completed bool
+		want      []string
+	}{
+		{"sin copiar", 0, false, []string{"A1", "A2", "A3", "A4"}},
+		{"a medio camino", 2, false, []string{"A3", "A4"}},
+		{"falta la última", 3, false, []string{"A4"}},
+		{"completado", 4, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &AutocopyJob{Series: "A1 A2 A3 A4", Fecha: "27052025", Programado: started}
+			rec := newAutocopyRecord(job, series, started, finished, tt.copied, tt.completed)
+
+			if !reflect.DeepEqual(rec.Pendientes, tt.want) {
+				t.Errorf("Pendientes = %v, se esperaba %v", rec.Pendientes, tt.want)
+			}
+			if rec.Copiadas != tt.copied || rec.Total != len(series) || rec.Completado != tt.completed {
+				t.Errorf("registro = %d/%d completado=%v, se esperaba %d/%d completado=%v",
+					rec.Copiadas, rec.Total, rec.Completado, tt.copied, len(series), tt.completed)
+			}
+			if !rec.Job.Programado.IsZero() {
+				t.Error("la hora programada no debe guardarse en el historial")
+			}
+			if job.Programado.IsZero() {
+				t.Error("newAutocopyRecord modificó el trabajo original")
+			}
+		})
+	}
+}
+
+func TestNewAutocopyRecordCopiesSeries(t *testing.T) {
+	series := []string{"A1", "A2", "A3"}
+	rec := newAutocopyRecord(&AutocopyJob{}, series, time.Now(), time.Now(), 1, false)
+
+	series[2] = "X"
+	if rec.Pendientes[1] != "A3" {
+		t.Errorf("Pendientes comparte memoria con las series originales: %v", rec.Pendientes)
+	}
+}
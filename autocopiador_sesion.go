@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+const sessionFile = "sesion_autocopiado.json"
+
+// autocopySession guarda lo que había en el formulario al cerrar la aplicación
+type autocopySession struct {
+	Series         string
+	Fecha          string
+	TeclasCancelar string
+	Incluir        string
+	Excluir        string
+	ConfirmarMasDe string
+}
+
+// saveSession guarda las series, la fecha, los filtros, las teclas de cancelación y el
+// umbral de lotes largos para recuperarlos en la próxima apertura
+func (a *Autocopiador) saveSession() {
+	data, err := json.MarshalIndent(autocopySession{
+		Series:         a.seriesInput.Text,
+		Fecha:          a.dateInput.Text,
+		TeclasCancelar: a.cancelKeysInput.Text,
+		Incluir:        a.includeInput.Text,
+		Excluir:        a.excludeInput.Text,
+		ConfirmarMasDe: a.longRunInput.Text,
+	}, "", "  ")
+	if err != nil {
+		log.Printf("Error serializando sesión: %v", err)
+		return
+	}
+
+	if err := ioutil.WriteFile(sessionFile, data, 0644); err != nil {
+		log.Printf("Error guardando sesión: %v", err)
+	}
+}
+
+// loadSession restaura el formulario de la última sesión, si existe
+func (a *Autocopiador) loadSession() {
+	data, err := ioutil.ReadFile(sessionFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Error cargando sesión: %v", err)
+		}
+		return
+	}
+
+	var session autocopySession
+	if err := json.Unmarshal(data, &session); err != nil {
+		log.Printf("Error leyendo sesión: %v", err)
+		return
+	}
+	a.seriesInput.SetText(session.Series)
+	a.dateInput.SetText(session.Fecha)
+	a.includeInput.SetText(session.Incluir)
+	a.excludeInput.SetText(session.Excluir)
+	if session.ConfirmarMasDe != "" {
+		a.longRunInput.SetText(session.ConfirmarMasDe)
+	}
+	if session.TeclasCancelar != "" {
+		a.cancelKeysInput.SetText(session.TeclasCancelar)
+	}
+}
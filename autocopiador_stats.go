@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Velocidad de referencia de una persona tecleando, para estimar el tiempo ahorrado
+const manualCharsPerMinute = 180
+
+// AutocopyStats acumula las métricas de la sesión actual del autocopiador.
+// Se actualiza desde el goroutine del autocopiado y se lee desde la interfaz.
+type AutocopyStats struct {
+	mu            sync.Mutex
+	Inicio        time.Time
+	Ejecuciones   int
+	Series        int
+	Caracteres    int
+	Cancelaciones int
+	Errores       int
+	Tecleando     time.Duration // Tiempo efectivo escribiendo series
+}
+
+func newAutocopyStats() *AutocopyStats {
+	return &AutocopyStats{Inicio: time.Now()}
+}
+
+func (st *AutocopyStats) addRun() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.Ejecuciones++
+}
+
+func (st *AutocopyStats) addEntry(chars int, elapsed time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.Series++
+	st.Caracteres += chars
+	st.Tecleando += elapsed
+}
+
+func (st *AutocopyStats) addCancel() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.Cancelaciones++
+}
+
+func (st *AutocopyStats) addError() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.Errores++
+}
+
+// entriesPerMinute calcula el ritmo sobre el tiempo efectivo de tecleo
+func (st *AutocopyStats) entriesPerMinute() float64 {
+	if st.Tecleando <= 0 {
+		return 0
+	}
+	return float64(st.Series) / st.Tecleando.Minutes()
+}
+
+// timeSaved estima cuánto habría tardado escribir lo mismo a mano
+func (st *AutocopyStats) timeSaved() time.Duration {
+	manual := time.Duration(float64(st.Caracteres) / manualCharsPerMinute * float64(time.Minute))
+	if manual < st.Tecleando {
+		return 0
+	}
+	return manual - st.Tecleando
+}
+
+func (st *AutocopyStats) summary() string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return fmt.Sprintf("Sesión iniciada: %s\n"+
+		"Ejecuciones: %d\n"+
+		"Series escritas: %d\n"+
+		"Caracteres tecleados: %d\n"+
+		"Series por minuto: %.1f\n"+
+		"Cancelaciones: %d\n"+
+		"Errores: %d\n"+
+		"Tiempo tecleando: %s\n"+
+		"Tiempo ahorrado estimado: %s",
+		st.Inicio.Format("02/01/2006 15:04"),
+		st.Ejecuciones,
+		st.Series,
+		st.Caracteres,
+		st.entriesPerMinute(),
+		st.Cancelaciones,
+		st.Errores,
+		st.Tecleando.Round(time.Second),
+		st.timeSaved().Round(time.Second))
+}
+
+func (a *Autocopiador) createStatsPanel() *widget.Accordion {
+	a.statsLabel = widget.NewLabel(a.stats.summary())
+
+	return widget.NewAccordion(
+		widget.NewAccordionItem("📈 Estadísticas de la sesión", a.statsLabel),
+		widget.NewAccordionItem("📊 Métricas diarias", a.createDailyMetricsPanel()),
+	)
+}
+
+// refreshStats actualiza el panel; se puede llamar desde cualquier goroutine
+func (a *Autocopiador) refreshStats() {
+	if a.statsLabel == nil {
+		return
+	}
+	text := a.stats.summary()
+	fyne.Do(func() {
+		a.statsLabel.SetText(text)
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutocopyStatsRates(t *testing.T) {
+	st := newAutocopyStats()
+	if got := st.entriesPerMinute(); got != 0 {
+		t.Errorf("entriesPerMinute() sin datos = %v, se esperaba 0", got)
+	}
+
+	for i := 0; i < 30; i++ {
+		st.addEntry(18, time.Second)
+	}
+	if got := st.entriesPerMinute(); got != 60 {
+		t.Errorf("entriesPerMinute() = %v, se esperaba 60", got)
+	}
+
+	// 540 caracteres a 180 por minuto son 3 minutos manuales frente a 30 s automáticos
+	if got, want := st.timeSaved(), 150*time.Second; got != want {
+		t.Errorf("timeSaved() = %v, se esperaba %v", got, want)
+	}
+
+	st.addCancel()
+	st.addError()
+	st.addError()
+	if st.Cancelaciones != 1 || st.Errores != 2 {
+		t.Errorf("cancelaciones/errores = %d/%d, se esperaba 1/2", st.Cancelaciones, st.Errores)
+	}
+}
+
+func TestAutocopyStatsTimeSavedNeverNegative(t *testing.T) {
+	st := newAutocopyStats()
+	st.addEntry(1, time.Minute)
+	if got := st.timeSaved(); got != 0 {
+		t.Errorf("timeSaved() = %v, se esperaba 0", got)
+	}
+}
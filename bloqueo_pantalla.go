@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// Cada consulta del bloqueo puede lanzar un proceso, así que no se repite en cada paso
+	lockCheckInterval = time.Second
+	lockPollInterval  = 2 * time.Second
+)
+
+// lockWatcher limita la frecuencia con que se consulta el estado de la sesión
+type lockWatcher struct {
+	next time.Time
+}
+
+// due indica si toca volver a consultar el bloqueo
+func (w *lockWatcher) due(now time.Time) bool {
+	if now.Before(w.next) {
+		return false
+	}
+	w.next = now.Add(lockCheckInterval)
+	return true
+}
+
+// guardLock pausa el autocopiado mientras la pantalla esté bloqueada o con el protector
+// activo, y al desbloquearse pide confirmación antes de seguir; devuelve false si se cancela.
+func (a *Autocopiador) guardLock(w *lockWatcher) bool {
+	if !w.due(time.Now()) || !screenLocked() {
+		return true
+	}
+
+	a.statusLabel.SetText("Pausado: pantalla bloqueada")
+	// Para desbloquear hay que escribir la contraseña
+	userInput.pause()
+	defer userInput.resume()
+	for screenLocked() {
+		select {
+		case <-cancel:
+			a.statusLabel.SetText("Estado: Cancelado.")
+			return false
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	if !waitConfirmation("🔒 Sesión desbloqueada",
+		fmt.Sprintf("La pantalla se bloqueó durante el autocopiado y el proceso se pausó para no escribir en la pantalla de bloqueo.\n\n"+
+			"Al continuar tendrás %d segundos para volver a la ventana destino con el cursor en el campo correcto.\n¿Continuar?",
+			resumeCountdown),
+		a.window, a.statusLabel) {
+		return false
+	}
+	if !runCountdown(resumeCountdown, a.statusLabel) {
+		return false
+	}
+	a.statusLabel.SetText("Copiando...")
+	return true
+}
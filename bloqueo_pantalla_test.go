@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockWatcherDue(t *testing.T) {
+	var w lockWatcher
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		offset time.Duration
+		want   bool
+	}{
+		{0, true},
+		{200 * time.Millisecond, false},
+		{999 * time.Millisecond, false},
+		{lockCheckInterval, true},
+		{lockCheckInterval + 500*time.Millisecond, false},
+		{3 * lockCheckInterval, true},
+	}
+	for _, tt := range tests {
+		if got := w.due(start.Add(tt.offset)); got != tt.want {
+			t.Errorf("due(+%v) = %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+}
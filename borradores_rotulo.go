@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+const (
+	draftExtension = ".rotulo"
+	draftVersion   = 1 // Sube si cambia el formato; los borradores más nuevos no se abren
+)
+
+// rotuloDraft es un rótulo a medio llenar guardado en un archivo para terminarlo después
+type rotuloDraft struct {
+	Version  int
+	Guardado time.Time
+	Sucursal string // Sucursal del remitente elegida en la lista
+	Rotulo   RotuloData
+}
+
+func encodeDraft(data RotuloData, sucursal string, now time.Time) ([]byte, error) {
+	return json.MarshalIndent(rotuloDraft{Version: draftVersion, Guardado: now, Sucursal: sucursal, Rotulo: data}, "", "  ")
+}
+
+func decodeDraft(data []byte) (rotuloDraft, error) {
+	var draft rotuloDraft
+	if err := json.Unmarshal(data, &draft); err != nil || draft.Version == 0 {
+		return rotuloDraft{}, fmt.Errorf("el archivo no es un borrador de rótulo")
+	}
+	if draft.Version > draftVersion {
+		return rotuloDraft{}, fmt.Errorf("el borrador es de una versión más nueva del programa")
+	}
+	return draft, nil
+}
+
+// draftFileName propone el nombre del borrador con la empresa y el destinatario, o la hora si
+// todavía no tiene destinatario
+func draftFileName(data RotuloData, now time.Time) string {
+	name := unsafeFileChars.ReplaceAllString(data.DestinatarioNombre, "_")
+	if name == "" || name == "_" {
+		name = now.Format("20060102_150405")
+	}
+	return fmt.Sprintf("borrador_%s_%s%s", data.Empresa, name, draftExtension)
+}
+
+// saveDraft guarda el rótulo tal como está, aunque le falten datos
+func (r *RotuloGenerator) saveDraft(window fyne.Window) {
+	now := time.Now()
+	content, err := encodeDraft(*r.data, r.sucursal.Selected, now)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		if _, err := writer.Write(content); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		dialog.ShowInformation("📝 Borrador Guardado",
+			fmt.Sprintf("El rótulo se guardó en %s.\nÁbrelo con 📂 Abrir borrador para terminarlo.", writer.URI().Name()), window)
+	}, window)
+	saveDialog.SetFileName(draftFileName(*r.data, now))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{draftExtension}))
+	saveDialog.Show()
+}
+
+// openDraft carga un borrador en el formulario y reemplaza lo que había
+func (r *RotuloGenerator) openDraft(window fyne.Window) {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		content, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		draft, err := decodeDraft(content)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if _, ok := empresasData[draft.Rotulo.Empresa]; !ok {
+			missing := draft.Rotulo.Empresa
+			draft.Rotulo.Empresa = defaultEmpresa()
+			dialog.ShowInformation("📂 Abrir borrador",
+				fmt.Sprintf("La empresa %s del borrador ya no existe; se usa %s.", missing, draft.Rotulo.Empresa), window)
+		}
+		r.applyDraft(draft)
+	}, window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{draftExtension}))
+	open.Show()
+}
+
+// applyDraft llena el formulario con el borrador. La empresa y la sucursal se eligen primero
+// porque llenan el remitente, que luego se pisa con el del borrador. La fecha de envío es la
+// de hoy: el borrador se termina y se imprime ahora.
+func (r *RotuloGenerator) applyDraft(draft rotuloDraft) {
+	data := draft.Rotulo
+	r.empresaCheck.SetSelected(data.Empresa)
+	if _, ok := findRemitente(empresasData[data.Empresa], draft.Sucursal); ok {
+		r.sucursal.SetSelected(draft.Sucursal)
+	}
+
+	r.filling = true
+	r.inputs["remitenteNombre"].SetText(data.RemitenteNombre)
+	r.inputs["remitenteDireccion"].SetText(data.RemitenteDireccion)
+	r.inputs["remitenteTelefono"].SetText(data.RemitenteTelefono)
+	r.inputs["destinatarioNombre"].SetText(data.DestinatarioNombre)
+	r.inputs["destinatarioDireccion"].SetText(data.DestinatarioDireccion)
+	r.inputs["destinatarioTelefono"].SetText(data.DestinatarioTelefono)
+	r.inputs["destinatarioDNI"].SetText(data.DestinatarioDNI)
+	r.inputs["peso"].SetText(data.Peso)
+	r.inputs["numeroGuia"].SetText(data.NumeroGuia)
+	r.inputs["observaciones"].SetText(data.Observaciones)
+	r.filling = false
+	r.suggestions.Hide()
+
+	r.tamanoHoja.SetSelected(getValueOrDefault(data.TamanoHoja, "A4"))
+	r.orientacion.SetSelected(getValueOrDefault(data.Orientacion, "Vertical"))
+	r.distribucion.SetSelected(findSheetLayout(data.Distribucion).Name)
+	r.data.FechaEnvio = time.Now()
+	r.updateLogoPreview(data.Empresa)
+	r.updatePreview()
+}
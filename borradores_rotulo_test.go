@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDraftRoundTrip(t *testing.T) {
+	now := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	data := RotuloData{Empresa: "ZETTACOM", RemitenteNombre: "ZETTACOM S.A.C", DestinatarioNombre: "María González",
+		DestinatarioDireccion: "Jr. Los Olivos 456\nMiraflores", Peso: "2.5 kg", TamanoHoja: "A5",
+		Orientacion: "Horizontal", Distribucion: "2 por A4 (2x1)", FechaEnvio: now}
+
+	content, err := encodeDraft(data, "Almacén", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft, err := decodeDraft(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Rotulo != data || draft.Sucursal != "Almacén" || !draft.Guardado.Equal(now) || draft.Version != draftVersion {
+		t.Errorf("el borrador no volvió igual: %+v", draft)
+	}
+}
+
+func TestDecodeDraftRejects(t *testing.T) {
+	for _, content := range []string{"", "no es json", `{"Rotulo":{"Empresa":"ZETTACOM"}}`, `[1,2]`} {
+		if _, err := decodeDraft([]byte(content)); err == nil {
+			t.Errorf("se aceptó %q como borrador", content)
+		}
+	}
+	if _, err := decodeDraft([]byte(`{"Version":99}`)); err == nil || !strings.Contains(err.Error(), "más nueva") {
+		t.Errorf("un borrador de una versión más nueva debería rechazarse: %v", err)
+	}
+}
+
+func TestDraftFileName(t *testing.T) {
+	now := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	if got := draftFileName(RotuloData{Empresa: "COMSITEC", DestinatarioNombre: "María González"}, now); got != "borrador_COMSITEC_Mar_a_Gonz_lez.rotulo" {
+		t.Errorf("draftFileName = %q", got)
+	}
+	if got := draftFileName(RotuloData{Empresa: "COMSITEC"}, now); got != "borrador_COMSITEC_20240305_093000.rotulo" {
+		t.Errorf("sin destinatario draftFileName = %q", got)
+	}
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// noteEntry es el editor del bloc de notas: un Entry multilínea que además atiende
+// atajos propios con Ctrl (Cmd en macOS) + tecla, como Ctrl+F para buscar. onUndo y onRedo
+// reemplazan el deshacer del Entry, que se pierde cada vez que cambia el texto con SetText.
+type noteEntry struct {
+	widget.Entry
+	shortcuts map[noteShortcut]func()
+	onUndo    func()
+	onRedo    func()
+}
+
+// noteShortcut es Ctrl + key, o Ctrl + Shift + key si shift es true
+type noteShortcut struct {
+	key   fyne.KeyName
+	shift bool
+}
+
+func newNoteEntry() *noteEntry {
+	e := &noteEntry{shortcuts: map[noteShortcut]func(){}}
+	e.MultiLine = true
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// TypedShortcut atiende primero los atajos propios y deja el resto al Entry
+func (e *noteEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	switch shortcut.(type) {
+	case *fyne.ShortcutUndo:
+		if e.onUndo != nil {
+			e.onUndo()
+			return
+		}
+	case *fyne.ShortcutRedo:
+		if e.onRedo != nil {
+			e.onRedo()
+			return
+		}
+	}
+	if custom, ok := shortcut.(*desktop.CustomShortcut); ok {
+		shift := custom.Modifier&fyne.KeyModifierShift != 0
+		if isCommandModifier(custom.Modifier &^ fyne.KeyModifierShift) {
+			if action, ok := e.shortcuts[noteShortcut{custom.KeyName, shift}]; ok {
+				action()
+				return
+			}
+		}
+	}
+	e.Entry.TypedShortcut(shortcut)
+}
+
+func isCommandModifier(m fyne.KeyModifier) bool {
+	return m == fyne.KeyModifierControl || m == fyne.KeyModifierSuper
+}
+
+// addCanvasShortcut registra el atajo en la ventana con Ctrl y con Cmd, para cuando el
+// foco no está en el editor
+func addCanvasShortcut(window fyne.Window, s noteShortcut, action func()) {
+	for _, mod := range []fyne.KeyModifier{fyne.KeyModifierControl, fyne.KeyModifierSuper} {
+		if s.shift {
+			mod |= fyne.KeyModifierShift
+		}
+		window.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: s.key, Modifier: mod}, func(fyne.Shortcut) {
+			action()
+		})
+	}
+}
+
+// selectRange selecciona length caracteres desde la fila y columna dadas, igual que si se
+// marcaran con Shift y las flechas; así la coincidencia queda resaltada y a la vista
+func selectRange(e *widget.Entry, row, col, length int) {
+	shift := &fyne.KeyEvent{Name: desktop.KeyShiftLeft}
+	e.KeyUp(shift)
+	if e.SelectedText() != "" {
+		e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	}
+	e.CursorRow, e.CursorColumn = row, col
+	e.KeyDown(shift)
+	for i := 0; i < length; i++ {
+		e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	}
+	e.KeyUp(shift)
+	e.Refresh()
+}
+
+// textMatch es una coincidencia en bytes del texto
+type textMatch struct {
+	start, end int
+}
+
+// compileSearch arma la expresión de búsqueda; sin regex el patrón se busca literal
+func compileSearch(pattern string, useRegex, matchCase bool) (*regexp.Regexp, error) {
+	if !useRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !matchCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("expresión inválida: %v", err)
+	}
+	return re, nil
+}
+
+// findMatches devuelve las coincidencias no vacías del texto
+func findMatches(text string, re *regexp.Regexp) []textMatch {
+	var matches []textMatch
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if loc[1] > loc[0] {
+			matches = append(matches, textMatch{loc[0], loc[1]})
+		}
+	}
+	return matches
+}
+
+// matchAfter elige la siguiente coincidencia desde offset (o la anterior si forward es
+// false), volviendo al principio o al final del texto al llegar al extremo
+func matchAfter(matches []textMatch, offset int, forward bool) int {
+	if forward {
+		for i, m := range matches {
+			if m.start >= offset {
+				return i
+			}
+		}
+		return 0
+	}
+	for i := len(matches) - 1; i >= 0; i-- {
+		if matches[i].start < offset {
+			return i
+		}
+	}
+	return len(matches) - 1
+}
+
+// offsetToRowCol convierte un desplazamiento en bytes en fila y columna (en caracteres)
+func offsetToRowCol(text string, offset int) (int, int) {
+	before := text[:offset]
+	row := strings.Count(before, "\n")
+	lineStart := strings.LastIndex(before, "\n") + 1
+	return row, utf8.RuneCountInString(before[lineStart:])
+}
+
+// rowColToOffset es la inversa de offsetToRowCol; las posiciones fuera del texto se ajustan
+func rowColToOffset(text string, row, col int) int {
+	lines := strings.SplitAfter(text, "\n")
+	offset := 0
+	for i := 0; i < row && i < len(lines); i++ {
+		offset += len(lines[i])
+	}
+	if row >= len(lines) {
+		return len(text)
+	}
+	line := strings.TrimSuffix(lines[row], "\n")
+	for i := range line {
+		if col == 0 {
+			return offset + i
+		}
+		col--
+	}
+	return offset + len(line)
+}
+
+// replacement devuelve el texto que reemplaza la coincidencia m; con regex admite $1, ${nombre}
+func replacement(text string, re *regexp.Regexp, m textMatch, repl string, useRegex bool) string {
+	if !useRegex {
+		return repl
+	}
+	for _, sub := range re.FindAllStringSubmatchIndex(text, -1) {
+		if sub[0] == m.start {
+			return string(re.ExpandString(nil, repl, text, sub))
+		}
+	}
+	return repl
+}
+
+// replaceAllMatches reemplaza todas las coincidencias y devuelve cuántas hubo
+func replaceAllMatches(text string, re *regexp.Regexp, repl string, useRegex bool) (string, int) {
+	count := len(findMatches(text, re))
+	if count == 0 {
+		return text, 0
+	}
+	if useRegex {
+		return re.ReplaceAllString(text, repl), count
+	}
+	return re.ReplaceAllLiteralString(text, repl), count
+}
+
+// findBar es la barra de búsqueda y reemplazo del bloc de notas (Ctrl+F)
+type findBar struct {
+	n            *NotePad
+	searchEntry  *widget.Entry
+	replaceEntry *widget.Entry
+	regexCheck   *widget.Check
+	caseCheck    *widget.Check
+	countLabel   *widget.Label
+	replaceRow   *fyne.Container
+	box          *fyne.Container
+	current      textMatch
+	hasCurrent   bool
+}
+
+func (f *findBar) compile() (*regexp.Regexp, bool) {
+	if f.searchEntry.Text == "" {
+		f.countLabel.SetText("")
+		f.hasCurrent = false
+		return nil, false
+	}
+	re, err := compileSearch(f.searchEntry.Text, f.regexCheck.Checked, f.caseCheck.Checked)
+	if err != nil {
+		f.countLabel.SetText("Expresión inválida")
+		f.hasCurrent = false
+		return nil, false
+	}
+	return re, true
+}
+
+// search salta a la siguiente (o anterior) coincidencia y la selecciona en el editor
+func (f *findBar) search(forward bool) {
+	re, ok := f.compile()
+	if !ok {
+		return
+	}
+	text := f.n.multiLine.Text
+	matches := findMatches(text, re)
+	if len(matches) == 0 {
+		f.countLabel.SetText("Sin coincidencias")
+		f.hasCurrent = false
+		return
+	}
+
+	offset := rowColToOffset(text, f.n.multiLine.CursorRow, f.n.multiLine.CursorColumn)
+	if f.hasCurrent && f.current.end <= len(text) {
+		offset = f.current.start
+		if forward {
+			offset = f.current.end
+		}
+	}
+	i := matchAfter(matches, offset, forward)
+	f.current, f.hasCurrent = matches[i], true
+
+	row, col := offsetToRowCol(text, f.current.start)
+	selectRange(&f.n.multiLine.Entry, row, col, utf8.RuneCountInString(text[f.current.start:f.current.end]))
+	f.countLabel.SetText(fmt.Sprintf("%d de %d", i+1, len(matches)))
+}
+
+// replaceCurrent reemplaza la coincidencia seleccionada y pasa a la siguiente
+func (f *findBar) replaceCurrent() {
+	re, ok := f.compile()
+	if !ok {
+		return
+	}
+	text := f.n.multiLine.Text
+	valid := false
+	for _, m := range findMatches(text, re) {
+		valid = valid || (f.hasCurrent && m == f.current)
+	}
+	if !valid {
+		f.hasCurrent = false
+		f.search(true)
+		return
+	}
+
+	repl := replacement(text, re, f.current, f.replaceEntry.Text, f.regexCheck.Checked)
+	f.n.applyEdit(historyReplace, text[:f.current.start]+repl+text[f.current.end:])
+	f.current = textMatch{f.current.start, f.current.start + len(repl)}
+	f.search(true)
+}
+
+func (f *findBar) replaceAll() {
+	re, ok := f.compile()
+	if !ok {
+		return
+	}
+	text, count := replaceAllMatches(f.n.multiLine.Text, re, f.replaceEntry.Text, f.regexCheck.Checked)
+	if count > 0 {
+		f.n.applyEdit(historyReplace, text)
+	}
+	f.hasCurrent = false
+	f.countLabel.SetText(fmt.Sprintf("%d reemplazos", count))
+}
+
+// show abre la barra con el texto seleccionado como búsqueda inicial
+func (f *findBar) show(window fyne.Window) {
+	if selected := f.n.multiLine.SelectedText(); selected != "" && !strings.Contains(selected, "\n") {
+		f.searchEntry.SetText(selected)
+	}
+	f.box.Show()
+	window.Canvas().Focus(f.searchEntry)
+}
+
+func (n *NotePad) createFindBar(window fyne.Window) *findBar {
+	f := &findBar{
+		n:            n,
+		searchEntry:  widget.NewEntry(),
+		replaceEntry: widget.NewEntry(),
+		countLabel:   widget.NewLabel(""),
+	}
+	f.searchEntry.SetPlaceHolder("Buscar...")
+	f.replaceEntry.SetPlaceHolder("Reemplazar con... ($1 con regex)")
+
+	restart := func(bool) {
+		f.hasCurrent = false
+		f.search(true)
+	}
+	f.regexCheck = widget.NewCheck("Regex", restart)
+	f.caseCheck = widget.NewCheck("Aa", restart)
+	f.searchEntry.OnChanged = func(string) {
+		f.hasCurrent = false
+		f.search(true)
+	}
+	f.searchEntry.OnSubmitted = func(string) {
+		f.search(true)
+	}
+
+	prevButton := widget.NewButton("◀", func() { f.search(false) })
+	nextButton := widget.NewButton("▶", func() { f.search(true) })
+
+	f.replaceRow = container.NewBorder(nil, nil, nil,
+		container.NewHBox(
+			widget.NewButton("Reemplazar", f.replaceCurrent),
+			widget.NewButton("Reemplazar todo", f.replaceAll),
+		),
+		f.replaceEntry)
+	f.replaceRow.Hide()
+
+	replaceToggle := widget.NewButton("⇄", func() {
+		if f.replaceRow.Visible() {
+			f.replaceRow.Hide()
+		} else {
+			f.replaceRow.Show()
+		}
+	})
+	closeButton := widget.NewButton("✖", func() {
+		f.box.Hide()
+		window.Canvas().Focus(n.multiLine)
+	})
+
+	f.box = container.NewVBox(
+		container.NewBorder(nil, nil, nil,
+			container.NewHBox(f.countLabel, prevButton, nextButton, f.regexCheck, f.caseCheck, replaceToggle, closeButton),
+			f.searchEntry),
+		f.replaceRow,
+	)
+	f.box.Hide()
+
+	// Ctrl+F abre la barra tanto desde el editor como desde el resto de la pestaña
+	n.addNoteShortcut(window, noteShortcut{key: fyne.KeyF}, func() { f.show(window) })
+	return f
+}
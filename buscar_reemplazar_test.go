@@ -0,0 +1,114 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompileSearch(t *testing.T) {
+	text := "Serie ZET00154 y zet00155 (a+b)"
+
+	re, err := compileSearch("zet", false, false)
+	if err != nil || len(findMatches(text, re)) != 2 {
+		t.Errorf("búsqueda sin distinguir mayúsculas: %v, %v", findMatches(text, re), err)
+	}
+	re, _ = compileSearch("zet", false, true)
+	if got := findMatches(text, re); !reflect.DeepEqual(got, []textMatch{{17, 20}}) {
+		t.Errorf("búsqueda con mayúsculas = %v", got)
+	}
+	re, _ = compileSearch("(a+b)", false, true)
+	if got := findMatches(text, re); !reflect.DeepEqual(got, []textMatch{{26, 31}}) {
+		t.Errorf("búsqueda literal = %v", got)
+	}
+	re, _ = compileSearch(`ZET\d+`, true, false)
+	if got := findMatches(text, re); len(got) != 2 {
+		t.Errorf("búsqueda regex = %v", got)
+	}
+	if _, err := compileSearch("(", true, false); err == nil {
+		t.Error("una expresión inválida debería dar error")
+	}
+	re, _ = compileSearch("x*", true, false)
+	if got := findMatches(text, re); len(got) != 0 {
+		t.Errorf("las coincidencias vacías no cuentan: %v", got)
+	}
+}
+
+func TestMatchAfter(t *testing.T) {
+	matches := []textMatch{{2, 4}, {10, 12}, {20, 22}}
+	tests := []struct {
+		offset  int
+		forward bool
+		want    int
+	}{
+		{0, true, 0},
+		{4, true, 1},
+		{12, true, 2},
+		{22, true, 0},
+		{20, false, 1},
+		{10, false, 0},
+		{2, false, 2},
+		{30, false, 2},
+	}
+	for _, tt := range tests {
+		if got := matchAfter(matches, tt.offset, tt.forward); got != tt.want {
+			t.Errorf("matchAfter(%d, %v) = %d, se esperaba %d", tt.offset, tt.forward, got, tt.want)
+		}
+	}
+}
+
+func TestOffsetRowCol(t *testing.T) {
+	text := "REPOSICIÓN 15:30\nañadir ZET00154\n\nfin"
+	tests := []struct {
+		offset, row, col int
+	}{
+		{0, 0, 0},
+		{len("REPOSICIÓN "), 0, 11},
+		{len("REPOSICIÓN 15:30\n"), 1, 0},
+		{len("REPOSICIÓN 15:30\nañadir "), 1, 7},
+		{len("REPOSICIÓN 15:30\nañadir ZET00154\n\n"), 3, 0},
+		{len(text), 3, 3},
+	}
+	for _, tt := range tests {
+		row, col := offsetToRowCol(text, tt.offset)
+		if row != tt.row || col != tt.col {
+			t.Errorf("offsetToRowCol(%d) = %d,%d, se esperaba %d,%d", tt.offset, row, col, tt.row, tt.col)
+		}
+		if got := rowColToOffset(text, tt.row, tt.col); got != tt.offset {
+			t.Errorf("rowColToOffset(%d, %d) = %d, se esperaba %d", tt.row, tt.col, got, tt.offset)
+		}
+	}
+	if got := rowColToOffset(text, 0, 99); got != len("REPOSICIÓN 15:30") {
+		t.Errorf("una columna fuera de la línea debería quedar al final: %d", got)
+	}
+	if got := rowColToOffset(text, 9, 0); got != len(text) {
+		t.Errorf("una fila fuera del texto debería quedar al final: %d", got)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	text := "ZET00154 15:30\nZET00155 16:45"
+
+	re, _ := compileSearch(`ZET(\d+)`, true, true)
+	matches := findMatches(text, re)
+	if got := replacement(text, re, matches[1], "Z-$1", true); got != "Z-00155" {
+		t.Errorf("replacement con grupos = %q", got)
+	}
+	got, count := replaceAllMatches(text, re, "Z-$1", true)
+	if count != 2 || got != "Z-00154 15:30\nZ-00155 16:45" {
+		t.Errorf("replaceAllMatches regex = %q, %d", got, count)
+	}
+
+	re, _ = compileSearch("zet", false, false)
+	if got := replacement(text, re, findMatches(text, re)[0], "$1", false); got != "$1" {
+		t.Errorf("sin regex el reemplazo es literal: %q", got)
+	}
+	got, count = replaceAllMatches(text, re, "$X", false)
+	if count != 2 || got != "$X00154 15:30\n$X00155 16:45" {
+		t.Errorf("replaceAllMatches literal = %q, %d", got, count)
+	}
+
+	re, _ = compileSearch("nada", false, false)
+	if got, count := replaceAllMatches(text, re, "x", false); count != 0 || got != text {
+		t.Errorf("sin coincidencias no debería cambiar nada: %q, %d", got, count)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// Opción de línea de comandos para ejecutar un trabajo exportado sin abrir la ventana
+const autocopyFlag = "--autocopy"
+
+// Segundos para enfocar la ventana destino antes de empezar
+const headlessCountdown = 5
+
+// Códigos de salida del modo sin interfaz
+const (
+	exitCompleted   = 0
+	exitError       = 1
+	exitInterrupted = 2
+)
+
+// cliJobPath busca "--autocopy trabajo.json" o "--autocopy=trabajo.json" en los argumentos
+func cliJobPath(args []string) (string, bool, error) {
+	for i, arg := range args {
+		if path, ok := strings.CutPrefix(arg, autocopyFlag+"="); ok {
+			if path == "" {
+				return "", true, fmt.Errorf("%s necesita la ruta de un trabajo .json", autocopyFlag)
+			}
+			return path, true, nil
+		}
+		if arg == autocopyFlag {
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return "", true, fmt.Errorf("%s necesita la ruta de un trabajo .json", autocopyFlag)
+			}
+			return args[i+1], true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// validateHeadlessJob rechaza las opciones que necesitan confirmar en un diálogo
+func validateHeadlessJob(job *AutocopyJob) error {
+	if job.PausarCada > 0 {
+		return fmt.Errorf("las pausas por lote piden confirmación y no están disponibles sin interfaz")
+	}
+	if job.VerificarOCR {
+		return fmt.Errorf("la verificación OCR pide confirmación y no está disponible sin interfaz")
+	}
+	return nil
+}
+
+// runHeadless ejecuta un trabajo exportado mostrando el avance en la consola. Las pausas
+// que en la interfaz piden confirmación (foco perdido, color no detectado) aquí lo detienen.
+func runHeadless(path string) int {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error leyendo %s: %v\n", path, err)
+		return exitError
+	}
+	job, err := decodeJob(data)
+	if err == nil {
+		err = validateHeadlessJob(job)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	preset, ok := findSpeedPreset(job.Velocidad)
+	if !ok {
+		preset, _ = findSpeedPreset(defaultSpeedPreset)
+	}
+	steps, err := parseMacro(job.Macro)
+	if err != nil {
+		fmt.Printf("Error: secuencia inválida (%v)\n", err)
+		return exitError
+	}
+	terminator, err := parseTerminator(job.Terminador, job.TerminadorPersonalizado)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	records, err := job.records()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	iterations := job.iterations()
+	perIteration := len(records)
+	records = repeatList(records, iterations)
+	total := len(records)
+
+	clipboard := ""
+	if macroUsesPlaceholder(append(steps, terminator...), "portapapeles") {
+		if clipboard, err = readClipboardValue(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+	}
+
+	combos, _ := parseCancelKeys(defaultCancelKeys)
+	listenCancelKeys(combos)
+
+	fmt.Printf("Trabajo %s: %d series. Enfoca la ventana destino.\n", path, total)
+	for i := headlessCountdown; i > 0; i-- {
+		fmt.Printf("Comenzando en %d...\n", i)
+		select {
+		case <-cancel:
+			fmt.Println("Cancelado.")
+			return exitInterrupted
+		case <-time.After(time.Second):
+		}
+	}
+
+	if job.CancelarSiTeclea {
+		userInput.start(cancelKeyNames(combos))
+		setHookObserver(userInput.handle)
+		defer func() {
+			setHookObserver(nil)
+			if key := userInput.stop(); key != "" {
+				fmt.Printf("Cancelado: se pulsó %q durante el autocopiado.\n", key)
+			}
+		}()
+	}
+
+	target := activeWindow()
+	var locks lockWatcher
+	copied := 0
+	interrupted := func(reason string) int {
+		fmt.Printf("%s Se copiaron %d de %d series.\n", reason, copied, total)
+		return exitInterrupted
+	}
+
+	for i, rec := range records {
+		if len(job.Ventanas) > 0 {
+			window := windowForRecord(job.Ventanas, i)
+			if err := activateWindow(window); err != nil {
+				return interrupted(fmt.Sprintf("Error: %v.", err))
+			}
+			target = window.target()
+		}
+
+		stepDelay, rowSettle := preset.Delay, preset.RowSettle
+		if rec.Espera > 0 {
+			stepDelay, rowSettle = rec.Espera, rec.Espera
+		}
+
+		vars := macroVars(rec, job.Fecha, i%perIteration, perIteration, time.Now())
+		vars["iteracion"] = strconv.Itoa(i/perIteration + 1)
+		vars["portapapeles"] = clipboard
+		for _, step := range steps {
+			if reason := headlessStep(job, step, vars, preset.KeyDelay, target, &locks); reason != "" {
+				return interrupted(reason)
+			}
+			if step.Tipo != stepWait {
+				time.Sleep(humanDelay(stepDelay, job.Variacion))
+			}
+		}
+		for _, step := range terminator {
+			if reason := headlessStep(job, step, vars, preset.KeyDelay, target, &locks); reason != "" {
+				return interrupted(reason)
+			}
+		}
+		time.Sleep(humanDelay(rowSettle, job.Variacion))
+
+		copied++
+		recordAudit(rec.Serie)
+		fmt.Printf("[%d/%d] %s\n", copied, total, rec.Serie)
+	}
+
+	fmt.Printf("Finalizado correctamente: %d series.\n", total)
+	return exitCompleted
+}
+
+// headlessStep ejecuta un paso con las comprobaciones del trabajo; devuelve el motivo
+// por el que hay que detenerse, o "" si se puede seguir
+func headlessStep(job *AutocopyJob, step MacroStep, vars map[string]string, keyDelay int, target targetWindow, locks *lockWatcher) string {
+	select {
+	case <-cancel:
+		return "Cancelado."
+	default:
+	}
+	if job.VigilarVentana {
+		if current := activeWindow(); current.pid != target.pid {
+			return fmt.Sprintf("Detenido: la ventana activa cambió a %q.", current.title)
+		}
+	}
+	if job.VigilarBloqueo && locks.due(time.Now()) && screenLocked() {
+		return "Detenido: la pantalla se bloqueó."
+	}
+
+	if step.Tipo == stepWait {
+		if !headlessWaitForPixel(step) {
+			return fmt.Sprintf("Detenido: el pixel %d,%d no cambió a %s.", step.X, step.Y, step.Valor)
+		}
+		return ""
+	}
+	if !job.adaptivePacing() || step.Tipo == stepText {
+		runSyntheticStep(step, vars, keyDelay, job.Escritura)
+		return ""
+	}
+	before := pacingSnapshot(job)
+	runSyntheticStep(step, vars, keyDelay, job.Escritura)
+	if !waitForResponse(job, before) {
+		return "Cancelado."
+	}
+	return ""
+}
+
+// headlessWaitForPixel espera el color una sola vez; sin interfaz no hay a quién preguntar
+func headlessWaitForPixel(step MacroStep) bool {
+	deadline := time.Now().Add(step.Espera)
+	for time.Now().Before(deadline) {
+		select {
+		case <-cancel:
+			return false
+		default:
+		}
+		if strings.ToLower(robotgo.GetPixelColor(step.X, step.Y)) == step.Valor {
+			return true
+		}
+		time.Sleep(pixelPollInterval)
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestCliJobPath(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		wantOK  bool
+		wantErr bool
+	}{
+		{nil, "", false, false},
+		{[]string{"-v"}, "", false, false},
+		{[]string{"--autocopy", "lote.json"}, "lote.json", true, false},
+		{[]string{"--autocopy=C:\\lotes\\noche.json"}, "C:\\lotes\\noche.json", true, false},
+		{[]string{"--autocopy"}, "", true, true},
+		{[]string{"--autocopy="}, "", true, true},
+		{[]string{"--autocopy", "-v"}, "", true, true},
+	}
+
+	for _, tt := range tests {
+		got, ok, err := cliJobPath(tt.args)
+		if got != tt.want || ok != tt.wantOK || (err != nil) != tt.wantErr {
+			t.Errorf("cliJobPath(%q) = %q, %v, %v", tt.args, got, ok, err)
+		}
+	}
+}
+
+func TestValidateHeadlessJob(t *testing.T) {
+	tests := []struct {
+		job     AutocopyJob
+		wantErr bool
+	}{
+		{AutocopyJob{Series: "A1", VigilarVentana: true, Repeticiones: 2}, false},
+		{AutocopyJob{Series: "A1", PausarCada: 10}, true},
+		{AutocopyJob{Series: "A1", VerificarOCR: true}, true},
+	}
+	for _, tt := range tests {
+		if err := validateHeadlessJob(&tt.job); (err != nil) != tt.wantErr {
+			t.Errorf("validateHeadlessJob(%+v) error = %v, wantErr %v", tt.job, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// code128Patterns son los anchos de barra y espacio de cada símbolo de Code 128, en módulos,
+// empezando por una barra. Del 0 al 102 son datos, 103 a 105 los inicios A, B y C y 106 la
+// parada, que termina con una barra más.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+	code128Quiet  = 10 // Módulos en blanco a cada lado para que el lector encuentre el código
+)
+
+// digitRun cuenta los dígitos seguidos desde i
+func digitRun(text string, i int) int {
+	n := 0
+	for i+n < len(text) && text[i+n] >= '0' && text[i+n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// code128Values convierte text en los símbolos de Code 128, con el inicio y el dígito de
+// control pero sin la parada. Usa el juego B para letras y signos y el C, que guarda dos
+// dígitos por símbolo, para los tramos de 4 o más dígitos, así una guía como ZET123456
+// queda más corta y con barras más anchas. Solo admite ASCII imprimible.
+func code128Values(text string) ([]int, error) {
+	if text == "" {
+		return nil, fmt.Errorf("el número de guía está vacío")
+	}
+	for _, r := range text {
+		if r < ' ' || r > '~' {
+			return nil, fmt.Errorf("el número de guía solo puede tener letras sin tilde, números y signos; %q no entra en el código de barras", r)
+		}
+	}
+
+	var values []int
+	setC := digitRun(text, 0) >= 4
+	if setC {
+		values = append(values, code128StartC)
+	} else {
+		values = append(values, code128StartB)
+	}
+	for i := 0; i < len(text); {
+		if setC {
+			if digitRun(text, i) >= 2 {
+				values = append(values, int(text[i]-'0')*10+int(text[i+1]-'0'))
+				i += 2
+				continue
+			}
+			values = append(values, code128CodeB)
+			setC = false
+			continue
+		}
+		// En medio del texto pasar a C solo conviene con 6 dígitos o más; al final basta con 4
+		if run := digitRun(text, i); run >= 6 || (run >= 4 && i+run == len(text)) {
+			if run%2 == 1 {
+				values = append(values, int(text[i])-' ')
+				i++
+			}
+			values = append(values, code128CodeC)
+			setC = true
+			continue
+		}
+		values = append(values, int(text[i])-' ')
+		i++
+	}
+
+	sum := values[0]
+	for i, v := range values[1:] {
+		sum += v * (i + 1)
+	}
+	return append(values, sum%103), nil
+}
+
+// code128Widths devuelve los anchos en módulos de barras y espacios alternados, empezando
+// por una barra y terminando con la parada
+func code128Widths(text string) ([]int, error) {
+	values, err := code128Values(text)
+	if err != nil {
+		return nil, err
+	}
+	var widths []int
+	for _, v := range append(values, code128Stop) {
+		for _, c := range code128Patterns[v] {
+			widths = append(widths, int(c-'0'))
+		}
+	}
+	return widths, nil
+}
+
+// drawCode128 dibuja text como Code 128 centrado en el ancho w, con el margen en blanco que
+// piden los lectores a cada lado
+func drawCode128(pdf *gofpdf.Fpdf, x, y, w, h float64, text string) error {
+	widths, err := code128Widths(text)
+	if err != nil {
+		return err
+	}
+	modules := 2 * code128Quiet
+	for _, width := range widths {
+		modules += width
+	}
+	module := w / float64(modules)
+	pos := x + code128Quiet*module
+	for i, width := range widths {
+		if i%2 == 0 {
+			pdf.Rect(pos, y, float64(width)*module, h, "F")
+		}
+		pos += float64(width) * module
+	}
+	return nil
+}
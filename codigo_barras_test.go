@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	seen := map[string]bool{}
+	for v, pattern := range code128Patterns {
+		modules, bars := 0, 0
+		for i, c := range pattern {
+			modules += int(c - '0')
+			if i%2 == 0 {
+				bars += int(c - '0')
+			}
+		}
+		want := 11
+		if v == code128Stop {
+			want = 13
+		}
+		if modules != want || bars%2 != 0 || seen[pattern] {
+			t.Errorf("el símbolo %d (%s) no es válido: %d módulos, %d de barra", v, pattern, modules, bars)
+		}
+		seen[pattern] = true
+	}
+	if len(code128Patterns) != 107 {
+		t.Errorf("hay %d símbolos, deberían ser 107", len(code128Patterns))
+	}
+}
+
+func TestCode128Values(t *testing.T) {
+	tests := map[string][]int{
+		"PJJ123C":    {104, 48, 42, 42, 17, 18, 19, 35, 55},
+		"ZET123456":  {104, 58, 37, 52, 99, 12, 34, 56, 2},
+		"1234567":    {105, 12, 34, 56, 100, 23, 44},
+		"GUIA-12345": {104, 39, 53, 41, 33, 13, 17, 99, 23, 45, 99},
+	}
+	for text, want := range tests {
+		got, err := code128Values(text)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("code128Values(%q) = %v, %v; quería %v", text, got, err, want)
+		}
+	}
+	for _, text := range []string{"", "GUÍA1", "ZET\t1"} {
+		if _, err := code128Values(text); err == nil {
+			t.Errorf("code128Values(%q) debería dar error", text)
+		}
+	}
+}
+
+func TestCode128Widths(t *testing.T) {
+	widths, err := code128Widths("ZET123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modules := 0
+	for _, w := range widths {
+		modules += w
+	}
+	// 9 símbolos de 11 módulos más la parada de 13
+	if len(widths) != 9*6+7 || modules != 9*11+13 {
+		t.Errorf("code128Widths dio %d anchos y %d módulos", len(widths), modules)
+	}
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Estados de un trabajo en la cola
+const (
+	queuePending     = "Pendiente"
+	queueActive      = "En curso"
+	queueDone        = "Completado"
+	queueInterrupted = "Interrumpido"
+)
+
+const defaultQueuePause = 10 // Segundos entre un trabajo y el siguiente
+
+// queuedJob es un trabajo preparado que espera su turno en la cola
+type queuedJob struct {
+	Job    AutocopyJob
+	Estado string
+}
+
+// jobQueue guarda los trabajos en cola; la ejecución los recorre desde su propio goroutine
+type jobQueue struct {
+	mu    sync.Mutex
+	items []*queuedJob
+}
+
+func (q *jobQueue) add(job AutocopyJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items = append(q.items, &queuedJob{Job: job, Estado: queuePending})
+}
+
+func (q *jobQueue) remove(i int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if i < 0 || i >= len(q.items) || q.items[i].Estado == queueActive {
+		return
+	}
+	q.items = append(q.items[:i], q.items[i+1:]...)
+}
+
+// clearFinished quita los trabajos que ya se ejecutaron
+func (q *jobQueue) clearFinished() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	kept := q.items[:0]
+	for _, item := range q.items {
+		if item.Estado == queuePending || item.Estado == queueActive {
+			kept = append(kept, item)
+		}
+	}
+	q.items = kept
+}
+
+// next devuelve el primer trabajo pendiente y lo marca en curso
+func (q *jobQueue) next() *queuedJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, item := range q.items {
+		if item.Estado == queuePending {
+			item.Estado = queueActive
+			return item
+		}
+	}
+	return nil
+}
+
+// pending cuenta los trabajos que aún no se ejecutaron
+func (q *jobQueue) pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, item := range q.items {
+		if item.Estado == queuePending {
+			n++
+		}
+	}
+	return n
+}
+
+func (q *jobQueue) setState(item *queuedJob, state string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item.Estado = state
+}
+
+func (q *jobQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// describe arma la línea que se muestra en la lista
+func (q *jobQueue) describe(i int) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if i < 0 || i >= len(q.items) {
+		return ""
+	}
+	item := q.items[i]
+	return fmt.Sprintf("%d. %s · %s", i+1, queueJobSummary(&item.Job), item.Estado)
+}
+
+// queueJobSummary resume un trabajo: series a escribir, fecha y velocidad
+func queueJobSummary(job *AutocopyJob) string {
+	date := job.Fecha
+	if date == "" {
+		date = "sin fecha general"
+	}
+	return fmt.Sprintf("%d series · %s · %s", len(job.seriesList())*job.iterations(), date, job.Velocidad)
+}
+
+// waitQueuePause espera entre dos trabajos mostrando el tiempo restante; devuelve false si se canceló
+func waitQueuePause(seconds int, statusLabel *widget.Label) bool {
+	for i := seconds; i > 0; i-- {
+		statusLabel.SetText(fmt.Sprintf("Siguiente trabajo de la cola en %d...", i))
+		select {
+		case <-cancel:
+			return false
+		case <-time.After(time.Second):
+		}
+	}
+	return true
+}
+
+// runQueue ejecuta los trabajos pendientes uno tras otro. Si uno no termina (cancelado
+// o con error) la cola se detiene y los siguientes quedan pendientes.
+func (a *Autocopiador) runQueue() {
+	if a.running {
+		dialog.ShowError(fmt.Errorf("ya hay un autocopiado programado o en curso, cancélalo antes de iniciar otro"), a.window)
+		return
+	}
+	if a.recorder != nil {
+		dialog.ShowError(fmt.Errorf("detén la grabación de la secuencia antes de iniciar"), a.window)
+		return
+	}
+	pause, err := strconv.Atoi(strings.TrimSpace(a.queuePauseInput.Text))
+	if err != nil || pause < 0 {
+		dialog.ShowError(fmt.Errorf("la pausa entre trabajos debe ser un número de segundos mayor o igual a 0"), a.window)
+		return
+	}
+	if a.queue.pending() == 0 {
+		dialog.ShowInformation("Cola vacía", "No hay trabajos pendientes en la cola.", a.window)
+		return
+	}
+
+	a.setRunning(true)
+	a.copiedCounter.SetText("Copiadas: 0 / 0")
+	cancel = make(chan struct{})
+	a.showOverlay()
+
+	go func() {
+		finished := true
+		for first := true; ; first = false {
+			item := a.queue.next()
+			if item == nil {
+				break
+			}
+			if !first && !waitQueuePause(pause, a.statusLabel) {
+				a.queue.setState(item, queuePending)
+				finished = false
+				break
+			}
+			a.refreshQueue()
+
+			job := item.Job
+			completed := a.autocopiar(&job)
+			if completed {
+				a.queue.setState(item, queueDone)
+			} else {
+				a.queue.setState(item, queueInterrupted)
+			}
+			a.refreshQueue()
+			if !completed {
+				finished = false
+				break
+			}
+		}
+
+		fyne.Do(func() {
+			a.setRunning(false)
+			a.closeOverlay()
+			a.queueList.Refresh()
+			if finished {
+				a.statusLabel.SetText("Estado: Cola de trabajos terminada.")
+			}
+		})
+	}()
+}
+
+// refreshQueue actualiza la lista; se puede llamar desde cualquier goroutine
+func (a *Autocopiador) refreshQueue() {
+	fyne.Do(func() {
+		a.queueList.Refresh()
+	})
+}
+
+func (a *Autocopiador) createQueueCard() *widget.Card {
+	selected := -1
+
+	a.queueList = widget.NewList(
+		a.queue.len,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(a.queue.describe(id))
+		},
+	)
+	a.queueList.OnSelected = func(id widget.ListItemID) {
+		selected = id
+	}
+
+	addButton := widget.NewButton("➕ Agregar formulario", func() {
+		job, err := a.buildJob()
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		a.queue.add(*job)
+		a.queueList.Refresh()
+		a.statusLabel.SetText(fmt.Sprintf("Estado: Trabajo agregado a la cola (%d en total)", a.queue.len()))
+	})
+
+	removeButton := widget.NewButton("🗑️ Quitar", func() {
+		a.queue.remove(selected)
+		selected = -1
+		a.queueList.UnselectAll()
+		a.queueList.Refresh()
+	})
+
+	clearButton := widget.NewButton("🧹 Limpiar terminados", func() {
+		a.queue.clearFinished()
+		selected = -1
+		a.queueList.UnselectAll()
+		a.queueList.Refresh()
+	})
+
+	runButton := widget.NewButton("▶️ Ejecutar cola", a.runQueue)
+	runButton.Importance = widget.HighImportance
+	a.runControls = append(a.runControls, runButton)
+
+	a.queuePauseInput = widget.NewEntry()
+	a.queuePauseInput.SetText(strconv.Itoa(defaultQueuePause))
+
+	listScroll := container.NewScroll(a.queueList)
+	listScroll.SetMinSize(fyne.NewSize(350, 120))
+
+	return widget.NewCard("📋 Cola de trabajos", "",
+		container.NewVBox(
+			listScroll,
+			container.NewBorder(nil, nil, widget.NewLabel("Pausa entre trabajos:"), widget.NewLabel("seg"), a.queuePauseInput),
+			container.NewGridWithColumns(2, addButton, removeButton, clearButton, runButton),
+		),
+	)
+}
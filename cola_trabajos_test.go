@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestJobQueueOrder(t *testing.T) {
+	var q jobQueue
+	q.add(AutocopyJob{Series: "A1 A2"})
+	q.add(AutocopyJob{Series: "B1"})
+	q.add(AutocopyJob{Series: "C1"})
+
+	first := q.next()
+	if first == nil || first.Job.Series != "A1 A2" || first.Estado != queueActive {
+		t.Fatalf("next() = %+v, se esperaba el primer trabajo en curso", first)
+	}
+	q.remove(0) // El trabajo en curso no se puede quitar
+	if q.len() != 3 {
+		t.Errorf("len() = %d tras quitar el trabajo en curso, se esperaba 3", q.len())
+	}
+	q.setState(first, queueDone)
+
+	q.remove(1) // Quita B1
+	second := q.next()
+	if second == nil || second.Job.Series != "C1" {
+		t.Fatalf("next() = %+v, se esperaba C1", second)
+	}
+	q.setState(second, queueInterrupted)
+
+	if q.next() != nil || q.pending() != 0 {
+		t.Error("no deberían quedar trabajos pendientes")
+	}
+
+	q.add(AutocopyJob{Series: "D1"})
+	q.clearFinished()
+	if q.len() != 1 || q.pending() != 1 {
+		t.Errorf("clearFinished() dejó %d trabajos (%d pendientes), se esperaba solo D1", q.len(), q.pending())
+	}
+}
+
+func TestQueueJobSummary(t *testing.T) {
+	tests := []struct {
+		job  AutocopyJob
+		want string
+	}{
+		{AutocopyJob{Series: "A1\nA2\nA3", Fecha: "05/03/2024", Velocidad: "Normal"}, "3 series · 05/03/2024 · Normal"},
+		{AutocopyJob{Series: "A1 A2", Velocidad: "Rápido", Repeticiones: 3}, "6 series · sin fecha general · Rápido"},
+		{AutocopyJob{Series: "A1 B1 A2", Incluir: "A*", Velocidad: "Lento"}, "2 series · sin fecha general · Lento"},
+	}
+	for _, tt := range tests {
+		if got := queueJobSummary(&tt.job); got != tt.want {
+			t.Errorf("queueJobSummary(%q) = %q, se esperaba %q", tt.job.Series, got, tt.want)
+		}
+	}
+}
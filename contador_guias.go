@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+const guiaCounterFile = "contador_guias.json" // Junto al programa, como empresas.json
+
+// guiaCounterMu evita que dos rótulos generados a la vez tomen el mismo número
+var guiaCounterMu sync.Mutex
+
+// loadGuiaCounters lee el último número de guía usado por cada empresa. Si el archivo está
+// dañado devuelve error en vez de empezar de cero, que repetiría guías ya entregadas.
+func loadGuiaCounters() (map[string]int, error) {
+	counters := map[string]int{}
+	data, err := ioutil.ReadFile(guiaCounterFile)
+	if os.IsNotExist(err) {
+		return counters, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &counters); err != nil {
+		return nil, fmt.Errorf("%s está dañado; revísalo antes de generar más guías: %v", guiaCounterFile, err)
+	}
+	return counters, nil
+}
+
+// saveGuiaCounters escribe un archivo aparte y lo renombra, así un corte de luz no deja el
+// contador a medias
+func saveGuiaCounters(counters map[string]int) error {
+	data, err := json.MarshalIndent(counters, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := guiaCounterFile + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, guiaCounterFile)
+}
+
+// reserveGuias aparta count números seguidos para la empresa y devuelve el primero. El
+// contador queda guardado antes de usarlos, así no se repiten aunque el programa se cierre.
+func reserveGuias(empresa string, count int) (int, error) {
+	guiaCounterMu.Lock()
+	defer guiaCounterMu.Unlock()
+	counters, err := loadGuiaCounters()
+	if err != nil {
+		return 0, err
+	}
+	first := counters[empresa] + 1
+	counters[empresa] += count
+	if err := saveGuiaCounters(counters); err != nil {
+		return 0, fmt.Errorf("no se pudo guardar el contador de guías: %v", err)
+	}
+	return first, nil
+}
+
+// nextGuia aparta la siguiente guía de la empresa
+func nextGuia(empresa string) (string, error) {
+	n, err := reserveGuias(empresa, 1)
+	if err != nil {
+		return "", err
+	}
+	return formatGuia(empresa, n, time.Now()), nil
+}
+
+// peekGuiaNumber es el número que tocará a la empresa, sin apartarlo
+func peekGuiaNumber(empresa string) (int, error) {
+	guiaCounterMu.Lock()
+	defer guiaCounterMu.Unlock()
+	counters, err := loadGuiaCounters()
+	if err != nil {
+		return 0, err
+	}
+	return counters[empresa] + 1, nil
+}
+
+// peekGuia es la guía que tocará a la empresa, para la vista previa. Vacía si no se puede
+// leer el contador.
+func peekGuia(empresa string) string {
+	n, err := peekGuiaNumber(empresa)
+	if err != nil {
+		return ""
+	}
+	return formatGuia(empresa, n, time.Now())
+}
+
+// ensureGuia le pone al rótulo del formulario la siguiente guía de su empresa si no tiene
+// una; queda escrita en el campo para que se vea cuál se usó
+func (r *RotuloGenerator) ensureGuia() error {
+	if r.data.NumeroGuia != "" {
+		return nil
+	}
+	guia, err := nextGuia(r.data.Empresa)
+	if err != nil {
+		return err
+	}
+	r.inputs["numeroGuia"].SetText(guia)
+	r.data.NumeroGuia = guia
+	r.autoGuia = true
+	return nil
+}
+
+// releaseGuia borra del campo la guía que apartó ensureGuia una vez usada, para que el
+// siguiente rótulo tome la que sigue. Una guía escrita a mano se deja como está.
+func (r *RotuloGenerator) releaseGuia(used string) {
+	if r.autoGuia && r.data.NumeroGuia == used {
+		r.inputs["numeroGuia"].SetText("")
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+func TestReserveGuias(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if got := peekGuia("ZETTACOM"); got != "ZET-000001" {
+		t.Errorf("sin contador la primera guía debería ser ZET-000001, no %s", got)
+	}
+	if got, err := nextGuia("ZETTACOM"); err != nil || got != "ZET-000001" {
+		t.Errorf("nextGuia = %s, %v", got, err)
+	}
+	if first, err := reserveGuias("ZETTACOM", 3); err != nil || first != 2 {
+		t.Errorf("reserveGuias = %d, %v", first, err)
+	}
+	// Cada empresa lleva su propio contador y la vista previa no aparta números
+	if got, _ := nextGuia("COMSITEC"); got != "COM-000001" {
+		t.Errorf("COMSITEC empezó en %s", got)
+	}
+	if peekGuia("ZETTACOM") != "ZET-000005" || peekGuia("ZETTACOM") != "ZET-000005" {
+		t.Errorf("peekGuia = %s", peekGuia("ZETTACOM"))
+	}
+
+	// El contador sobrevive a un reinicio porque se lee del archivo
+	counters, err := loadGuiaCounters()
+	if err != nil || counters["ZETTACOM"] != 4 || counters["COMSITEC"] != 1 {
+		t.Errorf("contadores guardados = %v, %v", counters, err)
+	}
+}
+
+func TestReserveGuiasConcurrent(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			guia, err := nextGuia("ZETTACOM")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[guia] {
+				t.Errorf("la guía %s salió dos veces", guia)
+			}
+			seen[guia] = true
+		}()
+	}
+	wg.Wait()
+	if got := peekGuia("ZETTACOM"); got != "ZET-000021" {
+		t.Errorf("después de 20 guías toca %s", got)
+	}
+}
+
+func TestReserveGuiasDamagedFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	ioutil.WriteFile(guiaCounterFile, []byte("{no es json"), 0644)
+	if _, err := nextGuia("ZETTACOM"); err == nil {
+		t.Error("con el contador dañado no debería entregar guías")
+	}
+	if got := peekGuia("ZETTACOM"); got != "" {
+		t.Errorf("peekGuia con el contador dañado = %q", got)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	hook "github.com/robotn/gohook"
+)
+
+// Margen tras cada tecla simulada: el hook recibe las propias pulsaciones con algo de retraso
+const syntheticKeyGrace = 150 * time.Millisecond
+
+// inputWatcher detecta teclas pulsadas por el usuario durante el autocopiado. Las teclas
+// que envía el propio proceso también llegan al hook, así que solo cuentan las que
+// aparecen fuera de los pasos simulados y fuera de las pausas con diálogo.
+type inputWatcher struct {
+	mu         sync.Mutex
+	active     bool
+	paused     int
+	synthetic  int
+	quietUntil time.Time
+	ignore     map[string]bool // Teclas de cancelación: ya tienen su propio efecto
+	detected   string
+}
+
+// userInput es el detector del autocopiado en curso
+var userInput = &inputWatcher{}
+
+func (w *inputWatcher) start(ignore map[string]bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.active = true
+	w.paused = 0
+	w.synthetic = 0
+	w.quietUntil = time.Time{}
+	w.ignore = ignore
+	w.detected = ""
+}
+
+// stop desactiva el detector y devuelve la tecla del usuario que lo disparó, si hubo
+func (w *inputWatcher) stop() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.active = false
+	return w.detected
+}
+
+// pause y resume envuelven las esperas en que el usuario sí puede usar el teclado
+func (w *inputWatcher) pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.paused++
+}
+
+func (w *inputWatcher) resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.paused > 0 {
+		w.paused--
+	}
+}
+
+// beginSynthetic y endSynthetic envuelven los pasos que envían teclas
+func (w *inputWatcher) beginSynthetic() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.synthetic++
+}
+
+func (w *inputWatcher) endSynthetic() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.synthetic > 0 {
+		w.synthetic--
+	}
+	w.quietUntil = time.Now().Add(syntheticKeyGrace)
+}
+
+// keyPressed decide si la tecla vino del usuario; la primera que lo hace queda registrada
+func (w *inputWatcher) keyPressed(key string, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.active || w.paused > 0 || w.synthetic > 0 || now.Before(w.quietUntil) || w.ignore[key] {
+		return false
+	}
+	if w.detected == "" {
+		w.detected = key
+	}
+	return true
+}
+
+// handle recibe los eventos del hook y cancela el proceso ante una tecla del usuario
+func (w *inputWatcher) handle(ev hook.Event) {
+	if ev.Kind != hook.KeyDown {
+		return
+	}
+	if !w.keyPressed(hookKeyName(ev.Keycode), time.Now()) {
+		return
+	}
+	select {
+	case <-cancel:
+	default:
+		close(cancel)
+	}
+}
+
+// cancelKeyNames reúne las teclas de las combinaciones de cancelación
+func cancelKeyNames(combos [][]string) map[string]bool {
+	names := map[string]bool{}
+	for _, combo := range combos {
+		for _, key := range combo {
+			names[key] = true
+		}
+	}
+	return names
+}
+
+// watchUserInput activa el detector para la ejecución; la función devuelta lo detiene
+// y, si se disparó, deja el motivo en el estado
+func (a *Autocopiador) watchUserInput() func() {
+	userInput.start(cancelKeyNames(activeCancelKeys()))
+	setHookObserver(userInput.handle)
+
+	return func() {
+		setHookObserver(nil)
+		if key := userInput.stop(); key != "" {
+			a.statusLabel.SetText(fmt.Sprintf("Estado: Cancelado: se pulsó %q durante el autocopiado", key))
+		}
+	}
+}
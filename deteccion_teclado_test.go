@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInputWatcherKeyPressed(t *testing.T) {
+	now := time.Now()
+	w := &inputWatcher{}
+
+	if w.keyPressed("a", now) {
+		t.Error("un detector inactivo no debería disparar")
+	}
+
+	w.start(map[string]bool{"esc": true})
+	if w.keyPressed("esc", now) {
+		t.Error("las teclas de cancelación no deberían contar como tecleo del usuario")
+	}
+
+	w.beginSynthetic()
+	if w.keyPressed("a", now) {
+		t.Error("las teclas simuladas no deberían disparar")
+	}
+	w.endSynthetic()
+	if w.keyPressed("a", time.Now()) {
+		t.Error("las teclas simuladas que llegan con retraso no deberían disparar")
+	}
+
+	w.pause()
+	if w.keyPressed("a", now.Add(time.Second)) {
+		t.Error("durante una pausa el usuario puede teclear")
+	}
+	w.resume()
+
+	if !w.keyPressed("x", now.Add(time.Second)) {
+		t.Error("una tecla fuera de los pasos simulados debería disparar")
+	}
+	w.keyPressed("y", now.Add(2*time.Second))
+	if got := w.stop(); got != "x" {
+		t.Errorf("stop() = %q, se esperaba la primera tecla %q", got, "x")
+	}
+}
+
+func TestCancelKeyNames(t *testing.T) {
+	got := cancelKeyNames([][]string{{"esc"}, {"ctrl", "alt", "x"}})
+	for _, key := range []string{"esc", "ctrl", "alt", "x"} {
+		if !got[key] {
+			t.Errorf("falta %q en %v", key, got)
+		}
+	}
+	if len(got) != 4 {
+		t.Errorf("cancelKeyNames() = %v, se esperaban 4 teclas", got)
+	}
+}
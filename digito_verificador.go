@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Validaciones de dígito verificador disponibles
+const (
+	checkDigitNone  = "Sin validar"
+	checkDigitMod10 = "Módulo 10 (Luhn)"
+	checkDigitMod11 = "Módulo 11"
+)
+
+var checkDigitOptions = []string{checkDigitNone, checkDigitMod10, checkDigitMod11}
+
+// Series inválidas que se listan en el aviso antes de resumir el resto
+const maxListedInvalid = 20
+
+// serieDigits devuelve los dígitos de la serie, ignorando prefijos como "ZET" y guiones.
+// En módulo 11 el verificador puede ser "K" (resto 10).
+func serieDigits(serie string) string {
+	var b strings.Builder
+	for _, r := range serie {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// luhnValid comprueba el último dígito con el algoritmo de Luhn (módulo 10)
+func luhnValid(digits string) bool {
+	if len(digits) < 2 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// mod11CheckChar calcula el verificador módulo 11 con pesos 2 a 7 desde la derecha
+func mod11CheckChar(body string) byte {
+	sum, weight := 0, 2
+	for i := len(body) - 1; i >= 0; i-- {
+		sum += int(body[i]-'0') * weight
+		weight++
+		if weight > 7 {
+			weight = 2
+		}
+	}
+	switch check := 11 - sum%11; check {
+	case 11:
+		return '0'
+	case 10:
+		return 'K'
+	default:
+		return byte('0' + check)
+	}
+}
+
+// validCheckDigit indica si la serie cumple el dígito verificador elegido
+func validCheckDigit(serie, mode string) bool {
+	switch mode {
+	case checkDigitMod10:
+		return luhnValid(serieDigits(serie))
+	case checkDigitMod11:
+		upper := strings.ToUpper(strings.TrimSpace(serie))
+		if strings.HasSuffix(upper, "K") {
+			body := serieDigits(upper)
+			return body != "" && mod11CheckChar(body) == 'K'
+		}
+		digits := serieDigits(upper)
+		if len(digits) < 2 {
+			return false
+		}
+		return mod11CheckChar(digits[:len(digits)-1]) == digits[len(digits)-1]
+	}
+	return true
+}
+
+// invalidCheckDigits devuelve las series que no cumplen el dígito verificador
+func invalidCheckDigits(records []SerieRecord, mode string) []string {
+	var invalid []string
+	for _, rec := range records {
+		if !validCheckDigit(rec.Serie, mode) {
+			invalid = append(invalid, rec.Serie)
+		}
+	}
+	return invalid
+}
+
+// confirmCheckDigits lista las series con dígito verificador inválido antes de escribir
+// nada; onContinue se llama si no hay inválidas o si el usuario decide seguir igual
+func (a *Autocopiador) confirmCheckDigits(job *AutocopyJob, records []SerieRecord, onContinue func()) {
+	if job.DigitoVerificador == "" {
+		onContinue()
+		return
+	}
+	invalid := invalidCheckDigits(records, job.DigitoVerificador)
+	if len(invalid) == 0 {
+		onContinue()
+		return
+	}
+
+	listed := invalid
+	if len(listed) > maxListedInvalid {
+		listed = listed[:maxListedInvalid]
+	}
+	message := fmt.Sprintf("%d series no cumplen el dígito verificador (%s), puede haber dígitos cambiados de lugar:\n\n%s",
+		len(invalid), job.DigitoVerificador, strings.Join(listed, "\n"))
+	if len(invalid) > len(listed) {
+		message += fmt.Sprintf("\n... y %d más", len(invalid)-len(listed))
+	}
+	message += "\n\n¿Continuar de todos modos?"
+
+	confirm := dialog.NewConfirm("⚠️ Dígito verificador inválido", message, func(ok bool) {
+		if ok {
+			onContinue()
+		}
+	}, a.window)
+	confirm.SetConfirmText("Continuar igual")
+	confirm.SetDismissText("Cancelar")
+	confirm.Show()
+}
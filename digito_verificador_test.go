@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidCheckDigit(t *testing.T) {
+	tests := []struct {
+		serie string
+		mode  string
+		want  bool
+	}{
+		{"79927398713", checkDigitMod10, true},
+		{"79927398731", checkDigitMod10, false}, // dígitos transpuestos
+		{"ZET-79927398713", checkDigitMod10, true},
+		{"5", checkDigitMod10, false},
+		{"12343", checkDigitMod11, true},
+		{"12345", checkDigitMod11, false},
+		{"ZET01546", checkDigitMod11, true},
+		{"104K", checkDigitMod11, true},
+		{"104k", checkDigitMod11, true},
+		{"1090", checkDigitMod11, true},
+		{"105K", checkDigitMod11, false},
+		{"cualquiera", checkDigitNone, true},
+	}
+
+	for _, tt := range tests {
+		if got := validCheckDigit(tt.serie, tt.mode); got != tt.want {
+			t.Errorf("validCheckDigit(%q, %q) = %v, se esperaba %v", tt.serie, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidCheckDigits(t *testing.T) {
+	records := []SerieRecord{{Serie: "12343"}, {Serie: "12334"}, {Serie: "1090"}}
+	got := invalidCheckDigits(records, checkDigitMod11)
+	if want := []string{"12334"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("invalidCheckDigits = %v, se esperaba %v", got, want)
+	}
+}
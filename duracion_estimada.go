@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Tamaño de lote a partir del cual se pide confirmar con la duración estimada
+const defaultLongRunThreshold = 200
+
+// estimateRecord calcula cuánto tarda un registro con las pausas del preset. Las esperas
+// de color y el ritmo adaptativo dependen de la aplicación destino y no se cuentan.
+func estimateRecord(steps, terminator []MacroStep, vars map[string]string, preset SpeedPreset, espera time.Duration) time.Duration {
+	stepDelay, rowSettle := preset.Delay, preset.RowSettle
+	if espera > 0 {
+		stepDelay, rowSettle = espera, espera
+	}
+	keyDelay := time.Duration(preset.KeyDelay) * time.Millisecond
+
+	var d time.Duration
+	for _, step := range steps {
+		switch step.Tipo {
+		case stepText:
+			d += time.Duration(utf8.RuneCountInString(expandPlaceholders(step.Valor, vars))) * keyDelay
+		case stepKey:
+			d += keyDelay
+		}
+		if step.Tipo != stepWait {
+			d += stepDelay
+		}
+	}
+	d += time.Duration(len(terminator)) * keyDelay
+	return d + rowSettle
+}
+
+// estimateRun suma la duración estimada de todos los registros de un trabajo
+func estimateRun(job *AutocopyJob, records []SerieRecord) (time.Duration, error) {
+	preset, ok := findSpeedPreset(job.Velocidad)
+	if !ok {
+		preset, _ = findSpeedPreset(defaultSpeedPreset)
+	}
+	steps, err := parseMacro(job.Macro)
+	if err != nil {
+		return 0, err
+	}
+	terminator, err := parseTerminator(job.Terminador, job.TerminadorPersonalizado)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var total time.Duration
+	for i, rec := range records {
+		vars := macroVars(rec, job.Fecha, i, len(records), now)
+		total += estimateRecord(steps, terminator, vars, preset, rec.Espera)
+	}
+	return total * time.Duration(job.iterations()), nil
+}
+
+// formatRemaining muestra una duración como MM:SS, o H:MM:SS si pasa de una hora
+func formatRemaining(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	seconds := int(d.Round(time.Second) / time.Second)
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// remainingTime proyecta lo que falta con el ritmo medido; antes del primer registro
+// usa la estimación inicial
+func remainingTime(estimated, elapsed time.Duration, copied, total int) time.Duration {
+	if copied <= 0 {
+		return estimated
+	}
+	return elapsed / time.Duration(copied) * time.Duration(total-copied)
+}
+
+// parseLongRunThreshold interpreta el umbral de confirmación; vacío o 0 lo desactiva
+func parseLongRunThreshold(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("el tamaño de lote a confirmar debe ser un entero mayor o igual a 0")
+	}
+	return n, nil
+}
+
+// confirmLongRun pide confirmación con la duración estimada si el lote supera el umbral
+func (a *Autocopiador) confirmLongRun(job *AutocopyJob, records []SerieRecord, onContinue func()) {
+	threshold, err := parseLongRunThreshold(a.longRunInput.Text)
+	if err != nil {
+		dialog.ShowError(err, a.window)
+		return
+	}
+	total := len(records) * job.iterations()
+	if threshold == 0 || total <= threshold {
+		onContinue()
+		return
+	}
+
+	estimated, err := estimateRun(job, records)
+	if err != nil {
+		dialog.ShowError(err, a.window)
+		return
+	}
+	start := time.Now().Add(time.Duration(a.countdown) * time.Second)
+	if !job.Programado.IsZero() {
+		start = job.Programado
+	}
+
+	confirm := dialog.NewConfirm("⏱️ Lote largo",
+		fmt.Sprintf("Se escribirán %d series.\n\nDuración estimada: ~%s\nTerminaría cerca de las %s\n\n"+
+			"La estimación no incluye pausas por lote, esperas de color ni confirmaciones.\n¿Iniciar?",
+			total, formatRemaining(estimated), start.Add(estimated).Format("15:04")),
+		func(ok bool) {
+			if ok {
+				onContinue()
+			}
+		}, a.window)
+	confirm.SetConfirmText("Iniciar")
+	confirm.SetDismissText("Cancelar")
+	confirm.Show()
+}
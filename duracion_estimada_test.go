@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateRecord(t *testing.T) {
+	preset := SpeedPreset{Delay: 100 * time.Millisecond, KeyDelay: 10, RowSettle: 50 * time.Millisecond}
+	steps := []MacroStep{
+		{Tipo: stepText, Valor: "{serie}"},
+		{Tipo: stepKey, Valor: "tab"},
+		{Tipo: stepWait, X: 1, Y: 1, Valor: "ffffff"},
+		{Tipo: stepClick, X: 5, Y: 5, Valor: "left"},
+	}
+	terminator := []MacroStep{{Tipo: stepKey, Valor: "down"}}
+	vars := map[string]string{"serie": "ZET001"}
+
+	// 6 caracteres + tab + abajo a 10 ms, 3 pausas entre acciones y el asiento de fila
+	want := 80*time.Millisecond + 300*time.Millisecond + 50*time.Millisecond
+	if got := estimateRecord(steps, terminator, vars, preset, 0); got != want {
+		t.Errorf("estimateRecord() = %v, se esperaba %v", got, want)
+	}
+
+	// La pausa propia del registro reemplaza las del preset
+	want = 80*time.Millisecond + 3*time.Second + time.Second
+	if got := estimateRecord(steps, terminator, vars, preset, time.Second); got != want {
+		t.Errorf("estimateRecord() con pausa propia = %v, se esperaba %v", got, want)
+	}
+}
+
+func TestFormatRemaining(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00"},
+		{-time.Second, "00:00"},
+		{59*time.Second + 600*time.Millisecond, "01:00"},
+		{12*time.Minute + 5*time.Second, "12:05"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+	}
+	for _, tt := range tests {
+		if got := formatRemaining(tt.d); got != tt.want {
+			t.Errorf("formatRemaining(%v) = %q, se esperaba %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRemainingTime(t *testing.T) {
+	if got := remainingTime(time.Minute, 0, 0, 10); got != time.Minute {
+		t.Errorf("sin registros copiados = %v, se esperaba la estimación", got)
+	}
+	if got, want := remainingTime(time.Minute, 20*time.Second, 4, 10), 30*time.Second; got != want {
+		t.Errorf("remainingTime() = %v, se esperaba %v", got, want)
+	}
+	if got := remainingTime(time.Minute, 20*time.Second, 10, 10); got != 0 {
+		t.Errorf("al terminar = %v, se esperaba 0", got)
+	}
+}
+
+func TestParseLongRunThreshold(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{" 250 ", 250, false},
+		{"0", 0, false},
+		{"-1", 0, true},
+		{"muchas", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseLongRunThreshold(tt.text)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseLongRunThreshold(%q) = %d, %v", tt.text, got, err)
+		}
+	}
+}
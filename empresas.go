@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const empresasFile = "empresas.json" // Junto al programa, como los logos
+
+// Empresa son los datos de una empresa que envía rótulos. La clave con la que se guarda
+// (ZETTACOM, COMSITEC...) es la que aparece para elegirla y la que empieza los números de
+// guía generados, salvo que la máscara diga otra cosa.
+type Empresa struct {
+	Nombre     string
+	Direccion  string
+	Telefono   string
+	NeedQR     bool
+	Color      struct{ R, G, B int }
+	Logo       string      // Ruta del logo PNG o JPG; vacía si no tiene
+	Remitentes []Remitente // Otras sucursales o contactos que envían a nombre de la empresa
+	Mascara    string      // Formato de las guías generadas, como COM-{YYYYMMDD}-{####}; vacía es el de siempre
+}
+
+// empresaKeyRegex es la clave: mayúsculas y números sin espacios, porque las tres primeras
+// letras van en el número de guía y este en el código de barras
+var empresaKeyRegex = regexp.MustCompile(`^[A-Z0-9]{3,20}$`)
+
+// defaultEmpresas son las empresas de siempre, que se usan mientras no haya empresas.json
+func defaultEmpresas() map[string]Empresa {
+	zettacom := Empresa{
+		Nombre:    "ZETTACOM S.A.C",
+		Direccion: "Av. Giraldez 242, Huancayo, Junín",
+		Telefono:  "+51 964 789 123",
+		Logo:      zettacomLogo,
+	}
+	zettacom.Color.R, zettacom.Color.G, zettacom.Color.B = 0, 51, 102 // Azul marino
+	comsitec := Empresa{
+		Nombre:    "COMSITEC S.A.C",
+		Direccion: "Av. Giraldez 242, Huancayo, Junín",
+		Telefono:  "+51 964 789 456",
+		NeedQR:    true,
+		Logo:      comsitecLogo,
+	}
+	comsitec.Color.R, comsitec.Color.G, comsitec.Color.B = 180, 20, 40 // Rojo corporativo
+	return map[string]Empresa{"ZETTACOM": zettacom, "COMSITEC": comsitec}
+}
+
+// defaultEmpresa es la que aparece elegida al abrir o limpiar el rótulo
+func defaultEmpresa() string {
+	if _, ok := empresasData["ZETTACOM"]; ok {
+		return "ZETTACOM"
+	}
+	return companyNames()[0]
+}
+
+// validateEmpresa revisa una empresa antes de guardarla; key se pasa ya en mayúsculas
+func validateEmpresa(key string, e Empresa) error {
+	if !empresaKeyRegex.MatchString(key) {
+		return fmt.Errorf("la clave debe tener de 3 a 20 letras sin tilde o números, sin espacios, como ZETTACOM")
+	}
+	if strings.TrimSpace(e.Nombre) == "" {
+		return fmt.Errorf("la razón social no puede estar vacía")
+	}
+	for _, c := range []int{e.Color.R, e.Color.G, e.Color.B} {
+		if c < 0 || c > 255 {
+			return fmt.Errorf("el color no es válido")
+		}
+	}
+	seen := map[string]bool{}
+	for _, rem := range e.Remitentes {
+		if err := validateRemitente(rem); err != nil {
+			return err
+		}
+		if seen[strings.ToLower(rem.Sucursal)] {
+			return fmt.Errorf("el remitente %s está repetido", rem.Sucursal)
+		}
+		seen[strings.ToLower(rem.Sucursal)] = true
+	}
+	if e.Mascara != "" {
+		if err := validateGuiaMask(e.Mascara, key); err != nil {
+			return err
+		}
+	}
+	if e.Logo != "" {
+		switch strings.ToLower(filepath.Ext(e.Logo)) {
+		case ".png", ".jpg", ".jpeg":
+		default:
+			return fmt.Errorf("el logo debe ser una imagen PNG o JPG")
+		}
+	}
+	return nil
+}
+
+// parseHexColor lee un color como #003366 o 003366
+func parseHexColor(s string) (r, g, b int, err error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		return 0, 0, 0, fmt.Errorf("el color debe escribirse como #003366")
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("el color debe escribirse como #003366")
+	}
+	return int(v >> 16), int(v >> 8 & 0xFF), int(v & 0xFF), nil
+}
+
+func hexColor(r, g, b int) string {
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
+
+func loadEmpresas() map[string]Empresa {
+	data, err := ioutil.ReadFile(empresasFile)
+	if err != nil {
+		return defaultEmpresas()
+	}
+	var empresas map[string]Empresa
+	if err := json.Unmarshal(data, &empresas); err != nil {
+		log.Printf("Error leyendo %s: %v", empresasFile, err)
+		return defaultEmpresas()
+	}
+	for key, e := range empresas {
+		if err := validateEmpresa(key, e); err != nil {
+			log.Printf("Empresa %q inválida en %s, se ignora: %v", key, empresasFile, err)
+			delete(empresas, key)
+		}
+	}
+	if len(empresas) == 0 {
+		return defaultEmpresas()
+	}
+	return empresas
+}
+
+func saveEmpresas(empresas map[string]Empresa) error {
+	data, err := json.MarshalIndent(empresas, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(empresasFile, data, 0644)
+}
+
+// refreshEmpresas vuelve a llenar la lista de empresas del rótulo; si la elegida ya no
+// existe pasa a la primera, y si sigue se recargan sus datos por si cambiaron
+func (r *RotuloGenerator) refreshEmpresas() {
+	names := companyNames()
+	r.empresaCheck.Options = names
+	selected := r.data.Empresa
+	if _, ok := empresasData[selected]; !ok {
+		selected = names[0]
+	}
+	r.empresaCheck.Selected = ""
+	r.empresaCheck.SetSelected(selected)
+}
+
+// showEmpresas abre el administrador de empresas: la lista a la izquierda y a la derecha
+// los datos de la elegida. Los cambios se guardan en empresas.json y se ven al instante en
+// el rótulo.
+func (r *RotuloGenerator) showEmpresas(window fyne.Window) {
+	names := companyNames()
+	editing := "" // Clave de la empresa que se edita; vacía si es nueva
+
+	keyInput := widget.NewEntry()
+	keyInput.SetPlaceHolder("ZETTACOM")
+	nameInput := widget.NewEntry()
+	nameInput.SetPlaceHolder("ZETTACOM S.A.C")
+	addressInput := widget.NewEntry()
+	phoneInput := widget.NewEntry()
+	colorInput := widget.NewEntry()
+	colorInput.SetPlaceHolder("#003366")
+	swatch := canvas.NewRectangle(color.Black)
+	swatch.SetMinSize(fyne.NewSize(28, 28))
+	colorInput.OnChanged = func(text string) {
+		if red, green, blue, err := parseHexColor(text); err == nil {
+			swatch.FillColor = color.NRGBA{R: uint8(red), G: uint8(green), B: uint8(blue), A: 255}
+			swatch.Refresh()
+		}
+	}
+	colorButton := widget.NewButton("🎨", func() {
+		picker := dialog.NewColorPicker("Color de la empresa", "Color de la banda del rótulo", func(c color.Color) {
+			red, green, blue, _ := c.RGBA()
+			colorInput.SetText(hexColor(int(red>>8), int(green>>8), int(blue>>8)))
+		}, window)
+		picker.Advanced = true
+		picker.Show()
+	})
+	logoInput := widget.NewEntry()
+	logoInput.SetPlaceHolder("logos/empresa.png")
+	logoButton := widget.NewButton("📂", func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			logoInput.SetText(reader.URI().Path())
+			reader.Close()
+		}, window)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg"}))
+		open.Show()
+	})
+	qrCheck := widget.NewCheck("Incluir código QR de seguimiento", nil)
+	maskInput := widget.NewEntry()
+	maskInput.SetPlaceHolder(defaultGuiaMask)
+	maskExample := widget.NewLabel("")
+	// El ejemplo se arma con la clave escrita y la fecha de hoy mientras se escribe
+	showExample := func() {
+		key := strings.ToUpper(strings.TrimSpace(keyInput.Text))
+		if !empresaKeyRegex.MatchString(key) {
+			maskExample.SetText("")
+			return
+		}
+		mask := getValueOrDefault(strings.TrimSpace(maskInput.Text), defaultGuiaMask)
+		if err := validateGuiaMask(mask, key); err != nil {
+			maskExample.SetText("⚠️ " + err.Error())
+			return
+		}
+		guia, _ := expandGuiaMask(mask, key, 123, time.Now())
+		maskExample.SetText("Ejemplo: " + guia)
+	}
+	maskInput.OnChanged = func(string) { showExample() }
+	keyInput.OnChanged = func(string) { showExample() }
+
+	fill := func(key string, e Empresa) {
+		editing = key
+		keyInput.SetText(key)
+		nameInput.SetText(e.Nombre)
+		addressInput.SetText(e.Direccion)
+		phoneInput.SetText(e.Telefono)
+		colorInput.SetText(hexColor(e.Color.R, e.Color.G, e.Color.B))
+		logoInput.SetText(e.Logo)
+		qrCheck.SetChecked(e.NeedQR)
+		maskInput.SetText(e.Mascara)
+	}
+
+	list := widget.NewList(
+		func() int { return len(names) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(names[id])
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		fill(names[id], empresasData[names[id]])
+	}
+	// afterChange guarda, actualiza la lista y el rótulo y deja elegida key
+	afterChange := func(key string) bool {
+		if err := saveEmpresas(empresasData); err != nil {
+			dialog.ShowError(err, window)
+			return false
+		}
+		names = companyNames()
+		list.Refresh()
+		r.refreshEmpresas()
+		for i, name := range names {
+			if name == key {
+				list.Select(i)
+			}
+		}
+		return true
+	}
+
+	newButton := widget.NewButton("➕ Nueva", func() {
+		list.UnselectAll()
+		fill("", Empresa{})
+		editing = ""
+		window.Canvas().Focus(keyInput)
+	})
+	saveButton := widget.NewButton("💾 Guardar", func() {
+		key := strings.ToUpper(strings.TrimSpace(keyInput.Text))
+		e := Empresa{
+			Nombre:    strings.TrimSpace(nameInput.Text),
+			Direccion: strings.TrimSpace(addressInput.Text),
+			Telefono:  strings.TrimSpace(phoneInput.Text),
+			NeedQR:    qrCheck.Checked,
+			Logo:      strings.TrimSpace(logoInput.Text),
+			Mascara:   strings.TrimSpace(maskInput.Text),
+		}
+		e.Remitentes = empresasData[editing].Remitentes // Las sucursales se editan en el rótulo
+		var err error
+		if e.Color.R, e.Color.G, e.Color.B, err = parseHexColor(colorInput.Text); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if err := validateEmpresa(key, e); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if _, exists := empresasData[key]; exists && key != editing {
+			dialog.ShowError(fmt.Errorf("ya existe una empresa con la clave %s", key), window)
+			return
+		}
+		if e.Logo != "" {
+			if _, err := os.Stat(e.Logo); err != nil {
+				dialog.ShowError(fmt.Errorf("no se encuentra el logo %s", e.Logo), window)
+				return
+			}
+		}
+		if editing != "" && editing != key {
+			delete(empresasData, editing)
+		}
+		empresasData[key] = e
+		if afterChange(key) {
+			editing = key
+		}
+	})
+	deleteButton := widget.NewButton("🗑️ Eliminar", func() {
+		if editing == "" {
+			return
+		}
+		if len(empresasData) == 1 {
+			dialog.ShowError(fmt.Errorf("debe quedar al menos una empresa"), window)
+			return
+		}
+		key := editing
+		dialog.ShowConfirm("Eliminar empresa", fmt.Sprintf("¿Eliminar la empresa %s? Los rótulos ya generados no cambian.", key), func(ok bool) {
+			if !ok {
+				return
+			}
+			delete(empresasData, key)
+			list.UnselectAll()
+			fill("", Empresa{})
+			afterChange("")
+		}, window)
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Clave", keyInput),
+		widget.NewFormItem("Razón social", nameInput),
+		widget.NewFormItem("Dirección", addressInput),
+		widget.NewFormItem("Teléfono", phoneInput),
+		widget.NewFormItem("Color", container.NewBorder(nil, nil, nil, container.NewHBox(swatch, colorButton), colorInput)),
+		widget.NewFormItem("Logo", container.NewBorder(nil, nil, nil, logoButton, logoInput)),
+		widget.NewFormItem("", qrCheck),
+		widget.NewFormItem("Máscara de guía", maskInput),
+		widget.NewFormItem("", maskExample),
+	)
+	listScroll := container.NewScroll(list)
+	listScroll.SetMinSize(fyne.NewSize(150, 280))
+	content := container.NewBorder(nil, container.NewHBox(newButton, saveButton, deleteButton), listScroll, nil, form)
+
+	d := dialog.NewCustom("🏢 Empresas", "Cerrar", content, window)
+	d.Resize(fyne.NewSize(680, 500))
+	d.Show()
+	for i, name := range names {
+		if name == r.data.Empresa {
+			list.Select(i)
+		}
+	}
+}
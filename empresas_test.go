@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestValidateEmpresa(t *testing.T) {
+	ok := Empresa{Nombre: "ACME S.A.C", Logo: "logos/acme.png"}
+	if err := validateEmpresa("ACME", ok); err != nil {
+		t.Errorf("empresa válida rechazada: %v", err)
+	}
+	for _, key := range []string{"", "AC", "acme", "ACME SAC", "ÑANDU"} {
+		if validateEmpresa(key, ok) == nil {
+			t.Errorf("se aceptó la clave %q", key)
+		}
+	}
+	noName := ok
+	noName.Nombre = "  "
+	if validateEmpresa("ACME", noName) == nil {
+		t.Error("se aceptó una empresa sin razón social")
+	}
+	badLogo := ok
+	badLogo.Logo = "logos/acme.gif"
+	if validateEmpresa("ACME", badLogo) == nil {
+		t.Error("se aceptó un logo GIF")
+	}
+	badMask := ok
+	badMask.Mascara = "ACM-{YYYY}"
+	if validateEmpresa("ACME", badMask) == nil {
+		t.Error("se aceptó una máscara sin contador")
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	r, g, b, err := parseHexColor(" #003366")
+	if err != nil || r != 0 || g != 51 || b != 102 {
+		t.Errorf("#003366 = %d %d %d %v", r, g, b, err)
+	}
+	if hexColor(180, 20, 40) != "#B41428" {
+		t.Errorf("hexColor = %s", hexColor(180, 20, 40))
+	}
+	if r, g, b, _ := parseHexColor(hexColor(180, 20, 40)); r != 180 || g != 20 || b != 40 {
+		t.Errorf("ida y vuelta = %d %d %d", r, g, b)
+	}
+	for _, s := range []string{"", "#0033", "#00336G", "#0033667"} {
+		if _, _, _, err := parseHexColor(s); err == nil {
+			t.Errorf("se aceptó el color %q", s)
+		}
+	}
+}
+
+func TestLoadSaveEmpresas(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if got := loadEmpresas(); len(got) != 2 || got["COMSITEC"].Nombre != "COMSITEC S.A.C" {
+		t.Errorf("sin archivo = %v", got)
+	}
+	acme := Empresa{Nombre: "ACME S.A.C", Telefono: "+51 999 888 777", NeedQR: true,
+		Remitentes: []Remitente{{Sucursal: "Lima", Nombre: "ACME Lima", Direccion: "Av. Arequipa 123"}}}
+	acme.Color.R, acme.Color.G, acme.Color.B = 10, 20, 30
+	if err := saveEmpresas(map[string]Empresa{"ACME": acme}); err != nil {
+		t.Fatal(err)
+	}
+	got := loadEmpresas()
+	if len(got) != 1 || !reflect.DeepEqual(got["ACME"], acme) {
+		t.Errorf("guardadas = %v", got)
+	}
+
+	// Las empresas inválidas se ignoran; si no queda ninguna vuelven las de siempre
+	ioutil.WriteFile(empresasFile, []byte(`{"ACME":{"Nombre":"ACME"},"x":{"Nombre":"X"}}`), 0644)
+	if got := loadEmpresas(); len(got) != 1 || got["ACME"].Nombre != "ACME" {
+		t.Errorf("con una inválida = %v", got)
+	}
+	ioutil.WriteFile(empresasFile, []byte(`{"x":{"Nombre":"X"}}`), 0644)
+	if got := loadEmpresas(); len(got) != 2 {
+		t.Errorf("todas inválidas = %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"runtime"
+	"time"
+	"unicode"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// Formas de escribir los textos en la aplicación destino
+const (
+	typingAuto  = "Automático (pegar acentos y ñ)"
+	typingKeys  = "Teclear siempre"
+	typingPaste = "Pegar siempre"
+)
+
+var typingOptions = []string{typingAuto, typingKeys, typingPaste}
+
+// Tiempo para que la aplicación lea el portapapeles antes de restaurarlo
+const pasteSettle = 150 * time.Millisecond
+
+// needsPaste indica si el texto se pega en lugar de teclearse. robotgo teclea mal la ñ y
+// las vocales con tilde en distribuciones de teclado en español, así que en modo
+// automático todo texto que no sea ASCII se pega.
+func needsPaste(text, mode string) bool {
+	switch mode {
+	case typingKeys:
+		return false
+	case typingPaste:
+		return text != ""
+	}
+	for _, r := range text {
+		if r > unicode.MaxASCII {
+			return true
+		}
+	}
+	return false
+}
+
+// pasteText escribe el texto con el portapapeles y deja el contenido anterior como estaba
+func pasteText(text string) {
+	previous, readErr := robotgo.ReadAll()
+	if err := robotgo.WriteAll(text); err != nil {
+		log.Printf("Error copiando al portapapeles, se teclea: %v", err)
+		robotgo.TypeStr(text)
+		return
+	}
+
+	if runtime.GOOS == "darwin" {
+		robotgo.KeyTap("v", "cmd")
+	} else {
+		robotgo.KeyTap("v", "ctrl")
+	}
+	time.Sleep(pasteSettle)
+
+	if readErr == nil {
+		if err := robotgo.WriteAll(previous); err != nil {
+			log.Printf("Error restaurando el portapapeles: %v", err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestNeedsPaste(t *testing.T) {
+	tests := []struct {
+		text string
+		mode string
+		want bool
+	}{
+		{"ZET001", typingAuto, false},
+		{"Cañón de 3 m", typingAuto, true},
+		{"Descripción", "", true},
+		{"Descripción", typingKeys, false},
+		{"ZET001", typingPaste, true},
+		{"", typingPaste, false},
+	}
+
+	for _, tt := range tests {
+		if got := needsPaste(tt.text, tt.mode); got != tt.want {
+			t.Errorf("needsPaste(%q, %q) = %v, se esperaba %v", tt.text, tt.mode, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// Cada cuánto se vuelve a leer el pixel mientras se espera
+const pixelPollInterval = 100 * time.Millisecond
+
+// recordPixelWait agrega a la secuencia una espera por el color actual bajo el mouse
+func (a *Autocopiador) recordPixelWait() {
+	a.appendStepAtMouse("Coloca el mouse sobre el indicador a esperar", func(x, y int) string {
+		return fmt.Sprintf("[esperar %d %d %s]", x, y, strings.ToLower(robotgo.GetPixelColor(x, y)))
+	})
+}
+
+// waitForPixel espera a que el pixel del paso tome el color indicado. Si se agota el tiempo
+// pausa hasta que el usuario confirme; devuelve false si se cancela.
+func (a *Autocopiador) waitForPixel(step MacroStep) bool {
+	for {
+		deadline := time.Now().Add(step.Espera)
+		current := ""
+		for time.Now().Before(deadline) {
+			select {
+			case <-cancel:
+				a.statusLabel.SetText("Estado: Cancelado.")
+				return false
+			default:
+			}
+
+			current = strings.ToLower(robotgo.GetPixelColor(step.X, step.Y))
+			if current == step.Valor {
+				return true
+			}
+			time.Sleep(pixelPollInterval)
+		}
+
+		a.stats.addError()
+		a.refreshStats()
+
+		a.statusLabel.SetText(fmt.Sprintf("Pausado: el pixel %d,%d no cambió a %s", step.X, step.Y, step.Valor))
+		if !waitConfirmation("⚠️ Color no detectado",
+			fmt.Sprintf("Tras %s el pixel %d,%d sigue en %s en lugar de %s.\n\n"+
+				"Al continuar se volverá a esperar el color durante el mismo tiempo.\n¿Continuar?",
+				step.Espera, step.X, step.Y, current, step.Valor),
+			a.window, a.statusLabel) {
+			return false
+		}
+		a.statusLabel.SetText("Copiando...")
+	}
+}
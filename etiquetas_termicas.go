@@ -0,0 +1,499 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	thermalSettingsFile = "zebra_rotulos.json" // Junto al programa; conserva el nombre de cuando solo había Zebra
+	thermalPort         = "9100"               // Puerto de impresión directa de Zebra, TSC y Godex en red
+	thermalTimeout      = 5 * time.Second
+	thermalWidthMM      = 100.0 // Rollo de etiquetas de 10x15 cm
+	thermalHeightMM     = 150.0
+)
+
+// thermalResolutions son los puntos por milímetro de las impresoras de 203 y 300 dpi
+var thermalResolutions = []int{8, 12}
+
+func resolutionLabel(dpmm int) string {
+	return fmt.Sprintf("%d dpi (%d puntos/mm)", int(float64(dpmm)*25.4+0.5), dpmm)
+}
+
+// labelWriter arma los comandos de una impresora térmica con las medidas en milímetros
+type labelWriter interface {
+	begin(width, height float64)
+	// text escribe en x, y con letra de size mm; si lines es mayor que 1 el texto se reparte
+	// en ese número de líneas dentro de width
+	text(x, y, size, width float64, lines int, text string)
+	// box dibuja un rectángulo; con height igual a thickness queda una línea
+	box(x, y, width, height, thickness float64)
+	// barcode dibuja un Code 128 con el número debajo
+	barcode(x, y, height float64, text string)
+	qr(x, y float64, text string)
+	end(copies int) string
+}
+
+// labelDots guarda los comandos y la resolución que comparten los lenguajes
+type labelDots struct {
+	b    strings.Builder
+	dpmm int
+}
+
+func (l *labelDots) dots(mm float64) int {
+	return int(mm*float64(l.dpmm) + 0.5)
+}
+
+// thermalLanguage es un lenguaje de impresora que se puede elegir al imprimir
+type thermalLanguage struct {
+	Name      string
+	Printers  string
+	Extension string
+	writer    func(dpmm int) labelWriter
+}
+
+var thermalLanguages = []thermalLanguage{
+	{"ZPL", "Zebra", ".zpl", func(dpmm int) labelWriter { return &zplWriter{labelDots{dpmm: dpmm}} }},
+	{"TSPL", "TSC", ".prn", func(dpmm int) labelWriter { return &tsplWriter{labelDots{dpmm: dpmm}} }},
+	{"EPL", "Godex y Zebra antiguas", ".prn", func(dpmm int) labelWriter { return &eplWriter{labelDots{dpmm: dpmm}} }},
+}
+
+func (l thermalLanguage) label() string {
+	return fmt.Sprintf("%s (%s)", l.Name, l.Printers)
+}
+
+// findThermalLanguage devuelve el lenguaje llamado name, o ZPL si no existe
+func findThermalLanguage(name string) thermalLanguage {
+	for _, l := range thermalLanguages {
+		if l.Name == name {
+			return l
+		}
+	}
+	return thermalLanguages[0]
+}
+
+// barcodeModule es el ancho en puntos de la barra más angosta del Code 128; con guías largas
+// es menor para que entre en la etiqueta
+func barcodeModule(guia string, dpmm int) int {
+	module := 3
+	if len(guia) > 12 {
+		module = 2
+	}
+	if dpmm == 12 {
+		module = module * 3 / 2
+	}
+	return module
+}
+
+// thermalLabel arma el rótulo de data para una etiqueta de 10x15 cm con el lenguaje de w
+func thermalLabel(w labelWriter, data RotuloData, empresa Empresa, copies int) string {
+	const margin, inner = 4.0, thermalWidthMM - 8
+	w.begin(thermalWidthMM, thermalHeightMM)
+
+	w.text(margin, 4, 5, inner, 1, empresa.Nombre)
+	w.text(margin, 10, 4, inner, 1, "TRACKING: "+data.NumeroGuia)
+	w.box(margin, 16, inner, 0.4, 0.4)
+
+	w.text(margin, 19, 3, inner, 1, "REMITENTE")
+	w.text(margin, 23, 3.5, inner, 1, data.RemitenteNombre)
+	w.text(margin, 27, 3, inner, 2, data.RemitenteDireccion)
+	w.text(margin, 34, 3, inner, 1, "Tel: "+data.RemitenteTelefono)
+	w.box(margin, 39, inner, 0.4, 0.4)
+
+	w.text(margin, 42, 3, inner, 1, "DESTINATARIO")
+	w.text(margin, 46, 5, inner, 1, data.DestinatarioNombre)
+	w.text(margin, 52, 4, inner, 2, data.DestinatarioDireccion)
+	w.text(margin, 61, 4, inner, 1, "Tel: "+data.DestinatarioTelefono)
+	if data.DestinatarioDNI != "" {
+		w.text(margin, 66, 4, inner, 1, "DNI: "+data.DestinatarioDNI)
+	}
+	w.box(margin, 72, inner, 0.4, 0.4)
+
+	w.text(margin, 75, 3, inner, 1, "Fecha: "+data.FechaEnvio.Format("02/01/2006 15:04"))
+	if data.Peso != "" {
+		w.text(margin, 79, 3, inner, 1, "Peso: "+data.Peso)
+	}
+	if data.Observaciones != "" {
+		w.text(margin, 83, 3, inner, 2, "Obs: "+data.Observaciones)
+	}
+
+	w.barcode(margin+4, 92, 18, data.NumeroGuia)
+	if empresa.NeedQR {
+		w.qr(70, 116, "https://www.comsitec.tech"+data.NumeroGuia)
+	}
+
+	w.text(margin, 118, 2.5, inner, 1, "FIRMA DESTINATARIO")
+	w.box(margin, 122, 60, 14, 0.3)
+	w.text(margin, 140, 2.5, inner, 2, empresa.Nombre+" - "+empresa.Direccion)
+	return w.end(copies)
+}
+
+// tsplWriter arma los comandos TSPL de las TSC. La letra 0 es escalable y se mide en puntos
+// tipográficos; el texto va en UTF-8.
+type tsplWriter struct {
+	labelDots
+}
+
+// tsplEscape prepara texto para ir entre comillas: TSPL escribe la comilla como \["]
+func tsplEscape(text string) string {
+	return strings.NewReplacer(`"`, `\["]`, "\n", " ").Replace(text)
+}
+
+func (t *tsplWriter) begin(width, height float64) {
+	fmt.Fprintf(&t.b, "SIZE %g mm,%g mm\nGAP 3 mm,0 mm\nDIRECTION 1\nCODEPAGE UTF-8\nCLS\n", width, height)
+}
+
+func (t *tsplWriter) text(x, y, size, width float64, lines int, text string) {
+	points := int(size*72/25.4 + 0.5)
+	if lines > 1 {
+		fmt.Fprintf(&t.b, "BLOCK %d,%d,%d,%d,\"0\",0,%d,%d,0,1,\"%s\"\n",
+			t.dots(x), t.dots(y), t.dots(width), t.dots(size*1.25*float64(lines)), points, points, tsplEscape(text))
+		return
+	}
+	fmt.Fprintf(&t.b, "TEXT %d,%d,\"0\",0,%d,%d,\"%s\"\n", t.dots(x), t.dots(y), points, points, tsplEscape(text))
+}
+
+func (t *tsplWriter) box(x, y, width, height, thickness float64) {
+	if height <= thickness {
+		fmt.Fprintf(&t.b, "BAR %d,%d,%d,%d\n", t.dots(x), t.dots(y), t.dots(width), t.dots(height))
+		return
+	}
+	fmt.Fprintf(&t.b, "BOX %d,%d,%d,%d,%d\n", t.dots(x), t.dots(y), t.dots(x+width), t.dots(y+height), t.dots(thickness))
+}
+
+func (t *tsplWriter) barcode(x, y, height float64, text string) {
+	module := barcodeModule(text, t.dpmm)
+	fmt.Fprintf(&t.b, "BARCODE %d,%d,\"128\",%d,1,0,%d,%d,\"%s\"\n", t.dots(x), t.dots(y), t.dots(height), module, module, tsplEscape(text))
+}
+
+func (t *tsplWriter) qr(x, y float64, text string) {
+	fmt.Fprintf(&t.b, "QRCODE %d,%d,M,%d,A,0,\"%s\"\n", t.dots(x), t.dots(y), t.dpmm/2, tsplEscape(text))
+}
+
+func (t *tsplWriter) end(copies int) string {
+	fmt.Fprintf(&t.b, "PRINT 1,%d\n", copies)
+	return t.b.String()
+}
+
+// eplFont es una letra fija de EPL con su celda en puntos
+type eplFont struct {
+	Number        int
+	Width, Height int
+}
+
+// eplFonts son las letras 1 a 4 de EPL a 203 y 300 dpi; la 5 solo tiene mayúsculas
+var eplFonts = map[int][]eplFont{
+	8:  {{1, 10, 12}, {2, 12, 16}, {3, 14, 20}, {4, 16, 24}},
+	12: {{1, 14, 22}, {2, 18, 30}, {3, 22, 40}, {4, 26, 48}},
+}
+
+// eplPickFont elige la letra y el multiplicador más grandes que no pasan de height puntos
+func eplPickFont(dpmm, height int) (eplFont, int) {
+	fonts := eplFonts[dpmm]
+	if fonts == nil {
+		fonts = eplFonts[8]
+	}
+	best, bestMult := fonts[0], 1
+	for mult := 1; mult <= 2; mult++ {
+		for _, f := range fonts {
+			if f.Height*mult <= height && f.Height*mult > best.Height*bestMult {
+				best, bestMult = f, mult
+			}
+		}
+	}
+	return best, bestMult
+}
+
+// wrapText reparte text en líneas de hasta width caracteres cortando entre palabras, y se
+// queda con las primeras lines. Una palabra más larga que width se corta.
+func wrapText(text string, width, lines int) []string {
+	var result []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for len([]rune(word)) > width {
+			if line != "" {
+				result = append(result, line)
+				line = ""
+			}
+			result = append(result, string([]rune(word)[:width]))
+			word = string([]rune(word)[width:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= width:
+			line += " " + word
+		default:
+			result = append(result, line)
+			line = word
+		}
+	}
+	if line != "" {
+		result = append(result, line)
+	}
+	if len(result) > lines {
+		result = result[:lines]
+	}
+	return result
+}
+
+// encodeCP1252 pasa text a la página de códigos de Windows que se elige con I8,A; lo que no
+// existe en ella queda como ?
+func encodeCP1252(text string) string {
+	reverse := make(map[rune]byte, len(cp1252))
+	for b, r := range cp1252 {
+		reverse[r] = b
+	}
+	var out []byte
+	for _, r := range text {
+		switch b, ok := reverse[r]; {
+		case ok:
+			out = append(out, b)
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return string(out)
+}
+
+// eplWriter arma los comandos EPL2 de las Godex y las Zebra antiguas. EPL solo tiene letras
+// fijas, así que el texto largo se corta en líneas aquí y va en Windows-1252.
+type eplWriter struct {
+	labelDots
+}
+
+func eplEscape(text string) string {
+	return encodeCP1252(strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(text))
+}
+
+func (e *eplWriter) begin(width, height float64) {
+	fmt.Fprintf(&e.b, "\nN\nI8,A,001\nq%d\nQ%d,24\n", e.dots(width), e.dots(height))
+}
+
+func (e *eplWriter) text(x, y, size, width float64, lines int, text string) {
+	font, mult := eplPickFont(e.dpmm, e.dots(size))
+	step := font.Height * mult
+	for i, line := range wrapText(text, e.dots(width)/(font.Width*mult), lines) {
+		fmt.Fprintf(&e.b, "A%d,%d,0,%d,%d,%d,N,\"%s\"\n", e.dots(x), e.dots(y)+i*step, font.Number, mult, mult, eplEscape(line))
+	}
+}
+
+func (e *eplWriter) box(x, y, width, height, thickness float64) {
+	if height <= thickness {
+		fmt.Fprintf(&e.b, "LO%d,%d,%d,%d\n", e.dots(x), e.dots(y), e.dots(width), e.dots(height))
+		return
+	}
+	fmt.Fprintf(&e.b, "X%d,%d,%d,%d,%d\n", e.dots(x), e.dots(y), e.dots(thickness), e.dots(x+width), e.dots(y+height))
+}
+
+func (e *eplWriter) barcode(x, y, height float64, text string) {
+	module := barcodeModule(text, e.dpmm)
+	fmt.Fprintf(&e.b, "B%d,%d,0,1,%d,%d,%d,B,\"%s\"\n", e.dots(x), e.dots(y), module, module, e.dots(height), eplEscape(text))
+}
+
+func (e *eplWriter) qr(x, y float64, text string) {
+	fmt.Fprintf(&e.b, "b%d,%d,Q,m2,s%d,eM,\"%s\"\n", e.dots(x), e.dots(y), e.dpmm/2, eplEscape(text))
+}
+
+func (e *eplWriter) end(copies int) string {
+	fmt.Fprintf(&e.b, "P1,%d\n", copies)
+	return e.b.String()
+}
+
+// ThermalSettings recuerda la impresora térmica de este equipo
+type ThermalSettings struct {
+	Direccion string // IP o nombre, con o sin :9100
+	Puntos    int    // Puntos por milímetro
+	Lenguaje  string // ZPL, TSPL o EPL; vacío en los archivos de cuando solo había Zebra
+}
+
+func loadThermalSettings() ThermalSettings {
+	settings := ThermalSettings{Puntos: 8}
+	data, err := ioutil.ReadFile(thermalSettingsFile)
+	if err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			log.Printf("Error leyendo %s: %v", thermalSettingsFile, err)
+			settings = ThermalSettings{Puntos: 8}
+		}
+	}
+	if settings.Puntos != 8 && settings.Puntos != 12 {
+		settings.Puntos = 8
+	}
+	settings.Lenguaje = findThermalLanguage(settings.Lenguaje).Name
+	return settings
+}
+
+func saveThermalSettings(settings ThermalSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(thermalSettingsFile, data, 0644)
+}
+
+// thermalAddress agrega el puerto 9100 si la dirección no trae uno
+func thermalAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", fmt.Errorf("escribe la IP de la impresora")
+	}
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address, nil
+	}
+	return net.JoinHostPort(address, thermalPort), nil
+}
+
+// sendLabel manda los comandos directo a la impresora, sin controlador ni PDF
+func sendLabel(address, commands string) error {
+	conn, err := net.DialTimeout("tcp", address, thermalTimeout)
+	if err != nil {
+		return fmt.Errorf("no se pudo conectar con la impresora en %s: %v", address, err)
+	}
+	defer conn.Close()
+	conn.SetWriteDeadline(time.Now().Add(thermalTimeout))
+	if _, err := conn.Write([]byte(commands)); err != nil {
+		return fmt.Errorf("la impresora en %s no recibió el rótulo: %v", address, err)
+	}
+	return nil
+}
+
+// showThermal manda el rótulo a una impresora térmica en red en el lenguaje elegido o lo
+// guarda como archivo
+func (r *RotuloGenerator) showThermal(window fyne.Window) {
+	if r.data.RemitenteNombre == "" || r.data.DestinatarioNombre == "" {
+		dialog.ShowError(fmt.Errorf("debes completar al menos el nombre del remitente y destinatario"), window)
+		return
+	}
+	if err := r.ensureGuia(); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	if _, err := code128Values(r.data.NumeroGuia); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	// Guardar el archivo y enviarlo a la impresora usan el mismo rótulo, aunque al guardar ya
+	// se libere la guía del formulario
+	label := *r.data
+
+	settings := loadThermalSettings()
+	addressInput := widget.NewEntry()
+	addressInput.SetPlaceHolder("192.168.1.50 o 192.168.1.50:9100")
+	addressInput.SetText(settings.Direccion)
+	var languages []string
+	for _, l := range thermalLanguages {
+		languages = append(languages, l.label())
+	}
+	languageSelect := widget.NewSelect(languages, nil)
+	languageSelect.SetSelected(findThermalLanguage(settings.Lenguaje).label())
+	var resolutions []string
+	for _, dpmm := range thermalResolutions {
+		resolutions = append(resolutions, resolutionLabel(dpmm))
+	}
+	resolutionSelect := widget.NewSelect(resolutions, nil)
+	resolutionSelect.SetSelected(resolutionLabel(settings.Puntos))
+	copiesInput := widget.NewEntry()
+	copiesInput.SetText("1")
+
+	// build arma los comandos con lo elegido y recuerda la impresora para la próxima vez
+	build := func() (string, thermalLanguage, ThermalSettings, error) {
+		language := thermalLanguages[languageSelect.SelectedIndex()]
+		copies, err := strconv.Atoi(strings.TrimSpace(copiesInput.Text))
+		if err != nil || copies < 1 || copies > maxLabelCopies {
+			return "", language, settings, fmt.Errorf("las copias deben ser un número entre 1 y %d", maxLabelCopies)
+		}
+		chosen := ThermalSettings{
+			Direccion: strings.TrimSpace(addressInput.Text),
+			Puntos:    thermalResolutions[resolutionSelect.SelectedIndex()],
+			Lenguaje:  language.Name,
+		}
+		if err := saveThermalSettings(chosen); err != nil {
+			log.Printf("Error guardando %s: %v", thermalSettingsFile, err)
+		}
+		commands := thermalLabel(language.writer(chosen.Puntos), label, empresasData[label.Empresa], copies)
+		return commands, language, chosen, nil
+	}
+
+	saveButton := widget.NewButton("💾 Guardar como archivo", func() {
+		commands, language, _, err := build()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+			if _, err := writer.Write([]byte(commands)); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			r.recordLabels([]RotuloData{label}, writer.URI().Path())
+			r.releaseGuia(label.NumeroGuia)
+		}, window)
+		saveDialog.SetFileName(fmt.Sprintf("rotulo_%s_%s%s", label.Empresa, label.NumeroGuia, language.Extension))
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{language.Extension}))
+		saveDialog.Show()
+	})
+
+	hint := widget.NewLabel("Etiqueta de 10x15 cm. La impresora debe estar en la misma red; se envía al puerto 9100 sin pasar por el controlador.")
+	hint.Wrapping = fyne.TextWrapWord
+	content := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Impresora", addressInput),
+			widget.NewFormItem("Lenguaje", languageSelect),
+			widget.NewFormItem("Resolución", resolutionSelect),
+			widget.NewFormItem("Copias", copiesInput),
+		),
+		hint,
+		saveButton,
+	)
+
+	thermalDialog := dialog.NewCustomConfirm("🏷️ Imprimir en impresora térmica", "Enviar", "Cancelar", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		commands, language, chosen, err := build()
+		if err == nil {
+			chosen.Direccion, err = thermalAddress(chosen.Direccion)
+		}
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		go func() {
+			err := sendLabel(chosen.Direccion, commands)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+				r.recordLabels([]RotuloData{label}, fmt.Sprintf("Impresora %s %s", language.Name, chosen.Direccion))
+				dialog.ShowInformation("✅ Impresión Enviada",
+					fmt.Sprintf("Rótulo enviado en %s a la impresora %s\n\n📦 Tracking: %s", language.Name, chosen.Direccion, label.NumeroGuia), window)
+				r.releaseGuia(label.NumeroGuia)
+			})
+		}()
+	}, window)
+	thermalDialog.Resize(fyne.NewSize(460, 360))
+	thermalDialog.Show()
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThermalLabelTSPL(t *testing.T) {
+	data := RotuloData{RemitenteNombre: "ZETTACOM S.A.C", DestinatarioNombre: `María "Mary" González`,
+		NumeroGuia: "ZET123456", FechaEnvio: time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)}
+	empresa := Empresa{Nombre: "COMSITEC S.A.C", NeedQR: true}
+
+	tspl := thermalLabel(findThermalLanguage("TSPL").writer(8), data, empresa, 3)
+	for _, want := range []string{
+		"SIZE 100 mm,150 mm\n",
+		"CODEPAGE UTF-8\nCLS\n",
+		`TEXT 32,368,"0",0,14,14,"María \["]Mary\["] González"`,
+		"BAR 32,128,736,3\n",
+		`BARCODE 64,736,"128",144,1,0,3,3,"ZET123456"`,
+		`QRCODE 560,928,M,4,A,0,"https://www.comsitec.techZET123456"`,
+		"PRINT 1,3\n",
+	} {
+		if !strings.Contains(tspl, want) {
+			t.Errorf("falta %q en:\n%s", want, tspl)
+		}
+	}
+	if strings.Contains(tspl, "DNI:") {
+		t.Error("el DNI vacío no debería imprimirse")
+	}
+}
+
+func TestThermalLabelEPL(t *testing.T) {
+	data := RotuloData{RemitenteNombre: "ZETTACOM S.A.C", DestinatarioNombre: "Peña",
+		DestinatarioDireccion: "Av. Los Próceres 1234, Urb. Santa Rosa, San Juan de Lurigancho, Lima",
+		NumeroGuia:            "ZET123456", FechaEnvio: time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)}
+	epl := thermalLabel(findThermalLanguage("EPL").writer(8), data, Empresa{Nombre: "COMSITEC S.A.C"}, 1)
+	for _, want := range []string{
+		"\nN\nI8,A,001\nq800\nQ1200,24\n",
+		"A32,368,0,3,2,2,N,\"Pe\xf1a\"\n",
+		"B64,736,0,1,3,3,144,B,\"ZET123456\"\n",
+		"P1,1\n",
+	} {
+		if !strings.Contains(epl, want) {
+			t.Errorf("falta %q en:\n%q", want, epl)
+		}
+	}
+	// La dirección no entra en una línea y se reparte en dos
+	if strings.Count(epl, "A32,416,") != 1 || strings.Count(epl, "A32,448,") != 1 {
+		t.Errorf("la dirección debería ir en dos líneas:\n%s", epl)
+	}
+	if strings.Contains(epl, "\nb") {
+		t.Error("sin QR no debería llevar el comando b")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		lines int
+		want  []string
+	}{
+		{"Av. Los Olivos 123", 20, 2, []string{"Av. Los Olivos 123"}},
+		{"Av. Los Olivos 123 Lima", 10, 2, []string{"Av. Los", "Olivos 123"}},
+		{"Jirón  Áncash", 6, 3, []string{"Jirón", "Áncash"}},
+		{"ABCDEFGHIJ KL", 4, 5, []string{"ABCD", "EFGH", "IJ", "KL"}},
+		{"", 10, 2, nil},
+	}
+	for _, tt := range tests {
+		if got := wrapText(tt.text, tt.width, tt.lines); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrapText(%q, %d, %d) = %q; quería %q", tt.text, tt.width, tt.lines, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeCP1252(t *testing.T) {
+	if got, want := encodeCP1252("Año “ok” € 📦"), "A\xf1o \x93ok\x94 \x80 ?"; got != want {
+		t.Errorf("encodeCP1252 = %q; quería %q", got, want)
+	}
+}
+
+func TestEPLPickFont(t *testing.T) {
+	if font, mult := eplPickFont(8, 40); font.Number != 3 || mult != 2 {
+		t.Errorf("a 5 mm en 203 dpi eligió la letra %d x%d", font.Number, mult)
+	}
+	if font, mult := eplPickFont(12, 36); font.Number != 2 || mult != 1 {
+		t.Errorf("a 3 mm en 300 dpi eligió la letra %d x%d", font.Number, mult)
+	}
+	if font, mult := eplPickFont(8, 5); font.Number != 1 || mult != 1 {
+		t.Errorf("una letra muy chica debería quedar en la 1: %d x%d", font.Number, mult)
+	}
+}
+
+func TestFindThermalLanguage(t *testing.T) {
+	if got := findThermalLanguage("EPL").Name; got != "EPL" {
+		t.Errorf("findThermalLanguage(EPL) = %s", got)
+	}
+	if got := findThermalLanguage("").Name; got != "ZPL" {
+		t.Errorf("sin lenguaje guardado debería quedar ZPL, no %s", got)
+	}
+}
+
+func TestThermalAddress(t *testing.T) {
+	cases := map[string]string{
+		"192.168.1.50":        "192.168.1.50:9100",
+		" 192.168.1.50:6101 ": "192.168.1.50:6101",
+		"tsc-almacen":         "tsc-almacen:9100",
+	}
+	for in, want := range cases {
+		if got, err := thermalAddress(in); err != nil || got != want {
+			t.Errorf("thermalAddress(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := thermalAddress("  "); err == nil {
+		t.Error("se aceptó una dirección vacía")
+	}
+}
+
+func TestSendLabel(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- ""
+			return
+		}
+		defer conn.Close()
+		data, _ := ioutil.ReadAll(conn)
+		received <- string(data)
+	}()
+
+	if err := sendLabel(listener.Addr().String(), "^XA^XZ"); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-received; got != "^XA^XZ" {
+		t.Errorf("la impresora recibió %q", got)
+	}
+}
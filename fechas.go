@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formato que espera el sistema destino
+const targetDateLayout = "02012006"
+
+var (
+	// DD/MM/AAAA, DD-MM-AA, DD.MM.AAAA (día y mes de 1 o 2 dígitos)
+	dmyDateRegex = regexp.MustCompile(`^(\d{1,2})[/\-.](\d{1,2})[/\-.](\d{2}|\d{4})$`)
+	// AAAA-MM-DD, como lo pega Excel en algunas configuraciones
+	isoDateRegex = regexp.MustCompile(`^(\d{4})[/\-.](\d{1,2})[/\-.](\d{1,2})$`)
+
+	excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// normalizeDate convierte una fecha escrita en varios formatos al DDMMAAAA que espera el sistema destino
+func normalizeDate(input string) (string, error) {
+	value := strings.TrimSpace(input)
+	// Excel suele pegar la hora junto a la fecha ("15/05/2025 0:00:00")
+	if i := strings.IndexAny(value, " \tT"); i > 0 {
+		value = value[:i]
+	}
+	if value == "" {
+		return "", fmt.Errorf("la fecha está vacía")
+	}
+
+	var day, month, year int
+	switch {
+	case dmyDateRegex.MatchString(value):
+		m := dmyDateRegex.FindStringSubmatch(value)
+		day, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+		year = expandYear(m[3])
+	case isoDateRegex.MatchString(value):
+		m := isoDateRegex.FindStringSubmatch(value)
+		year, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+		day, _ = strconv.Atoi(m[3])
+	case isDigits(value) && len(value) == 8:
+		day, _ = strconv.Atoi(value[0:2])
+		month, _ = strconv.Atoi(value[2:4])
+		year, _ = strconv.Atoi(value[4:8])
+	case isDigits(value) && len(value) == 6:
+		day, _ = strconv.Atoi(value[0:2])
+		month, _ = strconv.Atoi(value[2:4])
+		year = expandYear(value[4:6])
+	case isDigits(value) && len(value) == 5:
+		// Número de serie de fecha de Excel (días desde 30/12/1899)
+		serial, _ := strconv.Atoi(value)
+		return excelEpoch.AddDate(0, 0, serial).Format(targetDateLayout), nil
+	default:
+		return "", fmt.Errorf("formato de fecha no reconocido: %q", input)
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || int(date.Month()) != month || date.Year() != year {
+		return "", fmt.Errorf("la fecha %q no existe", input)
+	}
+	return date.Format(targetDateLayout), nil
+}
+
+// expandYear completa los años de dos dígitos al siglo actual
+func expandYear(y string) int {
+	year, _ := strconv.Atoi(y)
+	if len(y) == 2 {
+		year += 2000
+	}
+	return year
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestNormalizeDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"15052025", "15052025", false},
+		{"15/05/2025", "15052025", false},
+		{"5/5/2025", "05052025", false},
+		{"15-05-25", "15052025", false},
+		{"15.05.2025", "15052025", false},
+		{"150525", "15052025", false},
+		{"2025-05-15", "15052025", false},
+		{"15/05/2025 0:00:00", "15052025", false},
+		{"2025-05-15T00:00:00", "15052025", false},
+		{" 15/05/2025 ", "15052025", false},
+		{"45792", "15052025", false},
+		{"29/02/2024", "29022024", false},
+		{"29/02/2025", "", true},
+		{"31/04/2025", "", true},
+		{"15/13/2025", "", true},
+		{"", "", true},
+		{"mañana", "", true},
+		{"1505202", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeDate(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("normalizeDate(%q) = %q, se esperaba error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalizeDate(%q) error inesperado: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeDate(%q) = %q, se esperaba %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// parseFilterList separa una lista de filtros escrita con espacios, comas, ';' o saltos de línea
+func parseFilterList(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+}
+
+// matchesFilter indica si la serie coincide con algún filtro: exacto, o por prefijo si
+// el filtro termina en "*" (ZET00* coincide con ZET00154). No distingue mayúsculas.
+func matchesFilter(serie string, filters []string) bool {
+	serie = strings.ToUpper(serie)
+	for _, f := range filters {
+		f = strings.ToUpper(f)
+		if prefix, ok := strings.CutSuffix(f, "*"); ok {
+			if strings.HasPrefix(serie, prefix) {
+				return true
+			}
+		} else if serie == f {
+			return true
+		}
+	}
+	return false
+}
+
+// recordSerie devuelve la serie (primera columna) de un registro en bruto
+func recordSerie(record string) string {
+	columns := strings.FieldsFunc(record, func(r rune) bool {
+		return strings.ContainsRune(recordSeparators, r)
+	})
+	if len(columns) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(columns[0])
+}
+
+// filterSeries deja solo los registros incluidos (si hay lista de inclusión) y quita los
+// excluidos, por ejemplo las series ya procesadas que siguen en la lista pegada
+func filterSeries(records []string, include, exclude string) []string {
+	includes, excludes := parseFilterList(include), parseFilterList(exclude)
+	if len(includes) == 0 && len(excludes) == 0 {
+		return records
+	}
+
+	var out []string
+	for _, r := range records {
+		serie := recordSerie(r)
+		if len(includes) > 0 && !matchesFilter(serie, includes) {
+			continue
+		}
+		if matchesFilter(serie, excludes) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func (a *Autocopiador) createFilterPanel() *widget.Accordion {
+	a.includeInput = widget.NewMultiLineEntry()
+	a.includeInput.SetPlaceHolder("Vacío = todas. Ej: ZET00* 0154")
+	a.includeInput.SetMinRowsVisible(2)
+
+	a.excludeInput = widget.NewMultiLineEntry()
+	a.excludeInput.SetPlaceHolder("Series ya procesadas. Ej: 0083 ZET001*")
+	a.excludeInput.SetMinRowsVisible(2)
+
+	return widget.NewAccordion(
+		widget.NewAccordionItem("🚫 Filtros de series", container.NewVBox(
+			widget.NewLabel("Incluir solo:"),
+			a.includeInput,
+			widget.NewLabel("Excluir:"),
+			a.excludeInput,
+		)),
+	)
+}
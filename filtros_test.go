@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterSeries(t *testing.T) {
+	records := []string{"0154", "0083", "ZET00154;15/03/2024", "ZET00200", "zet01000"}
+
+	tests := []struct {
+		name    string
+		include string
+		exclude string
+		want    []string
+	}{
+		{name: "sin filtros", want: records},
+		{name: "excluir exactas", exclude: "0083, 0154", want: []string{"ZET00154;15/03/2024", "ZET00200", "zet01000"}},
+		{name: "incluir por prefijo", include: "zet00*", want: []string{"ZET00154;15/03/2024", "ZET00200"}},
+		{name: "incluir y excluir", include: "ZET*", exclude: "ZET00154\nZET01*", want: []string{"ZET00200"}},
+		{name: "nada coincide", include: "ABC*", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterSeries(records, tt.include, tt.exclude)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterSeries(%q, %q) = %q, se esperaba %q", tt.include, tt.exclude, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	batchSingleFile = "Un PDF con todas las páginas"
+	batchOneEach    = "Un PDF por destinatario"
+	maxBatchErrors  = 10 // Filas con error que se listan; del resto solo se dice cuántas son
+)
+
+// batchColumns reconoce los títulos de columna del CSV, ya en minúsculas y sin tildes, como
+// los escribe la gente en Excel
+var batchColumns = map[string]string{
+	"nombre":         "nombre",
+	"destinatario":   "nombre",
+	"cliente":        "nombre",
+	"direccion":      "direccion",
+	"domicilio":      "direccion",
+	"telefono":       "telefono",
+	"celular":        "telefono",
+	"tel":            "telefono",
+	"dni":            "dni",
+	"documento":      "dni",
+	"peso":           "peso",
+	"observaciones":  "observaciones",
+	"observacion":    "observaciones",
+	"obs":            "observaciones",
+	"notas":          "observaciones",
+	"guia":           "guia",
+	"numero de guia": "guia",
+	"tracking":       "guia",
+}
+
+// csvDelimiter adivina el separador mirando la primera línea: Excel en español guarda los
+// CSV con punto y coma porque la coma es el separador decimal
+func csvDelimiter(text string) rune {
+	first, _, _ := strings.Cut(text, "\n")
+	best, count := ',', strings.Count(first, ",")
+	for _, d := range []rune{';', '\t'} {
+		if n := strings.Count(first, string(d)); n > count {
+			best, count = d, n
+		}
+	}
+	return best
+}
+
+// parseBatchCSV lee los destinatarios de un CSV con títulos en la primera fila. Solo la
+// columna del nombre es obligatoria; las filas vacías se saltan. Si alguna fila tiene un
+// error no devuelve ninguna, para no generar medio lote.
+func parseBatchCSV(data []byte) ([]RotuloData, error) {
+	text, _ := decodeLegacyText(data)
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = csvDelimiter(text)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("el archivo no es un CSV válido: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("el archivo está vacío")
+	}
+
+	columns := map[string]int{}
+	for i, title := range records[0] {
+		if name, ok := batchColumns[searchKey(title)]; ok {
+			if _, seen := columns[name]; !seen {
+				columns[name] = i
+			}
+		}
+	}
+	if _, ok := columns["nombre"]; !ok {
+		return nil, fmt.Errorf("falta la columna Nombre; la primera fila debe tener los títulos: Nombre, Dirección, Teléfono, DNI, Peso, Observaciones")
+	}
+
+	var labels []RotuloData
+	var problems []string
+	for i, record := range records[1:] {
+		field := func(name string) string {
+			if col, ok := columns[name]; ok && col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		row := i + 2 // Como la numera Excel, contando los títulos
+		label := RotuloData{
+			DestinatarioNombre:    field("nombre"),
+			DestinatarioDireccion: field("direccion"),
+			DestinatarioTelefono:  field("telefono"),
+			DestinatarioDNI:       field("dni"),
+			Peso:                  field("peso"),
+			Observaciones:         field("observaciones"),
+			NumeroGuia:            field("guia"),
+		}
+		if label.DestinatarioNombre == "" {
+			problems = append(problems, fmt.Sprintf("Fila %d: falta el nombre del destinatario", row))
+			continue
+		}
+		if label.DestinatarioDNI != "" && !dniRegex.MatchString(label.DestinatarioDNI) {
+			problems = append(problems, fmt.Sprintf("Fila %d: el DNI %s no tiene 8 dígitos", row, label.DestinatarioDNI))
+			continue
+		}
+		if label.NumeroGuia != "" {
+			if _, err := code128Values(label.NumeroGuia); err != nil {
+				problems = append(problems, fmt.Sprintf("Fila %d: %v", row, err))
+				continue
+			}
+		}
+		labels = append(labels, label)
+	}
+	if len(problems) > 0 {
+		if len(problems) > maxBatchErrors {
+			problems = append(problems[:maxBatchErrors], fmt.Sprintf("... y %d filas más", len(problems)-maxBatchErrors))
+		}
+		return nil, fmt.Errorf("corrige el archivo y vuelve a intentarlo:\n%s", strings.Join(problems, "\n"))
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("el archivo no tiene destinatarios")
+	}
+	return labels, nil
+}
+
+// missingGuias cuenta las filas que no traen guía y necesitan una del contador
+func missingGuias(rows []RotuloData) int {
+	count := 0
+	for _, row := range rows {
+		if row.NumeroGuia == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// batchLabels completa cada fila con la empresa, el remitente y el formato de base. Las
+// filas sin guía reciben, en orden, los números del contador de la empresa desde first con
+// su máscara.
+func batchLabels(rows []RotuloData, base RotuloData, now time.Time, first int) []RotuloData {
+	labels := make([]RotuloData, len(rows))
+	next := first
+	for i, row := range rows {
+		label := base
+		label.DestinatarioNombre = row.DestinatarioNombre
+		label.DestinatarioDireccion = row.DestinatarioDireccion
+		label.DestinatarioTelefono = row.DestinatarioTelefono
+		label.DestinatarioDNI = row.DestinatarioDNI
+		label.Peso = row.Peso
+		label.Observaciones = row.Observaciones
+		label.NumeroGuia = row.NumeroGuia
+		label.FechaEnvio = now
+		if label.NumeroGuia == "" {
+			label.NumeroGuia = formatGuia(base.Empresa, next, now)
+			next++
+		}
+		labels[i] = label
+	}
+	return labels
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// batchFileName es el archivo de un rótulo cuando se genera uno por destinatario
+func batchFileName(label RotuloData) string {
+	return fmt.Sprintf("rotulo_%s_%s.pdf", label.Empresa, unsafeFileChars.ReplaceAllString(label.NumeroGuia, "_"))
+}
+
+// showBatch genera los rótulos de un CSV de destinatarios con la empresa, el remitente, el
+// tamaño y la orientación que están elegidos en el formulario
+func (r *RotuloGenerator) showBatch(window fyne.Window) {
+	if r.data.RemitenteNombre == "" {
+		dialog.ShowError(fmt.Errorf("completa primero los datos del remitente; se usan en todos los rótulos"), window)
+		return
+	}
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		data, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		rows, err := parseBatchCSV(data)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		r.confirmBatch(rows, reader.URI().Name(), window)
+	}, window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".txt"}))
+	open.Show()
+}
+
+// confirmBatch muestra cuántos rótulos salen del archivo y pregunta cómo guardarlos. Las
+// guías que faltan se apartan recién al confirmar, así cancelar no deja huecos en el contador.
+func (r *RotuloGenerator) confirmBatch(rows []RotuloData, file string, window fyne.Window) {
+	base := *r.data
+	first, err := peekGuiaNumber(base.Empresa)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	labels := batchLabels(rows, base, time.Now(), first)
+	modeRadio := widget.NewRadioGroup([]string{batchSingleFile, batchOneEach}, nil)
+	modeRadio.SetSelected(batchSingleFile)
+	size := base.TamanoHoja + " - " + base.Orientacion
+	if layout := findSheetLayout(base.Distribucion); layout.perSheet() > 1 {
+		size = layout.Name + " con guías de corte"
+	}
+	summary := widget.NewLabel(fmt.Sprintf("%d destinatarios en %s\n\n🏢 Empresa: %s\n👤 Remitente: %s\n📏 Tamaño: %s\n📦 Guías: %s ... %s",
+		len(labels), file, base.Empresa, base.RemitenteNombre, size,
+		labels[0].NumeroGuia, labels[len(labels)-1].NumeroGuia))
+	content := container.NewVBox(summary, widget.NewSeparator(), modeRadio)
+
+	dialog.ShowCustomConfirm("📚 Generación masiva", "Generar", "Cancelar", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		first, err := reserveGuias(base.Empresa, missingGuias(rows))
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		labels := batchLabels(rows, base, time.Now(), first)
+		if modeRadio.Selected == batchOneEach {
+			r.saveBatchFiles(labels, window)
+		} else {
+			r.saveBatchPDF(labels, window)
+		}
+	}, window)
+}
+
+// saveBatchPDF guarda todos los rótulos en un solo PDF, uno por página
+func (r *RotuloGenerator) saveBatchPDF(labels []RotuloData, window fyne.Window) {
+	pdfData, err := createLabelsPDF(labels)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error generando PDF: %v", err), window)
+		return
+	}
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		if _, err := writer.Write(pdfData); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		r.recordLabels(labels, writer.URI().Path())
+		dialog.ShowInformation("✅ Rótulos Generados",
+			fmt.Sprintf("Se generaron %d rótulos en %s.", len(labels), filepath.Base(writer.URI().Path())), window)
+	}, window)
+	saveDialog.SetFileName(fmt.Sprintf("rotulos_%s_%s.pdf", r.data.Empresa, time.Now().Format("20060102_150405")))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
+	saveDialog.Show()
+}
+
+// saveBatchFiles guarda un PDF por destinatario en la carpeta elegida
+func (r *RotuloGenerator) saveBatchFiles(labels []RotuloData, window fyne.Window) {
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if uri == nil {
+			return
+		}
+		dir := uri.Path()
+		saved := 0
+		var failed []string
+		for _, label := range labels {
+			file := filepath.Join(dir, batchFileName(label))
+			pdfData, err := createLabelsPDF([]RotuloData{label})
+			if err == nil {
+				err = ioutil.WriteFile(file, pdfData, 0644)
+			}
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", label.DestinatarioNombre, err))
+				continue
+			}
+			r.historial = addLabelRecords(r.historial, []RotuloData{label}, file, time.Now())
+			saved++
+		}
+		if err := saveLabelHistory(r.historial); err != nil {
+			log.Printf("Error guardando %s: %v", labelHistoryFile, err)
+		}
+		r.refreshHistory()
+		message := fmt.Sprintf("Se generaron %d rótulos en %s.", saved, dir)
+		if len(failed) > 0 {
+			message += "\n\nNo se pudieron generar:\n" + strings.Join(failed, "\n")
+		}
+		dialog.ShowInformation("✅ Rótulos Generados", message, window)
+	}, window)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBatchCSV(t *testing.T) {
+	// Como lo guarda Excel en español: punto y coma, Latin-1 y CRLF
+	data := []byte("Nombre;Direcci\xf3n;Tel\xe9fono;DNI;Peso;Observaciones\r\n" +
+		"Mar\xeda Gonz\xe1lez;\"Jr. Los Olivos 456; Miraflores\";888 777 666;45678912;2.5 kg;Fr\xe1gil\r\n" +
+		";;;;;\r\n" +
+		"Juan P\xe9rez;Av. Arequipa 123;;;;\r\n")
+	labels, err := parseBatchCSV(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 {
+		t.Fatalf("se leyeron %d filas: %v", len(labels), labels)
+	}
+	first := labels[0]
+	if first.DestinatarioNombre != "María González" || first.DestinatarioDireccion != "Jr. Los Olivos 456; Miraflores" ||
+		first.DestinatarioTelefono != "888 777 666" || first.DestinatarioDNI != "45678912" ||
+		first.Peso != "2.5 kg" || first.Observaciones != "Frágil" {
+		t.Errorf("primera fila = %+v", first)
+	}
+	if labels[1].DestinatarioNombre != "Juan Pérez" || labels[1].DestinatarioTelefono != "" {
+		t.Errorf("segunda fila = %+v", labels[1])
+	}
+
+	// Con comas, columnas en otro orden y sinónimos
+	labels, err = parseBatchCSV([]byte("guía,cliente,celular\nZET900,Ana Quispe,987654321\n"))
+	if err != nil || len(labels) != 1 || labels[0].NumeroGuia != "ZET900" || labels[0].DestinatarioNombre != "Ana Quispe" || labels[0].DestinatarioTelefono != "987654321" {
+		t.Errorf("con comas = %+v, %v", labels, err)
+	}
+}
+
+func TestParseBatchCSVErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                                     "vacío",
+		"Direccion,Telefono\nAv. Lima 1,999\n": "columna Nombre",
+		"Nombre\n\n":                           "no tiene destinatarios",
+		"Nombre;DNI\nJuan;1234\n;45678912\n":   "Fila 3: falta el nombre",
+		"Nombre;Guia\nJuan;ZET-ñ\n":            "Fila 2:",
+	}
+	for data, want := range cases {
+		if _, err := parseBatchCSV([]byte(data)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseBatchCSV(%q) = %v, se esperaba %q", data, err, want)
+		}
+	}
+	if _, err := parseBatchCSV([]byte("Nombre;DNI\nJuan;1234\n")); err == nil || !strings.Contains(err.Error(), "Fila 2: el DNI 1234") {
+		t.Errorf("DNI inválido = %v", err)
+	}
+}
+
+func TestBatchLabels(t *testing.T) {
+	base := RotuloData{Empresa: "ZETTACOM", RemitenteNombre: "ZETTACOM S.A.C", TamanoHoja: "A5", Orientacion: "Horizontal",
+		DestinatarioNombre: "Del formulario", Peso: "9 kg"}
+	rows := []RotuloData{{DestinatarioNombre: "María"}, {DestinatarioNombre: "Juan", NumeroGuia: "ZET777"}}
+	now := time.Unix(1700000123, 0)
+	rows = append(rows, RotuloData{DestinatarioNombre: "Ana"})
+	labels := batchLabels(rows, base, now, 41)
+	if labels[0].NumeroGuia != "ZET-000041" || labels[1].NumeroGuia != "ZET777" || labels[2].NumeroGuia != "ZET-000042" {
+		t.Errorf("guías = %s %s %s", labels[0].NumeroGuia, labels[1].NumeroGuia, labels[2].NumeroGuia)
+	}
+	if got := missingGuias(rows); got != 2 {
+		t.Errorf("missingGuias = %d", got)
+	}
+	for _, label := range labels {
+		if label.RemitenteNombre != "ZETTACOM S.A.C" || label.TamanoHoja != "A5" || label.Orientacion != "Horizontal" || label.Peso != "" || !label.FechaEnvio.Equal(now) {
+			t.Errorf("rótulo = %+v", label)
+		}
+	}
+	if got := batchFileName(RotuloData{Empresa: "ZETTACOM", NumeroGuia: "ZET 12/3"}); got != "rotulo_ZETTACOM_ZET_12_3.pdf" {
+		t.Errorf("batchFileName = %s", got)
+	}
+}
+
+func TestCreateLabelsPDF(t *testing.T) {
+	label := RotuloData{Empresa: "COMSITEC", RemitenteNombre: "COMSITEC S.A.C", DestinatarioNombre: "María",
+		TamanoHoja: "A4", Orientacion: "Vertical", FechaEnvio: time.Now()}
+	labels := batchLabels([]RotuloData{{DestinatarioNombre: "María"}, {DestinatarioNombre: "Juan"}, {DestinatarioNombre: "Ana"}}, label, time.Now(), 1)
+	for _, n := range []int{1, 3} {
+		data, err := createLabelsPDF(labels[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pages := bytes.Count(data, []byte("/Type /Page\n")); pages != n {
+			t.Errorf("%d rótulos ocupan %d páginas", n, pages)
+		}
+	}
+}
+
+func TestCreateLabelsPDFPaperSizes(t *testing.T) {
+	for _, name := range paperSizeNames {
+		size := paperSizes[name]
+		label := RotuloData{Empresa: "COMSITEC", RemitenteNombre: "COMSITEC S.A.C", DestinatarioNombre: "María",
+			DestinatarioDNI: "45678912", NumeroGuia: "COM123456", TamanoHoja: name, Orientacion: "Vertical", FechaEnvio: time.Now()}
+		data, err := createLabelsPDF([]RotuloData{label})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if pages := bytes.Count(data, []byte("/Type /Page\n")); pages != 1 {
+			t.Errorf("%s ocupa %d páginas", name, pages)
+		}
+		box := fmt.Sprintf("/MediaBox [0 0 %.2f %.2f]", size.Width*72/25.4, size.Height*72/25.4)
+		if !bytes.Contains(data, []byte(box)) {
+			t.Errorf("%s no tiene %s", name, box)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showRangeGenerator abre el diálogo que agrega a la lista un rango de series consecutivas
+func (a *Autocopiador) showRangeGenerator() {
+	prefixInput := widget.NewEntry()
+	prefixInput.SetPlaceHolder("Ej: ZET")
+	startInput := widget.NewEntry()
+	startInput.SetText("1")
+	countInput := widget.NewEntry()
+	countInput.SetText("10")
+	paddingInput := widget.NewEntry()
+	paddingInput.SetText("5")
+
+	preview := widget.NewLabel("")
+	preview.Importance = widget.LowImportance
+
+	// generate lee el formulario y arma el rango
+	generate := func() ([]string, error) {
+		start, err := strconv.Atoi(strings.TrimSpace(startInput.Text))
+		if err != nil {
+			return nil, fmt.Errorf("el número inicial debe ser un entero")
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countInput.Text))
+		if err != nil {
+			return nil, fmt.Errorf("la cantidad debe ser un entero")
+		}
+		padding, err := strconv.Atoi(strings.TrimSpace(paddingInput.Text))
+		if err != nil {
+			return nil, fmt.Errorf("los dígitos deben ser un entero")
+		}
+		return seriesRange(strings.TrimSpace(prefixInput.Text), start, count, padding)
+	}
+
+	updatePreview := func(string) {
+		series, err := generate()
+		if err != nil {
+			preview.SetText("⚠️ " + err.Error())
+			return
+		}
+		preview.SetText(fmt.Sprintf("%s – %s (%d series)", series[0], series[len(series)-1], len(series)))
+	}
+	for _, entry := range []*widget.Entry{prefixInput, startInput, countInput, paddingInput} {
+		entry.OnChanged = updatePreview
+	}
+	updatePreview("")
+
+	form := dialog.NewForm("🔢 Generar rango de series", "Agregar", "Cancelar",
+		[]*widget.FormItem{
+			widget.NewFormItem("Prefijo", prefixInput),
+			widget.NewFormItem("Número inicial", startInput),
+			widget.NewFormItem("Cantidad", countInput),
+			widget.NewFormItem("Dígitos", paddingInput),
+			widget.NewFormItem("", preview),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			series, err := generate()
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+
+			a.appendSeries(series)
+			a.statusLabel.SetText(fmt.Sprintf("Estado: %d series generadas", len(series)))
+		}, a.window)
+	form.Show()
+}
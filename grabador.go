@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	hook "github.com/robotn/gohook"
+)
+
+// Botones del mouse según gohook
+var hookMouseButtons = map[uint16]string{1: "left", 2: "right", 3: "center"}
+
+// macroRecorder convierte los eventos globales de teclado y mouse en pasos de la secuencia
+type macroRecorder struct {
+	mu    sync.Mutex
+	steps []MacroStep
+	mods  map[string]bool
+}
+
+func newMacroRecorder() *macroRecorder {
+	return &macroRecorder{mods: map[string]bool{}}
+}
+
+// hookKeyName devuelve el nombre de tecla (el de la secuencia) de un código de gohook
+func hookKeyName(code uint16) string {
+	name := ""
+	for n, c := range hook.Keycode {
+		if c != code {
+			continue
+		}
+		// Varios nombres comparten código: preferir los que entiende la secuencia
+		if macroKeys[n] || macroModifiers[n] || len(n) == 1 {
+			return n
+		}
+		if trimmed := strings.TrimLeft(n, "lr"); macroModifiers[trimmed] {
+			name = trimmed
+		} else if name == "" {
+			name = n
+		}
+	}
+	return name
+}
+
+// handle registra un evento; se llama desde el goroutine del hook
+func (r *macroRecorder) handle(ev hook.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch ev.Kind {
+	case hook.KeyDown:
+		key := hookKeyName(ev.Keycode)
+		if macroModifiers[key] {
+			r.mods[key] = true
+			return
+		}
+
+		var mods []string
+		shortcut := false
+		for _, m := range []string{"ctrl", "alt", "shift", "cmd"} {
+			if r.mods[m] {
+				mods = append(mods, m)
+				shortcut = shortcut || m != "shift"
+			}
+		}
+		// Las letras y el espacio llegan como texto salvo que formen un atajo (ctrl+s)
+		if (macroKeys[key] && key != "space") || (shortcut && len(key) == 1) {
+			r.steps = append(r.steps, MacroStep{Tipo: stepKey, Valor: key, Args: mods})
+		}
+	case hook.KeyUp:
+		delete(r.mods, hookKeyName(ev.Keycode))
+	case hook.KeyHold:
+		// Evento de carácter escrito: se acumula en el último paso de texto
+		if !unicode.IsPrint(ev.Keychar) || r.mods["ctrl"] || r.mods["alt"] || r.mods["cmd"] {
+			return
+		}
+		if n := len(r.steps); n > 0 && r.steps[n-1].Tipo == stepText {
+			r.steps[n-1].Valor += string(ev.Keychar)
+			return
+		}
+		r.steps = append(r.steps, MacroStep{Tipo: stepText, Valor: string(ev.Keychar)})
+	case hook.MouseDown:
+		button, ok := hookMouseButtons[ev.Button]
+		if !ok {
+			return
+		}
+		x, y := int(ev.X), int(ev.Y)
+		// El segundo clic de un doble clic marca el paso anterior
+		if n := len(r.steps); ev.Clicks >= 2 && n > 0 {
+			last := &r.steps[n-1]
+			if last.Tipo == stepClick && last.X == x && last.Y == y && last.Valor == button {
+				last.Doble = true
+				return
+			}
+		}
+		r.steps = append(r.steps, MacroStep{Tipo: stepClick, Valor: button, X: x, Y: y})
+	}
+}
+
+// result devuelve los pasos grabados sin el último clic, que es el de "Detener grabación"
+func (r *macroRecorder) result() []MacroStep {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	steps := append([]MacroStep(nil), r.steps...)
+	if n := len(steps); n > 0 && steps[n-1].Tipo == stepClick {
+		steps = steps[:n-1]
+	}
+	return steps
+}
+
+// recordedMacro arma el texto editable de la secuencia. Los textos que coinciden con la
+// serie o la fecha de ejemplo se reemplazan por {serie} y {fecha}.
+func recordedMacro(steps []MacroStep, serie, fecha string) string {
+	lines := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if step.Tipo == stepText {
+			text := strings.TrimSpace(step.Valor)
+			if text == "" {
+				continue
+			}
+			if serie != "" && text == serie {
+				text = "{serie}"
+			} else if normalized, err := normalizeDate(text); fecha != "" && err == nil && normalized == fecha {
+				text = "{fecha}"
+			}
+			lines = append(lines, text)
+			continue
+		}
+		lines = append(lines, formatMacroStep(step))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// toggleRecording inicia o detiene la grabación de una entrada manual
+func (a *Autocopiador) toggleRecording(button *widget.Button) {
+	if a.recorder != nil {
+		recorder := a.recorder
+		a.recorder = nil
+		setHookObserver(nil)
+		button.SetText("⏺️ Grabar")
+
+		steps := recorder.result()
+		if len(steps) == 0 {
+			a.statusLabel.SetText("Estado: No se grabó ninguna acción")
+			return
+		}
+
+		// La primera serie y la fecha del formulario sirven para reconocer las variables
+		serie := ""
+		if records := splitRecords(a.seriesInput.Text); len(records) > 0 {
+			if rec, err := parseRecord(records[0]); err == nil {
+				serie = rec.Serie
+			}
+		}
+		fecha, _ := normalizeDate(a.dateInput.Text)
+
+		a.macroInput.SetText(recordedMacro(steps, serie, fecha))
+		a.statusLabel.SetText(fmt.Sprintf("Estado: Secuencia grabada (%d acciones), revísala antes de iniciar", len(steps)))
+		return
+	}
+
+	recorder := newMacroRecorder()
+	a.recorder = recorder
+	button.SetText("⏹️ Detener grabación")
+	go func() {
+		for i := 3; i > 0; i-- {
+			remaining := i
+			fyne.Do(func() {
+				a.statusLabel.SetText(fmt.Sprintf("Cambia a la aplicación destino, la grabación empieza en %d...", remaining))
+			})
+			time.Sleep(time.Second)
+		}
+		fyne.Do(func() {
+			// Se pudo detener durante la cuenta regresiva
+			if a.recorder != recorder {
+				return
+			}
+			setHookObserver(recorder.handle)
+			a.statusLabel.SetText("Grabando: realiza una entrada completa y pulsa \"Detener grabación\"")
+		})
+	}()
+}
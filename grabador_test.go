@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordedMacro(t *testing.T) {
+	steps := []MacroStep{
+		{Tipo: stepClick, Valor: "left", X: 120, Y: 340},
+		{Tipo: stepText, Valor: "ZET001"},
+		{Tipo: stepKey, Valor: "tab"},
+		{Tipo: stepText, Valor: "15/03/2024 "},
+		{Tipo: stepKey, Valor: "s", Args: []string{"ctrl"}},
+		{Tipo: stepText, Valor: "  "},
+		{Tipo: stepClick, Valor: "right", X: 5, Y: 6, Doble: true},
+	}
+	want := "[clic 120 340]\n{serie}\n[tab]\n{fecha}\n[ctrl+s]\n[clic 5 6 derecho doble]"
+
+	got := recordedMacro(steps, "ZET001", "15032024")
+	if got != want {
+		t.Fatalf("recordedMacro =\n%s\nse esperaba\n%s", got, want)
+	}
+
+	// Lo grabado debe volver a interpretarse igual
+	parsed, err := parseMacro(got)
+	if err != nil {
+		t.Fatalf("parseMacro(grabado): %v", err)
+	}
+	if len(parsed) != 6 || parsed[5].Valor != "right" || !parsed[5].Doble {
+		t.Errorf("parseMacro(grabado) = %+v", parsed)
+	}
+
+	if got := recordedMacro(steps[1:2], "", ""); got != "ZET001" {
+		t.Errorf("recordedMacro sin ejemplo = %q, se esperaba el texto literal", got)
+	}
+}
+
+func TestFormatMacroStepWait(t *testing.T) {
+	step := MacroStep{Tipo: stepWait, Valor: "00ff00", X: 1, Y: 2, Espera: 30 * time.Second}
+	if got := formatMacroStep(step); got != "[esperar 1 2 00ff00 30]" {
+		t.Errorf("formatMacroStep = %q", got)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Tipos de cambio del historial del editor
+const (
+	historyTyping  = "Escritura"
+	historyAuto    = "Hora automática"
+	historyReplace = "Reemplazo"
+	historyTable   = "Edición en tabla"
+	historySort    = "Orden"
+	historyDups    = "Repetidos quitados"
+	historyCheck   = "Casillas"
+	historyMerge   = "Combinación"
+	historyCapture = "Portapapeles"
+	historyFormat  = "Formato de línea"
+	historyRescue  = "Recuperación"
+	historyFold    = "Plegado"
+	historyColumn  = "Edición en columna"
+	historyHeader  = "Plantilla de empresa"
+	historyDupLine = "Línea duplicada"
+	historyRestore = "Versión restaurada"
+	historyClear   = "Contenido limpiado"
+	historySkip    = "-" // Cambios que no se registran: cargar una nota, deshacer y rehacer
+)
+
+const (
+	historyMax         = 200             // Pasos que se pueden deshacer
+	historyGroupWindow = 2 * time.Second // Lo escrito sin pausas más largas se deshace de una vez
+)
+
+// historyEntry guarda el texto que había antes de un cambio
+type historyEntry struct {
+	Texto string
+	Tipo  string
+	Fecha time.Time
+}
+
+// editHistory es la pila de deshacer y rehacer del bloc de notas. Las actualizaciones
+// automáticas de hora seguidas forman un solo paso, separado de lo que escribe el usuario.
+type editHistory struct {
+	past   []historyEntry
+	future []historyEntry
+}
+
+// record registra que el texto era before antes de un cambio de tipo kind
+func (h *editHistory) record(before, kind string, now time.Time) {
+	h.future = nil
+	if n := len(h.past); n > 0 {
+		top := &h.past[n-1]
+		grouped := kind == historyAuto || ((kind == historyTyping || kind == historyTable) && now.Sub(top.Fecha) < historyGroupWindow)
+		if top.Tipo == kind && grouped {
+			top.Fecha = now
+			return
+		}
+	}
+	h.past = append(h.past, historyEntry{Texto: before, Tipo: kind, Fecha: now})
+	if len(h.past) > historyMax {
+		h.past = h.past[len(h.past)-historyMax:]
+	}
+}
+
+// undo devuelve el texto anterior al último cambio; current queda para rehacer
+func (h *editHistory) undo(current string) (string, bool) {
+	n := len(h.past)
+	if n == 0 {
+		return "", false
+	}
+	top := h.past[n-1]
+	h.past = h.past[:n-1]
+	h.future = append(h.future, historyEntry{Texto: current, Tipo: top.Tipo, Fecha: top.Fecha})
+	return top.Texto, true
+}
+
+// redo vuelve a aplicar el último cambio deshecho
+func (h *editHistory) redo(current string) (string, bool) {
+	n := len(h.future)
+	if n == 0 {
+		return "", false
+	}
+	next := h.future[n-1]
+	h.future = h.future[:n-1]
+	h.past = append(h.past, historyEntry{Texto: current, Tipo: next.Tipo, Fecha: next.Fecha})
+	return next.Texto, true
+}
+
+func (h *editHistory) reset() {
+	h.past, h.future = nil, nil
+}
+
+// entry devuelve el paso i contando desde el más reciente
+func (h *editHistory) entry(i int) historyEntry {
+	return h.past[len(h.past)-1-i]
+}
+
+// applyEdit cambia el texto del editor registrando el cambio como kind en el historial
+func (n *NotePad) applyEdit(kind, text string) {
+	n.pendingKind = kind
+	n.multiLine.SetText(text)
+	n.pendingKind = ""
+}
+
+// trackEdit se llama desde OnChanged con el texto anterior y el nuevo
+func (n *NotePad) trackEdit(before string) {
+	kind := n.pendingKind
+	if kind == "" {
+		kind = historyTyping
+	}
+	if kind != historyAuto {
+		n.lastUserEdit = time.Now()
+	}
+	if kind != historySkip {
+		n.history.record(before, kind, time.Now())
+	}
+	n.refreshHistory()
+}
+
+// undoEdit y redoEdit conservan la posición del cursor, igual que la hora automática
+func (n *NotePad) undoEdit() {
+	if text, ok := n.history.undo(n.multiLine.Text); ok {
+		n.restoreHistoryText(text)
+		n.statusLabel.SetText("Estado: Cambio deshecho")
+	}
+}
+
+func (n *NotePad) redoEdit() {
+	if text, ok := n.history.redo(n.multiLine.Text); ok {
+		n.restoreHistoryText(text)
+		n.statusLabel.SetText("Estado: Cambio rehecho")
+	}
+}
+
+func (n *NotePad) restoreHistoryText(text string) {
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	n.applyEdit(historySkip, text)
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+	n.multiLine.Refresh()
+	n.refreshHistory()
+}
+
+func (n *NotePad) refreshHistory() {
+	if n.historyList != nil {
+		n.historyList.UnselectAll()
+		n.historyList.Refresh()
+	}
+}
+
+// createHistoryCard muestra los pasos que se pueden deshacer, el más reciente arriba.
+// Elegir uno deshace hasta dejar el texto como estaba antes de ese cambio.
+func (n *NotePad) createHistoryCard() *widget.Card {
+	n.historyList = widget.NewList(
+		func() int {
+			return len(n.history.past)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			e := n.history.entry(id)
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s · %s", e.Fecha.Format("15:04:05"), e.Tipo))
+		},
+	)
+	n.historyList.OnSelected = func(id widget.ListItemID) {
+		text := n.multiLine.Text
+		for i := 0; i <= id; i++ {
+			text, _ = n.history.undo(text)
+		}
+		n.restoreHistoryText(text)
+		n.statusLabel.SetText(fmt.Sprintf("Estado: %d cambios deshechos", id+1))
+	}
+
+	n.multiLine.onUndo = n.undoEdit
+	n.multiLine.onRedo = n.redoEdit
+
+	listScroll := container.NewScroll(n.historyList)
+	listScroll.SetMinSize(fyne.NewSize(250, 120))
+
+	return widget.NewCard("↶ Historial de cambios", "",
+		container.NewVBox(
+			container.NewGridWithColumns(2,
+				widget.NewButton("↶ Deshacer (Ctrl+Z)", n.undoEdit),
+				widget.NewButton("↷ Rehacer (Ctrl+Y)", n.redoEdit),
+			),
+			listScroll,
+		),
+	)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEditHistoryGrouping(t *testing.T) {
+	var h editHistory
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+
+	// Escritura continua: un solo paso
+	h.record("", historyTyping, start)
+	h.record("R", historyTyping, start.Add(500*time.Millisecond))
+	h.record("RE", historyTyping, start.Add(time.Second))
+	// Dos actualizaciones de hora seguidas: otro paso
+	h.record("REPO 09:00", historyAuto, start.Add(time.Minute))
+	h.record("REPO 09:01", historyAuto, start.Add(2*time.Minute))
+	// Escritura después de una pausa larga: paso nuevo
+	h.record("REPO 09:02", historyTyping, start.Add(3*time.Minute))
+
+	if len(h.past) != 3 {
+		t.Fatalf("se esperaban 3 pasos, hay %d: %v", len(h.past), h.past)
+	}
+	if h.entry(0).Tipo != historyTyping || h.entry(1).Tipo != historyAuto || h.entry(2).Texto != "" {
+		t.Errorf("pasos mal agrupados: %v", h.past)
+	}
+
+	text, ok := h.undo("REPO 09:02 JRIOS")
+	if !ok || text != "REPO 09:02" {
+		t.Errorf("primer deshacer = %q, %v", text, ok)
+	}
+	text, _ = h.undo(text)
+	if text != "REPO 09:00" {
+		t.Errorf("deshacer la hora automática debería volver a la hora anterior de una vez: %q", text)
+	}
+	text, ok = h.redo(text)
+	if !ok || text != "REPO 09:02" {
+		t.Errorf("rehacer = %q, %v", text, ok)
+	}
+	if text, _ = h.redo(text); text != "REPO 09:02 JRIOS" {
+		t.Errorf("segundo rehacer = %q", text)
+	}
+	if _, ok := h.redo(text); ok {
+		t.Error("no debería quedar nada para rehacer")
+	}
+}
+
+func TestEditHistoryNewEditClearsRedo(t *testing.T) {
+	var h editHistory
+	now := time.Now()
+	h.record("a", historyTyping, now)
+	h.undo("ab")
+	h.record("a", historyReplace, now.Add(time.Second))
+	if _, ok := h.redo("x"); ok {
+		t.Error("un cambio nuevo debería descartar lo que se podía rehacer")
+	}
+
+	// Pasos de distinto tipo nunca se agrupan
+	h.reset()
+	h.record("1", historyReplace, now)
+	h.record("2", historyReplace, now)
+	h.record("3", historyTyping, now)
+	if len(h.past) != 3 {
+		t.Errorf("se esperaban 3 pasos, hay %d", len(h.past))
+	}
+}
+
+func TestEditHistoryLimit(t *testing.T) {
+	var h editHistory
+	now := time.Now()
+	for i := 0; i < historyMax+10; i++ {
+		h.record(string(rune('a'+i%26)), historyReplace, now)
+	}
+	if len(h.past) != historyMax {
+		t.Errorf("el historial debería limitarse a %d pasos, tiene %d", historyMax, len(h.past))
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	labelHistoryFile = "historial_rotulos.json"
+	labelHistoryMax  = 5000 // Unos meses de envíos; los más viejos se descartan
+	labelHistoryHint = "Selecciona un rótulo para ver el detalle"
+)
+
+// RotuloRecord registra un rótulo generado con todos sus datos
+type RotuloRecord struct {
+	Generado time.Time
+	Salida   string // Ruta del archivo, o la impresora si se imprimió directo
+	Rotulo   RotuloData
+}
+
+func loadLabelHistory() []RotuloRecord {
+	data, err := ioutil.ReadFile(labelHistoryFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Error cargando %s: %v", labelHistoryFile, err)
+		}
+		return nil
+	}
+	var history []RotuloRecord
+	if err := json.Unmarshal(data, &history); err != nil {
+		log.Printf("Error leyendo %s: %v", labelHistoryFile, err)
+		return nil
+	}
+	return history
+}
+
+func saveLabelHistory(history []RotuloRecord) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(labelHistoryFile, data, 0644)
+}
+
+// addLabelRecords agrega los rótulos al inicio del historial, el último generado primero, y
+// descarta los más viejos si pasa de labelHistoryMax
+func addLabelRecords(history []RotuloRecord, labels []RotuloData, output string, now time.Time) []RotuloRecord {
+	records := make([]RotuloRecord, 0, len(labels)+len(history))
+	for i := len(labels) - 1; i >= 0; i-- {
+		records = append(records, RotuloRecord{Generado: now, Salida: output, Rotulo: labels[i]})
+	}
+	records = append(records, history...)
+	if len(records) > labelHistoryMax {
+		records = records[:labelHistoryMax]
+	}
+	return records
+}
+
+// searchLabelHistory devuelve las posiciones de los rótulos que tienen todas las palabras de
+// query en la guía o el nombre del destinatario; sin query devuelve todos
+func searchLabelHistory(history []RotuloRecord, query string) []int {
+	words := strings.Fields(searchKey(query))
+	var found []int
+	for i, rec := range history {
+		text := searchKey(rec.Rotulo.NumeroGuia + " " + rec.Rotulo.DestinatarioNombre)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+func labelRecordLine(rec RotuloRecord) string {
+	return fmt.Sprintf("%s · %s · %s · %s", rec.Generado.Format("02/01 15:04"), rec.Rotulo.NumeroGuia, rec.Rotulo.Empresa, rec.Rotulo.DestinatarioNombre)
+}
+
+// recordLabels guarda en el historial los rótulos que se acaban de generar en output
+func (r *RotuloGenerator) recordLabels(labels []RotuloData, output string) {
+	r.historial = addLabelRecords(r.historial, labels, output, time.Now())
+	if err := saveLabelHistory(r.historial); err != nil {
+		log.Printf("Error guardando %s: %v", labelHistoryFile, err)
+	}
+	r.refreshHistory()
+}
+
+// refreshHistory vuelve a buscar con el texto escrito; las posiciones cambian, así que se
+// descarta la selección
+func (r *RotuloGenerator) refreshHistory() {
+	if r.histList == nil {
+		return
+	}
+	r.histFound = searchLabelHistory(r.historial, r.histSearch.Text)
+	r.histPick = -1
+	r.histList.UnselectAll()
+	r.histList.Refresh()
+	r.histDetail.SetText(fmt.Sprintf("%d rótulos. %s", len(r.histFound), labelHistoryHint))
+}
+
+// createHistoryTab muestra los rótulos generados, con búsqueda por guía o destinatario y la
+// opción de volver a generar el PDF de uno
+func (r *RotuloGenerator) createHistoryTab(window fyne.Window) fyne.CanvasObject {
+	r.histSearch = widget.NewEntry()
+	r.histSearch.SetPlaceHolder("Buscar por número de guía o destinatario")
+	r.histDetail = widget.NewLabel(labelHistoryHint)
+	r.histDetail.Wrapping = fyne.TextWrapWord
+	r.histList = widget.NewList(
+		func() int { return len(r.histFound) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(labelRecordLine(r.historial[r.histFound[id]]))
+		},
+	)
+	r.histList.OnSelected = func(id widget.ListItemID) {
+		r.histPick = r.histFound[id]
+		rec := r.historial[r.histPick]
+		d := rec.Rotulo
+		r.histDetail.SetText(fmt.Sprintf("📦 Guía: %s\n🕘 Generado: %s\n📄 Salida: %s\n🏢 Empresa: %s\n\n"+
+			"👤 Remitente: %s\n%s\nTel: %s\n\n📍 Destinatario: %s\n%s\nTel: %s\nDNI: %s\n\n"+
+			"⚖️ Peso: %s\n📝 Observaciones: %s\n📏 Tamaño: %s - %s",
+			d.NumeroGuia, rec.Generado.Format("02/01/2006 15:04:05"), rec.Salida, d.Empresa,
+			d.RemitenteNombre, d.RemitenteDireccion, getValueOrDefault(d.RemitenteTelefono, "-"),
+			d.DestinatarioNombre, d.DestinatarioDireccion, getValueOrDefault(d.DestinatarioTelefono, "-"),
+			getValueOrDefault(d.DestinatarioDNI, "-"), getValueOrDefault(d.Peso, "-"),
+			getValueOrDefault(d.Observaciones, "-"), d.TamanoHoja, d.Orientacion))
+	}
+	r.histSearch.OnChanged = func(string) { r.refreshHistory() }
+
+	regenerateButton := widget.NewButton("📄 Regenerar PDF", func() {
+		if r.histPick < 0 || r.histPick >= len(r.historial) {
+			dialog.ShowInformation("Regenerar PDF", labelHistoryHint+".", window)
+			return
+		}
+		r.regenerateLabel(r.historial[r.histPick], window)
+	})
+	regenerateButton.Importance = widget.HighImportance
+	r.refreshHistory()
+
+	return container.NewBorder(
+		r.histSearch,
+		regenerateButton,
+		nil, nil,
+		container.NewHSplit(r.histList, container.NewVScroll(r.histDetail)),
+	)
+}
+
+// regenerateLabel vuelve a armar el PDF de un rótulo del historial con sus mismos datos,
+// fecha y guía incluidas. No se agrega al historial: es una copia de uno que ya está.
+func (r *RotuloGenerator) regenerateLabel(rec RotuloRecord, window fyne.Window) {
+	pdfData, err := createRotuloPDF(rec.Rotulo)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error generando PDF: %v", err), window)
+		return
+	}
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		if _, err := writer.Write(pdfData); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		dialog.ShowInformation("✅ Rótulo Regenerado",
+			fmt.Sprintf("Se volvió a generar el rótulo %s en %s.", rec.Rotulo.NumeroGuia, filepath.Base(writer.URI().Path())), window)
+	}, window)
+	saveDialog.SetFileName(batchFileName(rec.Rotulo))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".pdf"}))
+	saveDialog.Show()
+}
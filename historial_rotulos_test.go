@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAddLabelRecords(t *testing.T) {
+	earlier := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	history := []RotuloRecord{{Generado: earlier, Salida: "viejo.pdf", Rotulo: RotuloData{NumeroGuia: "ZET1"}}}
+
+	history = addLabelRecords(history, []RotuloData{{NumeroGuia: "ZET2"}, {NumeroGuia: "ZET3"}}, "lote.pdf", now)
+	var guias []string
+	for _, rec := range history {
+		guias = append(guias, rec.Rotulo.NumeroGuia)
+	}
+	if want := []string{"ZET3", "ZET2", "ZET1"}; !reflect.DeepEqual(guias, want) {
+		t.Errorf("orden del historial = %v; quería %v", guias, want)
+	}
+	if history[0].Salida != "lote.pdf" || !history[0].Generado.Equal(now) {
+		t.Errorf("registro nuevo = %+v", history[0])
+	}
+
+	labels := make([]RotuloData, labelHistoryMax)
+	if got := len(addLabelRecords(history, labels, "x.pdf", now)); got != labelHistoryMax {
+		t.Errorf("el historial quedó con %d registros; el máximo es %d", got, labelHistoryMax)
+	}
+}
+
+func TestSearchLabelHistory(t *testing.T) {
+	history := []RotuloRecord{
+		{Rotulo: RotuloData{NumeroGuia: "ZET123456", DestinatarioNombre: "María González"}},
+		{Rotulo: RotuloData{NumeroGuia: "COM000777", DestinatarioNombre: "Juan Pérez", DestinatarioDireccion: "Calle Maria 1"}},
+		{Rotulo: RotuloData{NumeroGuia: "ZET654321", DestinatarioNombre: "Ana Ruiz"}},
+	}
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{0, 1, 2}},
+		{"maria", []int{0}}, // La dirección no cuenta
+		{"zet", []int{0, 2}},
+		{"  perez com  ", []int{1}},
+		{"123456", []int{0}},
+		{"lima", nil},
+	}
+	for _, tt := range tests {
+		if got := searchLabelHistory(history, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("searchLabelHistory(%q) = %v; quería %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	maxLabelCopies = 50
+	labelPrintFile = "rotulo_imprimir.pdf" // En la carpeta temporal; se reemplaza en cada impresión
+)
+
+// pdfPrintCommand arma el comando que manda file a printer. Con CUPS (Linux y macOS) lp
+// imprime el PDF directamente. Windows no trae cómo imprimir un PDF sin abrirlo: se usa
+// SumatraPDF si está instalado (sumatra es su ruta) y si no el verbo PrintTo del visor de
+// PDF predeterminado, una vez por copia. La orientación ya va en el PDF.
+func pdfPrintCommand(goos, printer, file, title string, copies int, color bool, sumatra string) (string, []string) {
+	if goos != "windows" {
+		args := []string{"-d", printer, "-n", strconv.Itoa(copies), "-t", title, "-o", "fit-to-page"}
+		if !color {
+			args = append(args, "-o", "print-color-mode=monochrome")
+		}
+		return "lp", append(args, file)
+	}
+	if sumatra != "" {
+		settings := fmt.Sprintf("%dx,fit", copies)
+		if !color {
+			settings += ",monochrome"
+		}
+		return sumatra, []string{"-print-to", printer, "-print-settings", settings, "-silent", file}
+	}
+	script := fmt.Sprintf("for ($i = 0; $i -lt %d; $i++) { Start-Process -FilePath %s -Verb PrintTo -ArgumentList %s; Start-Sleep -Seconds 2 }",
+		copies, psQuote(file), psQuote(`"`+printer+`"`))
+	return "powershell", []string{"-NoProfile", "-Command", script}
+}
+
+// findSumatra busca SumatraPDF junto al programa, en el PATH o donde lo deja el instalador
+func findSumatra() string {
+	candidates := []string{"SumatraPDF.exe"}
+	for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "LOCALAPPDATA"} {
+		if dir := os.Getenv(env); dir != "" {
+			candidates = append(candidates, filepath.Join(dir, "SumatraPDF", "SumatraPDF.exe"))
+		}
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	if path, err := exec.LookPath("SumatraPDF.exe"); err == nil {
+		return path
+	}
+	return ""
+}
+
+// printPDF guarda data en la carpeta temporal y lo manda a la impresora
+func printPDF(data []byte, printer, title string, copies int, color bool) error {
+	file := filepath.Join(os.TempDir(), labelPrintFile)
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return err
+	}
+	sumatra := ""
+	if runtime.GOOS == "windows" {
+		sumatra = findSumatra()
+	}
+	name, args := pdfPrintCommand(runtime.GOOS, printer, file, title, copies, color, sumatra)
+	cmd := exec.Command(name, args...)
+	hideConsoleWindow(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("la impresora %q no aceptó el rótulo: %v %s", printer, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// printRotulo elige una impresora instalada, las copias, la orientación y el color, y manda
+// el mismo PDF que se guarda con "Generar PDF"
+func (r *RotuloGenerator) printRotulo(window fyne.Window) {
+	if r.data.RemitenteNombre == "" || r.data.DestinatarioNombre == "" {
+		dialog.ShowError(fmt.Errorf("debes completar al menos el nombre del remitente y destinatario"), window)
+		return
+	}
+	if err := r.ensureGuia(); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	if _, err := code128Values(r.data.NumeroGuia); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	printerSelect := widget.NewSelect(nil, nil)
+	printerSelect.PlaceHolder = "Buscando impresoras..."
+	copiesInput := widget.NewEntry()
+	copiesInput.SetText("1")
+	orientationRadio := widget.NewRadioGroup([]string{"Vertical", "Horizontal"}, nil)
+	orientationRadio.Horizontal = true
+	orientationRadio.SetSelected(r.data.Orientacion)
+	colorCheck := widget.NewCheck("Imprimir en color", nil)
+	colorCheck.SetChecked(true)
+
+	content := container.NewVBox(
+		widget.NewLabel("Selecciona la impresora:"),
+		printerSelect,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Copias", copiesInput),
+			widget.NewFormItem("Orientación", orientationRadio),
+		),
+		colorCheck,
+		widget.NewSeparator(),
+		widget.NewLabel(fmt.Sprintf("📄 Tamaño: %s", r.data.TamanoHoja)),
+	)
+	if runtime.GOOS == "windows" && findSumatra() == "" {
+		hint := widget.NewLabel("Sin SumatraPDF el rótulo se abre un momento en el visor de PDF para imprimirse; instálalo para imprimir sin ventanas.")
+		hint.Wrapping = fyne.TextWrapWord
+		content.Add(hint)
+	}
+
+	printerDialog := dialog.NewCustomConfirm("Imprimir Rótulo", "Imprimir", "Cancelar", content,
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			printer := printerSelect.Selected
+			if printer == "" {
+				dialog.ShowError(fmt.Errorf("elige una impresora"), window)
+				return
+			}
+			copies, err := strconv.Atoi(strings.TrimSpace(copiesInput.Text))
+			if err != nil || copies < 1 || copies > maxLabelCopies {
+				dialog.ShowError(fmt.Errorf("las copias deben ser un número entre 1 y %d", maxLabelCopies), window)
+				return
+			}
+			// La orientación elegida queda también en el formulario y en la vista previa
+			if orientationRadio.Selected != "" && orientationRadio.Selected != r.data.Orientacion {
+				r.orientacion.SetSelected(orientationRadio.Selected)
+			}
+			pdfData, err := r.createProfessionalPDF()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("error generando PDF: %v", err), window)
+				return
+			}
+			r.impresora = printer
+			title := fmt.Sprintf("Rótulo %s %s", r.data.Empresa, r.data.NumeroGuia)
+			inColor := colorCheck.Checked
+			label := *r.data
+			go func() {
+				err := printPDF(pdfData, printer, title, copies, inColor)
+				fyne.Do(func() {
+					if err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+					r.recordLabels([]RotuloData{label}, "Impresora "+printer)
+					dialog.ShowInformation("✅ Impresión Enviada",
+						fmt.Sprintf("Rótulo enviado a: %s\n\n"+
+							"🏢 Empresa: %s\n"+
+							"📦 Tracking: %s\n"+
+							"📏 Tamaño: %s - %s\n"+
+							"🖨️ Copias: %d",
+							printer, label.Empresa, label.NumeroGuia, label.TamanoHoja, label.Orientacion, copies), window)
+					r.releaseGuia(label.NumeroGuia)
+				})
+			}()
+		}, window)
+	printerDialog.Show()
+
+	go func() {
+		printers, def, err := listPrinters()
+		fyne.Do(func() {
+			if err != nil || len(printers) == 0 {
+				printerSelect.PlaceHolder = "No hay impresoras instaladas"
+				printerSelect.Refresh()
+				return
+			}
+			printerSelect.SetOptions(printers)
+			// La última usada para rótulos; si no, la predeterminada del sistema
+			for _, name := range []string{r.impresora, def, printers[0]} {
+				if name != "" && slices.Contains(printers, name) {
+					printerSelect.SetSelected(name)
+					break
+				}
+			}
+		})
+	}()
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPDFPrintCommand(t *testing.T) {
+	name, args := pdfPrintCommand("linux", "HP_LaserJet", "/tmp/rotulo.pdf", "Rótulo ZET123", 2, false, "")
+	want := []string{"-d", "HP_LaserJet", "-n", "2", "-t", "Rótulo ZET123", "-o", "fit-to-page", "-o", "print-color-mode=monochrome", "/tmp/rotulo.pdf"}
+	if name != "lp" || !reflect.DeepEqual(args, want) {
+		t.Errorf("con CUPS = %s %q", name, args)
+	}
+
+	name, args = pdfPrintCommand("windows", "Epson L3150", `C:\Temp\rotulo.pdf`, "", 3, true, `C:\SumatraPDF\SumatraPDF.exe`)
+	want = []string{"-print-to", "Epson L3150", "-print-settings", "3x,fit", "-silent", `C:\Temp\rotulo.pdf`}
+	if name != `C:\SumatraPDF\SumatraPDF.exe` || !reflect.DeepEqual(args, want) {
+		t.Errorf("con SumatraPDF = %s %q", name, args)
+	}
+
+	name, args = pdfPrintCommand("windows", "Epson L3150", `C:\Temp\rotulo.pdf`, "", 1, true, "")
+	script := `for ($i = 0; $i -lt 1; $i++) { Start-Process -FilePath 'C:\Temp\rotulo.pdf' -Verb PrintTo -ArgumentList '"Epson L3150"'; Start-Sleep -Seconds 2 }`
+	if name != "powershell" || args[len(args)-1] != script {
+		t.Errorf("sin SumatraPDF = %s %q", name, args)
+	}
+}
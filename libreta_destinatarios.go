@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	addressBookFile = "libreta_destinatarios.json" // Junto al programa, como empresas.json
+	maxSuggestions  = 5                            // Sugerencias bajo el nombre del destinatario
+)
+
+// Contacto es un destinatario guardado en la libreta
+type Contacto struct {
+	Nombre    string
+	Direccion string
+	Telefono  string
+	DNI       string // Vacío si no se conoce
+}
+
+var dniRegex = regexp.MustCompile(`^\d{8}$`)
+
+func validateContacto(c Contacto) error {
+	if strings.TrimSpace(c.Nombre) == "" {
+		return fmt.Errorf("el destinatario necesita un nombre")
+	}
+	if c.DNI != "" && !dniRegex.MatchString(c.DNI) {
+		return fmt.Errorf("el DNI debe tener 8 dígitos")
+	}
+	return nil
+}
+
+// searchKey deja el texto en minúsculas y sin tildes, así "maria" encuentra a "María"
+var searchKey = func() func(string) string {
+	accents := strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
+	return func(s string) string {
+		return accents.Replace(strings.ToLower(strings.TrimSpace(s)))
+	}
+}()
+
+// searchContacts devuelve los contactos que tienen todas las palabras de query en el nombre,
+// el DNI, el teléfono o la dirección. Primero van los nombres que empiezan con query y luego
+// el resto, cada grupo en orden alfabético; sin query devuelve toda la libreta.
+func searchContacts(book []Contacto, query string) []Contacto {
+	query = searchKey(query)
+	words := strings.Fields(query)
+	var found []Contacto
+	for _, c := range book {
+		text := searchKey(strings.Join([]string{c.Nombre, c.DNI, c.Telefono, c.Direccion}, " "))
+		match := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			found = append(found, c)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		pi := strings.HasPrefix(searchKey(found[i].Nombre), query)
+		pj := strings.HasPrefix(searchKey(found[j].Nombre), query)
+		if pi != pj {
+			return pi
+		}
+		return searchKey(found[i].Nombre) < searchKey(found[j].Nombre)
+	})
+	return found
+}
+
+// sameContacto indica si a y b son la misma persona: por DNI si ambos lo tienen y si no por
+// el nombre
+func sameContacto(a, b Contacto) bool {
+	if a.DNI != "" && b.DNI != "" {
+		return a.DNI == b.DNI
+	}
+	return searchKey(a.Nombre) == searchKey(b.Nombre)
+}
+
+// upsertContact agrega c a la libreta o reemplaza los datos de esa persona; added indica si
+// era nueva
+func upsertContact(book []Contacto, c Contacto) (result []Contacto, added bool) {
+	for i, old := range book {
+		if sameContacto(old, c) {
+			result = append([]Contacto{}, book...)
+			result[i] = c
+			return result, false
+		}
+	}
+	return append(append([]Contacto{}, book...), c), true
+}
+
+func removeContact(book []Contacto, c Contacto) []Contacto {
+	var result []Contacto
+	for _, old := range book {
+		if old != c {
+			result = append(result, old)
+		}
+	}
+	return result
+}
+
+func loadAddressBook() []Contacto {
+	data, err := ioutil.ReadFile(addressBookFile)
+	if err != nil {
+		return nil
+	}
+	var book []Contacto
+	if err := json.Unmarshal(data, &book); err != nil {
+		log.Printf("Error leyendo %s: %v", addressBookFile, err)
+		return nil
+	}
+	return book
+}
+
+func saveAddressBook(book []Contacto) error {
+	data, err := json.MarshalIndent(book, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(addressBookFile, data, 0644)
+}
+
+func contactLabel(c Contacto) string {
+	label := c.Nombre
+	if c.DNI != "" {
+		label += " · DNI " + c.DNI
+	}
+	if c.Telefono != "" {
+		label += " · " + c.Telefono
+	}
+	return label
+}
+
+// applyContacto llena el destinatario del rótulo con c
+func (r *RotuloGenerator) applyContacto(c Contacto) {
+	r.filling = true
+	r.inputs["destinatarioNombre"].SetText(c.Nombre)
+	r.inputs["destinatarioDireccion"].SetText(c.Direccion)
+	r.inputs["destinatarioTelefono"].SetText(c.Telefono)
+	r.inputs["destinatarioDNI"].SetText(c.DNI)
+	r.filling = false
+	r.suggestions.Hide()
+}
+
+// updateSuggestions muestra bajo el nombre del destinatario los contactos de la libreta que
+// coinciden con lo escrito; al elegir uno se llenan los demás campos
+func (r *RotuloGenerator) updateSuggestions(text string) {
+	if r.filling {
+		return
+	}
+	r.suggestions.Objects = nil
+	if len([]rune(strings.TrimSpace(text))) >= 2 {
+		for _, c := range searchContacts(r.libreta, text) {
+			if len(r.suggestions.Objects) == maxSuggestions {
+				break
+			}
+			button := widget.NewButton("👤 "+contactLabel(c), func() {
+				r.applyContacto(c)
+			})
+			button.Alignment = widget.ButtonAlignLeading
+			button.Importance = widget.LowImportance
+			r.suggestions.Add(button)
+		}
+	}
+	if len(r.suggestions.Objects) == 0 {
+		r.suggestions.Hide()
+		return
+	}
+	r.suggestions.Show()
+	r.suggestions.Refresh()
+}
+
+// saveDestinatario guarda en la libreta el destinatario del rótulo, o actualiza sus datos
+// si ya estaba
+func (r *RotuloGenerator) saveDestinatario(window fyne.Window) {
+	c := Contacto{
+		Nombre:    strings.TrimSpace(r.data.DestinatarioNombre),
+		Direccion: strings.TrimSpace(r.data.DestinatarioDireccion),
+		Telefono:  strings.TrimSpace(r.data.DestinatarioTelefono),
+		DNI:       strings.TrimSpace(r.data.DestinatarioDNI),
+	}
+	if err := validateContacto(c); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	book, added := upsertContact(r.libreta, c)
+	if err := saveAddressBook(book); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	r.libreta = book
+	message := fmt.Sprintf("%s se agregó a la libreta.", c.Nombre)
+	if !added {
+		message = fmt.Sprintf("Se actualizaron los datos de %s en la libreta.", c.Nombre)
+	}
+	dialog.ShowInformation("📒 Libreta de destinatarios", message, window)
+}
+
+// showAddressBook abre la libreta para buscar un destinatario por nombre, DNI o teléfono,
+// usarlo en el rótulo o eliminarlo
+func (r *RotuloGenerator) showAddressBook(window fyne.Window) {
+	found := searchContacts(r.libreta, "")
+	selected := -1
+
+	searchInput := widget.NewEntry()
+	searchInput.SetPlaceHolder("Buscar por nombre, DNI, teléfono o dirección")
+	detail := widget.NewLabel("")
+	detail.Wrapping = fyne.TextWrapWord
+	list := widget.NewList(
+		func() int { return len(found) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(contactLabel(found[id]))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		c := found[id]
+		detail.SetText(fmt.Sprintf("%s\nDNI: %s\nTeléfono: %s\n%s",
+			c.Nombre, getValueOrDefault(c.DNI, "-"), getValueOrDefault(c.Telefono, "-"), c.Direccion))
+	}
+	refresh := func() {
+		found = searchContacts(r.libreta, searchInput.Text)
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		detail.SetText(fmt.Sprintf("%d destinatarios", len(found)))
+	}
+	searchInput.OnChanged = func(string) { refresh() }
+	refresh()
+
+	var d dialog.Dialog
+	useButton := widget.NewButton("✔️ Usar en el rótulo", func() {
+		if selected < 0 {
+			return
+		}
+		r.applyContacto(found[selected])
+		d.Hide()
+	})
+	deleteButton := widget.NewButton("🗑️ Eliminar", func() {
+		if selected < 0 {
+			return
+		}
+		c := found[selected]
+		dialog.ShowConfirm("Eliminar destinatario", fmt.Sprintf("¿Eliminar a %s de la libreta?", c.Nombre), func(ok bool) {
+			if !ok {
+				return
+			}
+			book := removeContact(r.libreta, c)
+			if err := saveAddressBook(book); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			r.libreta = book
+			refresh()
+		}, window)
+	})
+
+	listScroll := container.NewScroll(list)
+	listScroll.SetMinSize(fyne.NewSize(300, 280))
+	content := container.NewBorder(searchInput, container.NewHBox(useButton, deleteButton), listScroll, nil, detail)
+	d = dialog.NewCustom("📒 Libreta de destinatarios", "Cerrar", content, window)
+	d.Resize(fyne.NewSize(680, 420))
+	d.Show()
+	window.Canvas().Focus(searchInput)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testLibreta = []Contacto{
+	{Nombre: "María González López", Direccion: "Jr. Los Olivos 456, Miraflores", Telefono: "+51 888 777 666", DNI: "45678912"},
+	{Nombre: "Juan Pérez", Direccion: "Av. Arequipa 123, Lima", Telefono: "987 654 321"},
+	{Nombre: "Ana María Quispe", Direccion: "Calle Real 10, Huancayo", DNI: "12345678"},
+}
+
+func contactNames(book []Contacto) []string {
+	var result []string
+	for _, c := range book {
+		result = append(result, c.Nombre)
+	}
+	return result
+}
+
+func TestSearchContacts(t *testing.T) {
+	cases := map[string][]string{
+		"":               {"Ana María Quispe", "Juan Pérez", "María González López"},
+		"maria":          {"María González López", "Ana María Quispe"},
+		"MARÍA quispe":   {"Ana María Quispe"},
+		"12345678":       {"Ana María Quispe"},
+		"987 654":        {"Juan Pérez"},
+		"huancayo":       {"Ana María Quispe"},
+		"perez lima":     {"Juan Pérez"},
+		"gonzalez cusco": nil,
+	}
+	for query, want := range cases {
+		if got := contactNames(searchContacts(testLibreta, query)); !reflect.DeepEqual(got, want) {
+			t.Errorf("searchContacts(%q) = %q, se esperaba %q", query, got, want)
+		}
+	}
+}
+
+func TestUpsertContact(t *testing.T) {
+	// Mismo DNI con el nombre escrito distinto: se actualiza
+	moved := Contacto{Nombre: "Maria Gonzalez", Direccion: "Av. Brasil 900", DNI: "45678912"}
+	book, added := upsertContact(testLibreta, moved)
+	if added || len(book) != 3 || book[0] != moved {
+		t.Errorf("por DNI = %v, %v", book, added)
+	}
+	if testLibreta[0].Direccion != "Jr. Los Olivos 456, Miraflores" {
+		t.Error("upsertContact no debe cambiar la libreta original")
+	}
+	// Sin DNI se reconoce por el nombre, sin importar tildes ni mayúsculas
+	book, added = upsertContact(testLibreta, Contacto{Nombre: "juan perez", Telefono: "999 111 222"})
+	if added || len(book) != 3 || book[1].Telefono != "999 111 222" {
+		t.Errorf("por nombre = %v, %v", book, added)
+	}
+	// Mismo nombre con otro DNI es otra persona
+	book, added = upsertContact(testLibreta, Contacto{Nombre: "Ana María Quispe", DNI: "87654321"})
+	if !added || len(book) != 4 {
+		t.Errorf("otro DNI = %v, %v", book, added)
+	}
+	if got := removeContact(testLibreta, testLibreta[1]); !reflect.DeepEqual(contactNames(got), []string{"María González López", "Ana María Quispe"}) {
+		t.Errorf("removeContact = %q", contactNames(got))
+	}
+}
+
+func TestValidateContacto(t *testing.T) {
+	if err := validateContacto(testLibreta[0]); err != nil {
+		t.Errorf("contacto válido rechazado: %v", err)
+	}
+	if validateContacto(Contacto{Nombre: " "}) == nil {
+		t.Error("se aceptó un contacto sin nombre")
+	}
+	if validateContacto(Contacto{Nombre: "Juan", DNI: "1234"}) == nil {
+		t.Error("se aceptó un DNI de 4 dígitos")
+	}
+}
+
+func TestLoadSaveAddressBook(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if book := loadAddressBook(); len(book) != 0 {
+		t.Errorf("sin archivo = %v", book)
+	}
+	if err := saveAddressBook(testLibreta); err != nil {
+		t.Fatal(err)
+	}
+	if book := loadAddressBook(); !reflect.DeepEqual(book, testLibreta) {
+		t.Errorf("guardada = %v", book)
+	}
+}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"github.com/go-vgo/robotgo"
+)
+
+// Secuencia por defecto: serie, tabulador y fecha (el comportamiento original)
+const defaultMacro = `{serie}
+[tab]
+{fecha}`
+
+// Tipos de paso de la secuencia
+const (
+	stepText  = "texto"
+	stepKey   = "tecla"
+	stepClick = "clic"
+	stepWait  = "esperar"
+
+	// Tiempo máximo por defecto para [esperar X Y color]
+	defaultPixelTimeout = 10 * time.Second
+)
+
+// Variables disponibles en los textos de la secuencia
+var macroPlaceholders = []string{"serie", "fecha", "contador", "total", "iteracion", "hoy", "portapapeles"}
+
+// Las columnas de fecha adicionales de cada registro se escriben con {fecha2} ... {fecha9}
+var dateColumnRegex = regexp.MustCompile(`^fecha([2-9])$`)
+
+// Teclas aceptadas entre corchetes, con los nombres que usa robotgo
+var macroKeys = map[string]bool{
+	"tab": true, "enter": true, "esc": true, "space": true,
+	"up": true, "down": true, "left": true, "right": true,
+	"home": true, "end": true, "pageup": true, "pagedown": true,
+	"backspace": true, "delete": true, "insert": true,
+	"f1": true, "f2": true, "f3": true, "f4": true, "f5": true, "f6": true,
+	"f7": true, "f8": true, "f9": true, "f10": true, "f11": true, "f12": true,
+}
+
+var macroModifiers = map[string]bool{"ctrl": true, "alt": true, "shift": true, "cmd": true}
+
+var (
+	placeholderRegex = regexp.MustCompile(`\{(\w+)\}`)
+	pixelColorRegex  = regexp.MustCompile(`^[0-9a-f]{6}$`)
+	keySpecRegex     = regexp.MustCompile(`\[([^\]]+)\]`)
+)
+
+// Teclas que cierran cada registro
+const (
+	terminatorDown   = "Flecha abajo"
+	terminatorEnter  = "Enter"
+	terminatorTab    = "Tab"
+	terminatorNone   = "Ninguna"
+	terminatorCustom = "Secuencia personalizada"
+)
+
+var terminatorOptions = []string{terminatorDown, terminatorEnter, terminatorTab, terminatorNone, terminatorCustom}
+
+// Botones del mouse aceptados en [clic X Y boton]
+var mouseButtons = map[string]string{
+	"izquierdo": "left",
+	"derecho":   "right",
+	"central":   "center",
+}
+
+// MacroStep es una acción de la secuencia que se repite por cada serie
+type MacroStep struct {
+	Tipo   string
+	Valor  string        // Texto, tecla principal, botón del mouse o color esperado
+	Args   []string      // Modificadores de la tecla
+	X, Y   int           // Coordenadas de pantalla para clics y esperas
+	Doble  bool          // Doble clic
+	Espera time.Duration // Tiempo máximo de espera del color
+}
+
+// parseMacro interpreta la secuencia: una acción por línea, las teclas, clics y esperas
+// entre corchetes ([tab], [ctrl+s], [clic 640 380], [esperar 640 380 00ff00]) y el resto
+// como texto con variables {serie}, {fecha}, etc.
+func parseMacro(text string) ([]MacroStep, error) {
+	if strings.TrimSpace(text) == "" {
+		text = defaultMacro
+	}
+
+	var steps []MacroStep
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			step, err := parseCommandStep(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("línea %d: %v", i+1, err)
+			}
+			steps = append(steps, step)
+			continue
+		}
+
+		for _, m := range placeholderRegex.FindAllStringSubmatch(line, -1) {
+			if !isMacroPlaceholder(m[1]) {
+				return nil, fmt.Errorf("línea %d: variable desconocida {%s}", i+1, m[1])
+			}
+		}
+		steps = append(steps, MacroStep{Tipo: stepText, Valor: line})
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("la secuencia no tiene pasos")
+	}
+	return steps, nil
+}
+
+// parseCommandStep interpreta el contenido de los corchetes: un clic o una tecla
+func parseCommandStep(spec string) (MacroStep, error) {
+	fields := strings.Fields(spec)
+	if len(fields) > 0 {
+		switch strings.ToLower(fields[0]) {
+		case "clic":
+			return parseClickStep(fields[1:])
+		case "esperar":
+			return parseWaitStep(fields[1:])
+		}
+	}
+	return parseKeyStep(spec)
+}
+
+// parseScreenPoint interpreta un par de coordenadas de pantalla
+func parseScreenPoint(xs, ys string) (int, int, error) {
+	x, errX := strconv.Atoi(xs)
+	y, errY := strconv.Atoi(ys)
+	if errX != nil || errY != nil || x < 0 || y < 0 {
+		return 0, 0, fmt.Errorf("coordenadas inválidas: %s %s", xs, ys)
+	}
+	return x, y, nil
+}
+
+// parseClickStep interpreta "X Y [izquierdo|derecho|central] [doble]"
+func parseClickStep(args []string) (MacroStep, error) {
+	if len(args) < 2 {
+		return MacroStep{}, fmt.Errorf("el clic necesita coordenadas: [clic X Y]")
+	}
+	x, y, err := parseScreenPoint(args[0], args[1])
+	if err != nil {
+		return MacroStep{}, err
+	}
+
+	step := MacroStep{Tipo: stepClick, Valor: "left", X: x, Y: y}
+	for _, opt := range args[2:] {
+		opt = strings.ToLower(opt)
+		if button, ok := mouseButtons[opt]; ok {
+			step.Valor = button
+		} else if opt == "doble" {
+			step.Doble = true
+		} else {
+			return MacroStep{}, fmt.Errorf("opción de clic desconocida %q", opt)
+		}
+	}
+	return step, nil
+}
+
+// parseWaitStep interpreta "X Y RRGGBB [segundos]": esperar a que el pixel tome ese color
+func parseWaitStep(args []string) (MacroStep, error) {
+	if len(args) < 3 || len(args) > 4 {
+		return MacroStep{}, fmt.Errorf("la espera necesita coordenadas y color: [esperar X Y RRGGBB]")
+	}
+	x, y, err := parseScreenPoint(args[0], args[1])
+	if err != nil {
+		return MacroStep{}, err
+	}
+
+	color := strings.ToLower(strings.TrimPrefix(args[2], "#"))
+	if !pixelColorRegex.MatchString(color) {
+		return MacroStep{}, fmt.Errorf("color inválido %q, usa el formato RRGGBB", args[2])
+	}
+
+	step := MacroStep{Tipo: stepWait, Valor: color, X: x, Y: y, Espera: defaultPixelTimeout}
+	if len(args) == 4 {
+		seconds, err := strconv.Atoi(args[3])
+		if err != nil || seconds <= 0 {
+			return MacroStep{}, fmt.Errorf("tiempo de espera inválido %q", args[3])
+		}
+		step.Espera = time.Duration(seconds) * time.Second
+	}
+	return step, nil
+}
+
+// parseKeyStep interpreta "tab" o combinaciones como "ctrl+shift+s"
+func parseKeyStep(spec string) (MacroStep, error) {
+	parts := strings.Split(strings.ToLower(spec), "+")
+	key := strings.TrimSpace(parts[len(parts)-1])
+
+	var mods []string
+	for _, p := range parts[:len(parts)-1] {
+		p = strings.TrimSpace(p)
+		if !macroModifiers[p] {
+			return MacroStep{}, fmt.Errorf("modificador desconocido %q", p)
+		}
+		mods = append(mods, p)
+	}
+
+	// Con modificadores se admite cualquier letra o número (ctrl+s, alt+1)
+	if !macroKeys[key] && !(len(mods) > 0 && len(key) == 1) {
+		return MacroStep{}, fmt.Errorf("tecla desconocida [%s]", spec)
+	}
+	return MacroStep{Tipo: stepKey, Valor: key, Args: mods}, nil
+}
+
+func isMacroPlaceholder(name string) bool {
+	for _, p := range macroPlaceholders {
+		if p == name {
+			return true
+		}
+	}
+	return dateColumnRegex.MatchString(name)
+}
+
+// macroUsesPlaceholder indica si algún texto de los pasos usa la variable
+func macroUsesPlaceholder(steps []MacroStep, name string) bool {
+	for _, step := range steps {
+		if step.Tipo != stepText {
+			continue
+		}
+		for _, m := range placeholderRegex.FindAllStringSubmatch(step.Valor, -1) {
+			if m[1] == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// clipboardValue limpia lo copiado para {portapapeles}: una celda de Excel llega con
+// salto de línea final, así que se usa solo la primera línea
+func clipboardValue(raw string) (string, error) {
+	value := strings.TrimSpace(strings.SplitN(strings.TrimSpace(raw), "\n", 2)[0])
+	if value == "" {
+		return "", fmt.Errorf("el portapapeles está vacío: copia el valor antes de iniciar")
+	}
+	return value, nil
+}
+
+// readClipboardValue lee el valor de {portapapeles}
+func readClipboardValue() (string, error) {
+	raw, err := robotgo.ReadAll()
+	if err != nil {
+		return "", fmt.Errorf("no se pudo leer el portapapeles: %v", err)
+	}
+	return clipboardValue(raw)
+}
+
+// macroDateColumns devuelve la columna de fecha más alta que usan los pasos:
+// 0 si no escriben fechas, 1 para {fecha}, 2 para {fecha2}, etc.
+func macroDateColumns(steps []MacroStep) int {
+	columns := 0
+	for _, step := range steps {
+		if step.Tipo != stepText {
+			continue
+		}
+		for _, m := range placeholderRegex.FindAllStringSubmatch(step.Valor, -1) {
+			n := 0
+			if m[1] == "fecha" {
+				n = 1
+			} else if col := dateColumnRegex.FindStringSubmatch(m[1]); col != nil {
+				n, _ = strconv.Atoi(col[1])
+			}
+			if n > columns {
+				columns = n
+			}
+		}
+	}
+	return columns
+}
+
+// macroVars arma los valores de las variables para un registro. {fecha} es la primera
+// fecha del registro o, si no trae ninguna, la fecha general del formulario.
+func macroVars(rec SerieRecord, fecha string, index, total int, now time.Time) map[string]string {
+	vars := map[string]string{
+		"serie":     rec.Serie,
+		"fecha":     fecha,
+		"contador":  strconv.Itoa(index + 1),
+		"total":     strconv.Itoa(total),
+		"iteracion": "1",
+		"hoy":       now.Format(targetDateLayout),
+	}
+	for i, f := range rec.Fechas {
+		if i == 0 {
+			vars["fecha"] = f
+		} else {
+			vars[fmt.Sprintf("fecha%d", i+1)] = f
+		}
+	}
+	return vars
+}
+
+// expandPlaceholders reemplaza las variables conocidas; las desconocidas se dejan tal cual
+func expandPlaceholders(text string, vars map[string]string) string {
+	return placeholderRegex.ReplaceAllStringFunc(text, func(match string) string {
+		if value, ok := vars[match[1:len(match)-1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// runMacroStep ejecuta un paso de la secuencia en la aplicación destino y devuelve
+// la cantidad de caracteres tecleados
+func runMacroStep(step MacroStep, vars map[string]string, keyDelay int, typing string) int {
+	switch step.Tipo {
+	case stepText:
+		text := expandPlaceholders(step.Valor, vars)
+		if needsPaste(text, typing) {
+			pasteText(text)
+		} else {
+			robotgo.TypeStrDelay(text, keyDelay)
+		}
+		return utf8.RuneCountInString(text)
+	case stepKey:
+		if len(step.Args) > 0 {
+			robotgo.KeyTap(step.Valor, step.Args)
+		} else {
+			robotgo.KeyTap(step.Valor)
+		}
+		return 1
+	case stepClick:
+		robotgo.MoveClick(step.X, step.Y, step.Valor, step.Doble)
+	}
+	return 0
+}
+
+// parseTerminator devuelve las teclas que se pulsan al terminar cada registro.
+// La secuencia personalizada usa la misma sintaxis de corchetes: "[enter] [down]".
+func parseTerminator(option, custom string) ([]MacroStep, error) {
+	switch option {
+	case "", terminatorDown:
+		return []MacroStep{{Tipo: stepKey, Valor: "down"}}, nil
+	case terminatorEnter:
+		return []MacroStep{{Tipo: stepKey, Valor: "enter"}}, nil
+	case terminatorTab:
+		return []MacroStep{{Tipo: stepKey, Valor: "tab"}}, nil
+	case terminatorNone:
+		return nil, nil
+	case terminatorCustom:
+		if strings.TrimSpace(keySpecRegex.ReplaceAllString(custom, "")) != "" {
+			return nil, fmt.Errorf("la secuencia final solo admite teclas entre corchetes, por ejemplo [enter] [down]")
+		}
+		var steps []MacroStep
+		for _, m := range keySpecRegex.FindAllStringSubmatch(custom, -1) {
+			step, err := parseKeyStep(strings.TrimSpace(m[1]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+		if len(steps) == 0 {
+			return nil, fmt.Errorf("la secuencia final personalizada está vacía")
+		}
+		return steps, nil
+	}
+	return nil, fmt.Errorf("tecla final desconocida: %q", option)
+}
+
+// formatMacroStep escribe el paso con la misma sintaxis que acepta parseMacro
+func formatMacroStep(step MacroStep) string {
+	switch step.Tipo {
+	case stepKey:
+		return "[" + strings.Join(append(append([]string(nil), step.Args...), step.Valor), "+") + "]"
+	case stepClick:
+		line := fmt.Sprintf("[clic %d %d", step.X, step.Y)
+		for name, button := range mouseButtons {
+			if button == step.Valor && button != "left" {
+				line += " " + name
+			}
+		}
+		if step.Doble {
+			line += " doble"
+		}
+		return line + "]"
+	case stepWait:
+		return fmt.Sprintf("[esperar %d %d %s %d]", step.X, step.Y, step.Valor, int(step.Espera/time.Second))
+	}
+	return step.Valor
+}
+
+// recordClickPosition agrega a la secuencia un clic en la posición actual del mouse
+func (a *Autocopiador) recordClickPosition() {
+	a.appendStepAtMouse("Coloca el mouse sobre el botón a pulsar", func(x, y int) string {
+		return fmt.Sprintf("[clic %d %d]", x, y)
+	})
+}
+
+// appendStepAtMouse toma la posición del mouse tras una cuenta regresiva y agrega
+// a la secuencia la línea que arma stepLine con esas coordenadas
+func (a *Autocopiador) appendStepAtMouse(prompt string, stepLine func(x, y int) string) {
+	go func() {
+		for i := 3; i > 0; i-- {
+			remaining := i
+			fyne.Do(func() {
+				a.statusLabel.SetText(fmt.Sprintf("%s... %d", prompt, remaining))
+			})
+			time.Sleep(time.Second)
+		}
+		x, y := robotgo.Location()
+		line := stepLine(x, y)
+
+		fyne.Do(func() {
+			text := strings.TrimRight(a.macroInput.Text, "\n")
+			if text != "" {
+				text += "\n"
+			}
+			a.macroInput.SetText(text + line)
+			a.statusLabel.SetText(fmt.Sprintf("Estado: Agregado %s", line))
+		})
+	}()
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseMacro(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []MacroStep
+		wantErr bool
+	}{
+		{
+			name:  "vacía usa la secuencia por defecto",
+			input: "  ",
+			want: []MacroStep{
+				{Tipo: stepText, Valor: "{serie}"},
+				{Tipo: stepKey, Valor: "tab"},
+				{Tipo: stepText, Valor: "{fecha}"},
+			},
+		},
+		{
+			name:  "prefijo fijo, comentarios y combinaciones",
+			input: "# registro\nZET-{serie}\n\n[ Enter ]\n[ctrl+s]\n",
+			want: []MacroStep{
+				{Tipo: stepText, Valor: "ZET-{serie}"},
+				{Tipo: stepKey, Valor: "enter"},
+				{Tipo: stepKey, Valor: "s", Args: []string{"ctrl"}},
+			},
+		},
+		{
+			name:  "clics del mouse",
+			input: "{serie}\n[clic 640 380]\n[CLIC 10 20 derecho doble]",
+			want: []MacroStep{
+				{Tipo: stepText, Valor: "{serie}"},
+				{Tipo: stepClick, Valor: "left", X: 640, Y: 380},
+				{Tipo: stepClick, Valor: "right", X: 10, Y: 20, Doble: true},
+			},
+		},
+		{
+			name:  "esperas por color",
+			input: "[clic 5 5]\n[esperar 640 380 #00FF00]\n[esperar 1 2 a1b2c3 30]",
+			want: []MacroStep{
+				{Tipo: stepClick, Valor: "left", X: 5, Y: 5},
+				{Tipo: stepWait, Valor: "00ff00", X: 640, Y: 380, Espera: defaultPixelTimeout},
+				{Tipo: stepWait, Valor: "a1b2c3", X: 1, Y: 2, Espera: 30 * time.Second},
+			},
+		},
+		{name: "espera sin color", input: "[esperar 640 380]", wantErr: true},
+		{name: "espera con color inválido", input: "[esperar 1 2 verde]", wantErr: true},
+		{name: "espera con tiempo inválido", input: "[esperar 1 2 00ff00 0]", wantErr: true},
+		{name: "clic sin coordenadas", input: "[clic 640]", wantErr: true},
+		{name: "clic con coordenadas inválidas", input: "[clic -1 abc]", wantErr: true},
+		{name: "clic con opción desconocida", input: "[clic 1 2 triple]", wantErr: true},
+		{name: "tecla desconocida", input: "{serie}\n[saltar]", wantErr: true},
+		{name: "letra sin modificador", input: "[s]", wantErr: true},
+		{name: "modificador desconocido", input: "[hyper+s]", wantErr: true},
+		{name: "variable desconocida", input: "{codigo}", wantErr: true},
+		{name: "solo comentarios", input: "# nada", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMacro(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseMacro(%q) = %v, se esperaba error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMacro(%q) error inesperado: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMacro(%q) = %#v, se esperaba %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPlaceholders(t *testing.T) {
+	now := time.Date(2025, 5, 27, 9, 0, 0, 0, time.Local)
+	vars := macroVars(SerieRecord{Serie: "0154"}, "15052025", 2, 10, now)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"{serie}", "0154"},
+		{"ZET-{serie}-{fecha}", "ZET-0154-15052025"},
+		{"{contador}/{total}", "3/10"},
+		{"{hoy}", "27052025"},
+		{"{serie}-{iteracion}", "0154-1"},
+		{"{otro} {serie}", "{otro} 0154"},
+		{"sin variables", "sin variables"},
+	}
+
+	for _, tt := range tests {
+		if got := expandPlaceholders(tt.input, vars); got != tt.want {
+			t.Errorf("expandPlaceholders(%q) = %q, se esperaba %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMacroVarsDateColumns(t *testing.T) {
+	now := time.Date(2025, 5, 27, 9, 0, 0, 0, time.Local)
+	rec := SerieRecord{Serie: "0154", Fechas: []string{"15032024", "15032026"}}
+	vars := macroVars(rec, "01012025", 0, 1, now)
+
+	got := expandPlaceholders("{serie} {fecha} {fecha2} {fecha3}", vars)
+	if want := "0154 15032024 15032026 {fecha3}"; got != want {
+		t.Errorf("expandPlaceholders = %q, se esperaba %q", got, want)
+	}
+}
+
+func TestMacroDateColumns(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"{serie}\n[tab]", 0},
+		{"{serie}\n[tab]\n{fecha}", 1},
+		{"{serie}\n[tab]\n{fecha}\n[tab]\n{fecha3}-{fecha2}", 3},
+	}
+
+	for _, tt := range tests {
+		steps, err := parseMacro(tt.input)
+		if err != nil {
+			t.Fatalf("parseMacro(%q): %v", tt.input, err)
+		}
+		if got := macroDateColumns(steps); got != tt.want {
+			t.Errorf("macroDateColumns(%q) = %d, se esperaba %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTerminator(t *testing.T) {
+	tests := []struct {
+		option  string
+		custom  string
+		want    []MacroStep
+		wantErr bool
+	}{
+		{"", "", []MacroStep{{Tipo: stepKey, Valor: "down"}}, false},
+		{terminatorDown, "", []MacroStep{{Tipo: stepKey, Valor: "down"}}, false},
+		{terminatorEnter, "", []MacroStep{{Tipo: stepKey, Valor: "enter"}}, false},
+		{terminatorTab, "", []MacroStep{{Tipo: stepKey, Valor: "tab"}}, false},
+		{terminatorNone, "", nil, false},
+		{terminatorCustom, "[enter] [down]", []MacroStep{{Tipo: stepKey, Valor: "enter"}, {Tipo: stepKey, Valor: "down"}}, false},
+		{terminatorCustom, "[ctrl+s]", []MacroStep{{Tipo: stepKey, Valor: "s", Args: []string{"ctrl"}}}, false},
+		{terminatorCustom, "", nil, true},
+		{terminatorCustom, "enter", nil, true},
+		{terminatorCustom, "[volar]", nil, true},
+		{"Otra", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTerminator(tt.option, tt.custom)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseTerminator(%q, %q) = %v, se esperaba error", tt.option, tt.custom, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTerminator(%q, %q) error inesperado: %v", tt.option, tt.custom, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseTerminator(%q, %q) = %#v, se esperaba %#v", tt.option, tt.custom, got, tt.want)
+		}
+	}
+}
+
+func TestClipboardValue(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "PED-4521", want: "PED-4521"},
+		{raw: "  PED-4521\r\n", want: "PED-4521"},
+		{raw: "PED-4521\r\nPED-4522\r\n", want: "PED-4521"},
+		{raw: " \r\n ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := clipboardValue(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("clipboardValue(%q) = %q, se esperaba error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("clipboardValue(%q) = %q, %v; se esperaba %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestMacroUsesPlaceholder(t *testing.T) {
+	steps, err := parseMacro("{serie}\n[tab]\nPED {portapapeles}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !macroUsesPlaceholder(steps, "portapapeles") {
+		t.Error("no se detectó {portapapeles}")
+	}
+	if macroUsesPlaceholder(steps, "fecha") {
+		t.Error("se detectó {fecha} sin usarse")
+	}
+}
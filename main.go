@@ -3,12 +3,16 @@ package main
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
 	"log"
+	"math"
+	"math/rand"
 	"os"
+	"os/exec"
 	"path/filepath"
-	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -18,17 +22,16 @@ import (
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
-	"github.com/go-vgo/robotgo"
 	"github.com/jung-kurt/gofpdf"
-	hook "github.com/robotn/gohook"
 	"github.com/skip2/go-qrcode"
 )
 
 var cancel = make(chan struct{})
 
 const (
-	saveFile         = "bloc_notas.txt"
+	legacyNoteFile   = "bloc_notas.txt" // Bloc único anterior a las notas múltiples
 	autoSaveInterval = 5 * time.Second
+	editPause        = 2 * time.Second // Sin escribir este tiempo se guarda y se actualiza la hora
 
 	// Rutas para los logos
 	logosDir     = "logos"
@@ -37,54 +40,234 @@ const (
 
 	// Fuentes
 	fontsDir = "fonts"
+
+	// Segundos para volver a la ventana destino tras una pausa de revisión
+	resumeCountdown = 3
 )
 
-// Datos predefinidos de empresas
-var empresasData = map[string]struct {
+// Empresas de los rótulos por clave; se cargan de empresas.json y se editan en 🏢 Empresas
+var empresasData = defaultEmpresas()
+
+type SpeedPreset struct {
 	Nombre    string
-	Direccion string
-	Telefono  string
-	NeedQR    bool
-	Color     struct{ R, G, B int }
-}{
-	"ZETTACOM": {
-		Nombre:    "ZETTACOM S.A.C",
-		Direccion: "Av. Giraldez 242, Huancayo, Junín",
-		Telefono:  "+51 964 789 123",
-		NeedQR:    false,
-		Color:     struct{ R, G, B int }{0, 51, 102}, // Azul marino
-	},
-	"COMSITEC": {
-		Nombre:    "COMSITEC S.A.C",
-		Direccion: "Av. Giraldez 242, Huancayo, Junín",
-		Telefono:  "+51 964 789 456",
-		NeedQR:    true,
-		Color:     struct{ R, G, B int }{180, 20, 40}, // Rojo corporativo
-	},
+	Delay     time.Duration // Pausa entre campos
+	KeyDelay  int           // Retardo entre teclas (ms)
+	RowSettle time.Duration // Pausa después de bajar de fila
+}
+
+// Presets de velocidad del autocopiado, en el orden en que se muestran
+var speedPresets = []SpeedPreset{
+	{Nombre: "Lento", Delay: 200 * time.Millisecond, KeyDelay: 10, RowSettle: 150 * time.Millisecond},
+	{Nombre: "Normal", Delay: 90 * time.Millisecond, KeyDelay: 2, RowSettle: 60 * time.Millisecond},
+	{Nombre: "Rápido", Delay: 40 * time.Millisecond, KeyDelay: 1, RowSettle: 30 * time.Millisecond},
+}
+
+const defaultSpeedPreset = "Normal"
+
+func speedPresetNames() []string {
+	names := make([]string, len(speedPresets))
+	for i, p := range speedPresets {
+		names[i] = p.Nombre
+	}
+	return names
+}
+
+func findSpeedPreset(name string) (SpeedPreset, bool) {
+	for _, p := range speedPresets {
+		if p.Nombre == name {
+			return p, true
+		}
+	}
+	return SpeedPreset{}, false
 }
 
-// Tamaños de papel en mm
+// Tamaños de papel en mm, en vertical. Scale es el factor del diseño del rótulo, pensado
+// para A4. Los de 10x15 cm y 4x6 pulgadas son los rollos de etiquetas de las impresoras
+// térmicas de los couriers.
 var paperSizes = map[string]struct {
 	Width  float64
 	Height float64
+	Scale  float64
 }{
-	"A4":    {Width: 210, Height: 297},
-	"A5":    {Width: 148, Height: 210},
-	"Carta": {Width: 216, Height: 279},
+	"A4":       {Width: 210, Height: 297, Scale: 1},
+	"A5":       {Width: 148, Height: 210, Scale: 0.7},
+	"Carta":    {Width: 216, Height: 279, Scale: 1.03},
+	"10x15 cm": {Width: 100, Height: 150, Scale: 0.7},
+	"4x6 in":   {Width: 101.6, Height: 152.4, Scale: 0.7},
 }
 
+// paperSizeNames son los tamaños en el orden en que se ofrecen
+var paperSizeNames = []string{"A4", "A5", "Carta", "10x15 cm", "4x6 in"}
+
 type Item struct {
 	Codigo string
 	Nombre string
 	Firma  string
 }
 
+// AutocopyJob agrupa los datos y la configuración de una ejecución del autocopiador
+type AutocopyJob struct {
+	Series     string
+	Fecha      string
+	Velocidad  string
+	Variacion  time.Duration
+	PausarCada int
+	Programado time.Time // Hora de inicio programada (cero = inmediato)
+	Macro      string    // Secuencia tecleada por serie (vacía = secuencia por defecto)
+	Orden      string    // Orden en que se escriben las series
+	// Filtros de series: exactas o prefijos terminados en "*"
+	Incluir string
+	Excluir string
+	// Validación del dígito verificador de cada serie (vacío = sin validar)
+	DigitoVerificador string
+	// Tecla que cierra cada registro (vacía = flecha abajo)
+	Terminador              string
+	TerminadorPersonalizado string
+	VigilarVentana          bool // Pausar si la ventana activa cambia durante el tecleo
+	VigilarBloqueo          bool // Pausar mientras la pantalla esté bloqueada
+	CancelarSiTeclea        bool // Cancelar si el usuario pulsa una tecla durante el tecleo
+	Repeticiones            int  // Veces que se escribe la lista completa (0 = una sola)
+	// Ventanas entre las que se alternan los registros (vacío = solo la activa)
+	Ventanas []VentanaDestino
+	// Teclear o pegar los textos (vacío = automático)
+	Escritura string
+	// Espera a la aplicación destino tras cada tecla o clic (vacío = pausas fijas)
+	Ritmo       string
+	RegionRitmo ScreenRegion
+	// Verificación por OCR de cada serie escrita
+	VerificarOCR bool
+	RegionOCR    ScreenRegion
+}
+
+type Autocopiador struct {
+	seriesInput      *widget.Entry
+	dateInput        *widget.Entry
+	speedSelect      *widget.Select
+	jitterCheck      *widget.Check
+	jitterSlider     *widget.Slider
+	pauseCheck       *widget.Check
+	pauseEveryInput  *widget.Entry
+	repeatCheck      *widget.Check
+	repeatInput      *widget.Entry
+	scheduleCheck    *widget.Check
+	scheduleInput    *widget.Entry
+	macroInput       *widget.Entry
+	orderSelect      *widget.Select
+	includeInput     *widget.Entry
+	excludeInput     *widget.Entry
+	checkDigitSelect *widget.Select
+	terminatorSelect *widget.Select
+	terminatorInput  *widget.Entry
+	focusCheck       *widget.Check
+	lockCheck        *widget.Check
+	userKeysCheck    *widget.Check
+	altCheck         *widget.Check
+	altWindows       [2]VentanaDestino
+	altWindowLabels  [2]*widget.Label
+	ocrCheck         *widget.Check
+	ocrRegionInput   *widget.Entry
+	pacingSelect     *widget.Select
+	typingSelect     *widget.Select
+	pacingRegion     *widget.Entry
+	cancelKeysInput  *widget.Entry
+	longRunInput     *widget.Entry
+	overlayCheck     *widget.Check
+	recorder         *macroRecorder // Grabación de secuencia en curso
+	statusLabel      *widget.Label
+	copiedCounter    *widget.Label
+	historyList      *widget.List
+	history          []AutocopyRecord
+	historySelected  int
+	stats            *AutocopyStats
+	statsLabel       *widget.Label
+	daily            []DailyMetric // Métricas persistidas por día
+	dailyChart       *fyne.Container
+	dailySummary     *widget.Label
+	historyDetail    *widget.Label
+	window           fyne.Window
+	countdown        int
+	running          bool               // Hay un trabajo programado o en curso
+	runControls      []fyne.Disableable // Botones que lanzan trabajos
+	queue            jobQueue           // Trabajos preparados para ejecutarse en secuencia
+	queueList        *widget.List
+	queuePauseInput  *widget.Entry
+	overlay          *autocopyOverlay
+}
+
 type NotePad struct {
-	multiLine    *widget.Entry
+	multiLine    *noteEntry
 	lastContent  string
 	lastSaveTime time.Time
 	statusLabel  *widget.Label
 	lastUserEdit time.Time
+	onSendSeries func(series []string) // Conecta con la lista de series del Autocopiador
+	onSendLabel  func(addressBlock)    // Conecta con el destinatario del Rótulo
+	onPersonal   func() bool           // La pestaña Personal está a la vista
+	current      string                // Nota abierta en el editor, o la ruta si external
+	external     bool                  // Se edita un .txt abierto con 📂 Abrir, fuera de la carpeta de notas
+	notes        []string
+	notesList    *widget.List
+	keepVersions int // Versiones anteriores que se conservan por nota
+	archive      snapshotSettings
+	history      editHistory
+	pendingKind  string // Tipo del cambio que está haciendo applyEdit
+	historyList  *widget.List
+	preview      *widget.RichText
+	previewBox   *fyne.Container
+	highlight    bool // La vista previa resalta horas, códigos y usuarios
+	timePatterns []TimePattern
+	autoPatterns []compiledPattern // Patrones activos ya compilados
+	autoSave     autoSaveSettings
+	key          []byte     // Clave de cifrado; nil si las notas no están cifradas
+	locked       bool       // Cifrado y sin desbloquear: el editor no guarda nada
+	saveMu       sync.Mutex // Evita guardar mientras se cifran o descifran las notas
+	dirty        bool       // Hay cambios sin guardar
+	lastWrite    time.Time  // Fecha del archivo al leerlo o guardarlo, para ver cambios de otro equipo
+	conflict     bool       // Otro equipo guardó la nota y falta decidir qué versión queda
+	window       fyne.Window
+	countLabel   *widget.Label
+	gutter       *widget.Label // Números de línea junto al editor
+	gutterLines  int
+	editorBox    *container.Scroll
+	editorFont   NoteFontSettings
+	editorTheme  *noteTheme
+	themeBox     *container.ThemeOverride // Aplica editorTheme al editor y al margen
+	tabTheme     *noteTheme
+	tabBox       *container.ThemeOverride
+	tabBackdrop  *canvas.Rectangle
+	repTable     *widget.Table
+	tableRows    []reposicionRow
+	tableLabel   *widget.Label
+	tableBox     *fyne.Container
+	filterEntry  *widget.Entry
+	filterRows   []filteredLine
+	filterList   *widget.List
+	filterLabel  *widget.Label
+	filterBox    *fyne.Container
+	unfiltered   fyne.CanvasObject // Lo que se ve sin filtro: el editor o la tabla
+	daily        bool              // Archivo diario: una nota por día
+	days         []string          // Notas diarias, la más reciente primero
+	today        string
+	daySelect    *widget.Select
+	captureStop  chan struct{} // Detiene la captura del portapapeles; nil si está apagada
+	formatRule   NoteFormatRule
+	listPrompt   string              // Última línea que agregó la lista al pulsar Enter
+	trash        map[string][]string // Lo limpiado en la sesión por archivo, lo último al final
+	clock        NoteClockSettings
+	lastClockRun time.Time // Última revisión de las horas; cero para revisar ya
+	recent       []string  // Rutas abiertas hace poco, la última primero
+	recentSelect *widget.Select
+	recovered    bool // Ya se revisó la copia de recuperación de la sesión anterior
+	recoverTimer *time.Timer
+	spell        *spellDict // Diccionario de ortografía; nil si no hay
+	userBars     *fyne.Container
+	dayStats     []dayCount // Últimas notas diarias sin contar la abierta
+	statsLabel   *widget.Label
+	folds        map[int]string // Lo ocultado de cada sección plegada; lo guarda saveMu
+	foldNext     int
+	foldSelect   *widget.Select
+	printer      string // Última impresora usada en esta sesión
+	mailClave    string // Clave SMTP de esta sesión; no se guarda en correo_notas.json
 }
 
 type RotuloData struct {
@@ -95,11 +278,13 @@ type RotuloData struct {
 	DestinatarioNombre    string
 	DestinatarioDireccion string
 	DestinatarioTelefono  string
+	DestinatarioDNI       string
 	Peso                  string
 	Observaciones         string
 	NumeroGuia            string
 	TamanoHoja            string
 	Orientacion           string
+	Distribucion          string // Nombre de uno de sheetLayouts; vacía es un rótulo por hoja
 	FechaEnvio            time.Time
 }
 
@@ -109,23 +294,54 @@ type RotuloGenerator struct {
 	empresaCheck *widget.RadioGroup
 	inputs       map[string]*widget.Entry
 	tamanoHoja   *widget.Select
+	distribucion *widget.Select // Cuántos rótulos van en cada hoja
 	orientacion  *widget.RadioGroup
 	logoPreview  *canvas.Image
-	pdfPreview   *widget.Label
+	pdfPreview   *widget.Label // Estado de la vista previa del PDF real
+	pdfImage     *canvas.Image // Primera página del PDF dibujada por rasterTool
+	pdfTimer     *time.Timer
+	pdfSeq       int    // Cambia con cada edición; descarta dibujos que ya quedaron viejos
+	rasterTool   string // Programa que dibuja el PDF; vacío si no hay ninguno instalado
+	rasterPath   string
 	window       fyne.Window
-	pdfCounter   int
+	impresora    string // Última impresora usada para rótulos en esta sesión
+	libreta      []Contacto
+	suggestions  *fyne.Container // Contactos de la libreta que coinciden con el destinatario
+	filling      bool            // Evita sugerir mientras se llena un contacto elegido
+	sucursal     *widget.Select  // Sucursal de la empresa que envía
+	autoGuia     bool            // La guía del campo la apartó ensureGuia, no se escribió a mano
+	historial    []RotuloRecord  // Rótulos generados, el último primero
+	histFound    []int           // Posiciones en historial de lo que coincide con la búsqueda
+	histPick     int             // Posición en historial del rótulo elegido, o -1
+	histList     *widget.List
+	histSearch   *widget.Entry
+	histDetail   *widget.Label
 }
 
 func main() {
+	// Con --autocopy se ejecuta un trabajo exportado sin abrir la ventana
+	if path, ok, err := cliJobPath(os.Args[1:]); ok {
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(exitError)
+		}
+		os.Exit(runHeadless(path))
+	}
+
 	a := app.New()
 	w := a.NewWindow("Mi herramienta de trabajo")
 	w.Resize(fyne.NewSize(1200, 700))
 
 	// Crear directorios necesarios
 	createRequiredDirs()
+	empresasData = loadEmpresas()
 
 	// Tab 1: Autocopiador
-	autocopiadorTab := createAutocopiadorTab(w)
+	autocopiador := &Autocopiador{countdown: 5, stats: newAutocopyStats()}
+	autocopiador.loadHistory()
+	autocopiador.loadDailyMetrics()
+	autocopiadorTab := autocopiador.createAutocopiadorTab(w)
+	autocopiador.loadSession()
 
 	// Tab 2: Personal
 	notepad := &NotePad{}
@@ -138,22 +354,55 @@ func main() {
 			Orientacion: "Vertical",
 			FechaEnvio:  time.Now(),
 		},
-		inputs:     make(map[string]*widget.Entry),
-		window:     w,
-		pdfCounter: 1,
+		inputs:    make(map[string]*widget.Entry),
+		window:    w,
+		libreta:   loadAddressBook(),
+		historial: loadLabelHistory(),
 	}
 	rotuloTab := rotuloGenerator.createRotuloTab(w)
+	historyTab := rotuloGenerator.createHistoryTab(w)
 
+	autocopiadorItem := container.NewTabItem("🤖 Autocopiador", autocopiadorTab)
+	personalItem := container.NewTabItem("📝 Personal", personalTab)
+	rotuloItem := container.NewTabItem("🏷️ Rótulo Profesional", rotuloTab)
+	historyItem := container.NewTabItem("🗂️ Historial de Rótulos", historyTab)
 	tabs := container.NewAppTabs(
-		container.NewTabItem("🤖 Autocopiador", autocopiadorTab),
-		container.NewTabItem("📝 Personal", personalTab),
-		container.NewTabItem("🏷️ Rótulo Profesional", rotuloTab),
+		autocopiadorItem,
+		personalItem,
+		rotuloItem,
+		historyItem,
 	)
 
+	notepad.onPersonal = func() bool { return tabs.Selected() == personalItem }
+	notepad.onSendSeries = func(series []string) {
+		autocopiador.appendSeries(series)
+		tabs.Select(autocopiadorItem)
+	}
+	notepad.onSendLabel = func(block addressBlock) {
+		rotuloGenerator.fillDestinatario(block)
+		tabs.Select(rotuloItem)
+	}
+
+	// Un .txt arrastrado a la ventana con la pestaña Personal a la vista se abre en el editor
+	w.SetOnDropped(func(_ fyne.Position, uris []fyne.URI) {
+		if tabs.Selected() == personalItem {
+			notepad.openDropped(uris)
+		}
+	})
+
 	w.SetContent(tabs)
+	w.SetOnClosed(func() {
+		autocopiador.saveSession()
+		// Con el guardado automático desactivado lo último escrito solo queda aquí; sin
+		// cambios no se escribe para no pisar lo que otro equipo guardó en una carpeta compartida
+		if notepad.isDirty() {
+			notepad.saveContent()
+		}
+		notepad.stopRecovery()
+	})
 	w.Show()
 
-	go globalEscapeListener(nil)
+	autocopiador.applyCancelKeys()
 	a.Run()
 }
 
@@ -172,69 +421,342 @@ func createRequiredDirs() {
 	}
 }
 
-func createAutocopiadorTab(window fyne.Window) *fyne.Container {
+func (a *Autocopiador) createAutocopiadorTab(window fyne.Window) *fyne.Container {
+	a.window = window
+
 	// Input de series
-	seriesInput := widget.NewMultiLineEntry()
-	seriesInput.SetPlaceHolder("Ejemplo: 12345 67890 11111 22222\n(Separa las series con espacios)")
+	a.seriesInput = widget.NewMultiLineEntry()
+	a.seriesInput.SetPlaceHolder("Ejemplo: 12345 67890 11111 22222\n(Separa las series con espacios o pega filas serie;fecha;fecha)")
 
-	seriesScroll := container.NewScroll(seriesInput)
+	seriesScroll := container.NewScroll(a.seriesInput)
 	seriesScroll.SetMinSize(fyne.NewSize(480, 180))
 
-	dateInput := widget.NewEntry()
-	dateInput.SetPlaceHolder("Formato: 15052025 (DDMMAAAA)")
+	rangeButton := widget.NewButton("🔢 Generar rango", func() {
+		a.showRangeGenerator()
+	})
 
-	// Labels de estado
-	statusLabel := widget.NewLabel("Estado: Esperando acción...")
-	statusLabel.Importance = widget.MediumImportance
+	// Modo escáner: cada lectura termina con Enter y se agrega a la lista
+	scanCount := 0
+	scanCountLabel := widget.NewLabel("Escaneadas: 0")
+	scanInput := widget.NewEntry()
+	scanInput.SetPlaceHolder("Escanea un código (el lector envía Enter al final)")
+	scanInput.OnSubmitted = func(code string) {
+		code = strings.TrimSpace(code)
+		scanInput.SetText("")
+		window.Canvas().Focus(scanInput)
+		if code == "" {
+			return
+		}
 
-	copiedCounter := widget.NewLabel("Copiadas: 0 / 0")
-	copiedCounter.Importance = widget.LowImportance
+		current := a.seriesInput.Text
+		if current != "" && !strings.HasSuffix(current, "\n") && !strings.HasSuffix(current, " ") {
+			current += "\n"
+		}
+		a.seriesInput.SetText(current + code)
 
-	// Botones
-	startButton := widget.NewButton("▶️ Iniciar Autocopiado", func() {
-		rawSeries := seriesInput.Text
-		date := dateInput.Text
+		scanCount++
+		scanCountLabel.SetText(fmt.Sprintf("Escaneadas: %d", scanCount))
+	}
+	scanBox := container.NewBorder(nil, nil, nil, scanCountLabel, scanInput)
+	scanBox.Hide()
+
+	scanCheck := widget.NewCheck("Modo escáner de código de barras", func(checked bool) {
+		if checked {
+			scanCount = 0
+			scanCountLabel.SetText("Escaneadas: 0")
+			scanBox.Show()
+			window.Canvas().Focus(scanInput)
+		} else {
+			scanBox.Hide()
+		}
+	})
+
+	a.dateInput = widget.NewEntry()
+	a.dateInput.SetPlaceHolder("Ej: 15/05/2025, 15-05-25, 15052025")
 
-		if strings.TrimSpace(rawSeries) == "" {
-			dialog.ShowError(fmt.Errorf("debes ingresar al menos una serie"), window)
+	// Vista previa de la fecha convertida a DDMMAAAA
+	datePreview := widget.NewLabel("")
+	datePreview.Importance = widget.LowImportance
+	a.dateInput.OnChanged = func(text string) {
+		if strings.TrimSpace(text) == "" {
+			datePreview.SetText("")
 			return
 		}
-		if strings.TrimSpace(date) == "" {
-			dialog.ShowError(fmt.Errorf("debes ingresar una fecha"), window)
+		normalized, err := normalizeDate(text)
+		if err != nil {
+			datePreview.SetText("⚠️ " + err.Error())
 			return
 		}
+		datePreview.SetText("Se escribirá: " + normalized)
+	}
+
+	// Orden de escritura de las series
+	a.orderSelect = widget.NewSelect(seriesOrderOptions, nil)
+	a.orderSelect.SetSelected(orderOriginal)
+
+	// Validación opcional del dígito verificador
+	a.checkDigitSelect = widget.NewSelect(checkDigitOptions, nil)
+	a.checkDigitSelect.SetSelected(checkDigitNone)
+
+	// Velocidad y variación humana
+	a.speedSelect = widget.NewSelect(speedPresetNames(), nil)
+	a.speedSelect.SetSelected(defaultSpeedPreset)
+
+	jitterLabel := widget.NewLabel("±30 ms")
+	a.jitterSlider = widget.NewSlider(20, 50)
+	a.jitterSlider.Step = 5
+	a.jitterSlider.SetValue(30)
+	a.jitterSlider.OnChanged = func(value float64) {
+		jitterLabel.SetText(fmt.Sprintf("±%.0f ms", value))
+	}
+	a.jitterSlider.Disable()
+
+	a.jitterCheck = widget.NewCheck("Variación humana", func(checked bool) {
+		if checked {
+			a.jitterSlider.Enable()
+		} else {
+			a.jitterSlider.Disable()
+		}
+	})
 
-		delayMs := 90
-		countdownSec := 5
+	// Pausas de revisión por lotes
+	a.pauseEveryInput = widget.NewEntry()
+	a.pauseEveryInput.SetPlaceHolder("N")
+	a.pauseEveryInput.Disable()
 
-		statusLabel.SetText(fmt.Sprintf("Iniciando en %d segundos...", countdownSec))
-		copiedCounter.SetText("Copiadas: 0 / 0")
+	a.pauseCheck = widget.NewCheck("Pausar cada N series", func(checked bool) {
+		if checked {
+			a.pauseEveryInput.Enable()
+		} else {
+			a.pauseEveryInput.Disable()
+		}
+	})
 
-		cancel = make(chan struct{})
+	// Repetición de la lista completa
+	a.repeatInput = widget.NewEntry()
+	a.repeatInput.SetPlaceHolder("N")
+	a.repeatInput.Disable()
 
-		go autocopiar(rawSeries, date, time.Duration(delayMs)*time.Millisecond, countdownSec, statusLabel, copiedCounter)
+	a.repeatCheck = widget.NewCheck("Repetir la lista N veces", func(checked bool) {
+		if checked {
+			a.repeatInput.Enable()
+		} else {
+			a.repeatInput.Disable()
+		}
 	})
-	startButton.Importance = widget.HighImportance
 
-	cancelButton := widget.NewButton("⏹️ Cancelar", func() {
-		select {
-		case <-cancel:
-		default:
-			close(cancel)
-			statusLabel.SetText("Estado: Cancelado manualmente.")
+	// Secuencia tecleada por cada serie
+	a.macroInput = widget.NewMultiLineEntry()
+	a.macroInput.SetText(defaultMacro)
+	a.macroInput.SetMinRowsVisible(4)
+
+	macroClickButton := widget.NewButton("🖱️ Agregar clic", func() {
+		a.recordClickPosition()
+	})
+	macroWaitButton := widget.NewButton("🎯 Esperar color", func() {
+		a.recordPixelWait()
+	})
+	var macroRecordButton *widget.Button
+	macroRecordButton = widget.NewButton("⏺️ Grabar", func() {
+		a.toggleRecording(macroRecordButton)
+	})
+	a.runControls = append(a.runControls, macroRecordButton)
+
+	// Tecla que cierra cada registro
+	a.terminatorInput = widget.NewEntry()
+	a.terminatorInput.SetPlaceHolder("[enter] [down]")
+	a.terminatorInput.Hide()
+
+	a.terminatorSelect = widget.NewSelect(terminatorOptions, func(selected string) {
+		if selected == terminatorCustom {
+			a.terminatorInput.Show()
+		} else {
+			a.terminatorInput.Hide()
+		}
+	})
+	a.terminatorSelect.SetSelected(terminatorDown)
+
+	// Protección contra escribir en otra ventana
+	a.focusCheck = widget.NewCheck("Pausar si cambia la ventana activa", nil)
+	a.focusCheck.SetChecked(true)
+	a.lockCheck = widget.NewCheck("Pausar si se bloquea la pantalla", nil)
+	a.lockCheck.SetChecked(true)
+	a.userKeysCheck = widget.NewCheck("Cancelar si pulsas una tecla durante el proceso", nil)
+	a.userKeysCheck.SetChecked(true)
+
+	// Verificación OCR después de cada serie
+	a.ocrRegionInput = widget.NewEntry()
+	a.ocrRegionInput.SetPlaceHolder("X,Y,Ancho,Alto del campo")
+	a.ocrRegionInput.Disable()
+
+	ocrPickButton := widget.NewButton("📍", func() {
+		a.pickRegionFromMouse(a.ocrRegionInput)
+	})
+	ocrPickButton.Disable()
+
+	a.ocrCheck = widget.NewCheck("Verificar con OCR", func(checked bool) {
+		if checked {
+			a.ocrRegionInput.Enable()
+			ocrPickButton.Enable()
+		} else {
+			a.ocrRegionInput.Disable()
+			ocrPickButton.Disable()
+		}
+	})
+
+	// Ritmo adaptativo: esperar a que la aplicación responda en lugar de pausas fijas
+	a.pacingRegion = widget.NewEntry()
+	a.pacingRegion.SetPlaceHolder("X,Y,Ancho,Alto a vigilar")
+	a.pacingRegion.Disable()
+
+	pacingPickButton := widget.NewButton("📍", func() {
+		a.pickRegionFromMouse(a.pacingRegion)
+	})
+	pacingPickButton.Disable()
+
+	a.pacingSelect = widget.NewSelect(pacingOptions, func(option string) {
+		if option == pacingRegion {
+			a.pacingRegion.Enable()
+			pacingPickButton.Enable()
+		} else {
+			a.pacingRegion.Disable()
+			pacingPickButton.Disable()
+		}
+	})
+	a.pacingSelect.SetSelected(pacingFixed)
+
+	// Teclear o pegar los textos con acentos
+	a.typingSelect = widget.NewSelect(typingOptions, nil)
+	a.typingSelect.SetSelected(typingAuto)
+
+	// Inicio programado
+	a.scheduleInput = widget.NewEntry()
+	a.scheduleInput.SetPlaceHolder("HH:MM (ej. 18:05)")
+	a.scheduleInput.Disable()
+
+	a.scheduleCheck = widget.NewCheck("Programar inicio", func(checked bool) {
+		if checked {
+			a.scheduleInput.Enable()
+		} else {
+			a.scheduleInput.Disable()
+		}
+	})
+
+	// Labels de estado
+	a.statusLabel = widget.NewLabel("Estado: Esperando acción...")
+	a.statusLabel.Importance = widget.MediumImportance
+
+	a.copiedCounter = widget.NewLabel("Copiadas: 0 / 0")
+	a.copiedCounter.Importance = widget.LowImportance
+
+	// Botones
+	startButton := widget.NewButton("▶️ Iniciar Autocopiado", func() {
+		job, err := a.buildJob()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
 		}
+		a.start(job)
 	})
+	startButton.Importance = widget.HighImportance
+	a.runControls = append(a.runControls, startButton)
+
+	cancelButton := widget.NewButton("⏹️ Cancelar", a.cancelRun)
 	cancelButton.Importance = widget.MediumImportance
 
+	// Lotes grandes: confirmar con la duración estimada
+	a.longRunInput = widget.NewEntry()
+	a.longRunInput.SetText(strconv.Itoa(defaultLongRunThreshold))
+	a.longRunInput.SetPlaceHolder("0 = nunca")
+
+	// Ventana flotante con la serie en curso
+	a.overlayCheck = widget.NewCheck("Mostrar ventana flotante durante el proceso", nil)
+	a.overlayCheck.SetChecked(true)
+
+	// Compartir trabajos preparados entre equipos
+	exportButton := widget.NewButton("💾 Exportar", a.exportJob)
+	importButton := widget.NewButton("📂 Importar", a.importJob)
+
+	// Teclas globales que cancelan el proceso
+	a.cancelKeysInput = widget.NewEntry()
+	a.cancelKeysInput.SetText(defaultCancelKeys)
+	a.cancelKeysInput.SetPlaceHolder("Ej: esc, ctrl+alt+x")
+	applyKeysButton := widget.NewButton("Aplicar", func() {
+		if err := a.applyCancelKeys(); err != nil {
+			dialog.ShowError(fmt.Errorf("teclas de cancelación inválidas: %v", err), window)
+			return
+		}
+		a.statusLabel.SetText("Estado: Teclas de cancelación actualizadas")
+	})
+
 	// Información de ayuda
 	helpText := widget.NewRichTextFromMarkdown(`
 **Instrucciones:**
 1. Ingresa las series separadas por espacios
-2. Ingresa la fecha en formato DDMMAAAA
-3. Presiona "Iniciar Autocopiado"
-4. Puedes cancelar con el botón o presionando ESC
+2. Ingresa la fecha (DD/MM/AAAA, DD-MM-AA, DDMMAAAA o pegada desde Excel); se convierte automáticamente a DDMMAAAA
+3. Elige la velocidad de tecleo
+4. Presiona "Iniciar Autocopiado" y revisa la vista previa: muestra cada serie con sus fechas y marca las que tienen caracteres extraños o un largo distinto al resto
+5. Puedes cancelar con el botón o con las teclas de cancelación (ESC por defecto)
+
+**Secuencia por serie:** una acción por línea. Las teclas van entre corchetes ([tab], [enter], [ctrl+s]) y el resto se escribe como texto. Los clics del mouse se escriben [clic X Y], opcionalmente con "derecho", "central" o "doble"; el botón "Agregar clic" toma la posición actual del mouse tras 3 segundos. Para esperar la confirmación de la aplicación destino usa [esperar X Y RRGGBB segundos]: el proceso no sigue hasta que ese pixel tome el color indicado (10 segundos por defecto antes de pausar); el botón "Esperar color" captura el color bajo el mouse. Con "Grabar" puedes hacer una entrada a mano en la aplicación destino: se registran las teclas, textos y clics, y al detener se convierten en la secuencia (la serie y la fecha del formulario se reemplazan por {serie} y {fecha}). Variables disponibles: {serie}, {fecha}, {contador}, {total}, {iteracion}, {hoy} y {portapapeles} (lo copiado justo antes de iniciar, por ejemplo un número de pedido, igual para todos los registros). Ejemplo: "ZET-{serie}" escribe el prefijo fijo delante de cada serie.
+
+**Al terminar cada registro:** tecla que se pulsa después de cada serie para pasar a la siguiente fila (flecha abajo, Enter, Tab, ninguna o una secuencia personalizada como "[enter] [down]").
+
+**Filtros de series:** "Incluir solo" deja únicamente las series indicadas y "Excluir" quita las que ya se procesaron aunque sigan en la lista pegada. Se escriben separadas por espacios, comas o líneas; un "*" al final indica prefijo (ZET00* cubre ZET00154).
+
+**Dígito verificador:** con módulo 10 (Luhn) o módulo 11 (pesos 2 a 7, "K" para el resto 10) se revisa el último dígito de cada serie antes de iniciar; las que no cumplen se listan para detectar dígitos cambiados de lugar. Las letras del prefijo se ignoran.
+
+**Generar rango:** arma una lista de series consecutivas a partir de un prefijo, un número inicial, la cantidad y los dígitos con ceros a la izquierda (por ejemplo ZET00100 a ZET00249) y la agrega al final de la lista.
+
+**Varias fechas por registro:** pega filas desde Excel (columnas separadas por tabulador, ";" o ","), por ejemplo "ZET001;15/03/2024;15/03/2026". La primera columna es la serie y las siguientes sus fechas, que se escriben con {fecha}, {fecha2}, {fecha3}... en la secuencia. Si una serie no trae fechas se usa la fecha general. Una columna como "800ms" o "2s" fija la pausa de ese registro (entre acciones y al pasar a la siguiente fila) en lugar de la de la velocidad elegida.
+
+**Orden:** por defecto las series se escriben tal como están en la lista. También puedes ordenarlas de forma ascendente o descendente (los números se comparan por valor) o invertir la lista antes de iniciar.
+
+**Variación humana:** agrega una pausa aleatoria (±20–50 ms) a cada retardo para que el tecleo parezca natural.
+
+**Modo escáner:** activa la casilla y escanea las cajas con el lector USB; cada código leído se agrega automáticamente a la lista de series.
+
+**Pausar cada N series:** el proceso se detiene al completar cada lote y espera tu confirmación para continuar. Al confirmar hay una cuenta regresiva de 3 segundos para que vuelvas a la ventana destino.
+
+**Alternar entre dos ventanas:** elige dos ventanas de programas distintos (por ejemplo dos sesiones del ERP) con los botones 🎯: tras 3 segundos se toma la ventana que tenga el foco. Los registros se reparten uno y uno entre ambas y el foco se cambia solo, así que deja el cursor en el campo inicial de cada ventana antes de comenzar.
+
+**Cancelar si pulsas una tecla:** cualquier tecla que pulses mientras el proceso escribe (salvo las teclas para cancelar) lo detiene de inmediato, porque las pulsaciones mezcladas corrompen los registros. Durante las pausas con confirmación y las cuentas regresivas puedes usar el teclado con normalidad.
+
+**Confirmar lotes de más de:** si el lote supera ese número de series (contando repeticiones) se muestra la duración estimada y la hora aproximada de término antes de iniciar. Usa 0 para no preguntar nunca. Durante el proceso, junto al contador se muestra el tiempo que falta según el ritmo real.
+
+**Cola de trabajos:** prepara el formulario y agrégalo a la cola con ➕; repite con otras series o fechas. Al ejecutar la cola los trabajos corren uno tras otro, cada uno con su cuenta regresiva y la pausa indicada entre ellos. Si uno se cancela o falla, la cola se detiene y los siguientes quedan pendientes.
+
+**Exportar / Importar:** guarda el trabajo completo (series, fecha, velocidad, pausas, secuencia y opciones) en un archivo .json para cargarlo en otro equipo, por ejemplo para dejarle un lote preparado al turno de noche. La hora programada no se exporta.
+
+**Ventana flotante:** mientras se escribe aparece una ventana pequeña encima de las demás con la serie en curso, su número y un botón para cancelar, ya que la ventana principal suele quedar tapada por la aplicación destino. En Linux necesita wmctrl para quedar encima.
+
+**Auditoría:** cada serie escrita se registra con fecha, equipo y usuario en auditoria_autocopiado.jsonl. Cada línea incluye el hash de la anterior, así que cualquier edición o borrado se detecta con "🔐 Verificar auditoría" en el historial.
+
+**Sin interfaz:** un trabajo exportado se puede lanzar desde un script con "herramienta --autocopy trabajo.json". El avance se muestra en la consola y ESC lo cancela. Como no hay diálogos, si cambia la ventana activa, se bloquea la pantalla o no aparece un color esperado el proceso se detiene; las pausas por lote y el OCR no están disponibles en este modo.
+
+**Métricas diarias:** el panel de estadísticas muestra las series escritas por día en las últimas dos semanas junto con la velocidad promedio. Se guardan en metricas_autocopiado.json y se conservan entre reinicios.
+
+**Pausar si se bloquea la pantalla:** si la sesión se bloquea o se activa el protector de pantalla durante el proceso, el tecleo se detiene hasta que vuelvas a desbloquear y confirmes. En macOS no se detecta el bloqueo.
+
+**Programar inicio:** el proceso espera hasta la hora indicada (HH:MM). Si la hora ya pasó, se programa para mañana. Puedes cancelarlo antes de que comience.
+
+**Pausar si cambia la ventana activa:** al terminar la cuenta regresiva se toma la ventana activa como destino. Si el foco pasa a otra aplicación (chat, correo, etc.) el proceso se pausa de inmediato hasta que confirmes y vuelvas a la ventana destino.
+
+**Verificar con OCR:** después de escribir cada serie se captura la región indicada (X,Y,Ancho,Alto; usa 📍 para tomar la posición del mouse) y se lee su texto. Si la serie no aparece, se anota en verificacion_ocr.log y el proceso se pausa. Requiere compilar con -tags ocr y tener Tesseract instalado.
+
+**Escritura:** en modo automático los textos con ñ o tildes (descripciones, nombres) se pegan desde el portapapeles, porque el tecleo simulado los cambia en teclados en español; el resto se teclea. El portapapeles se restaura después de pegar. Si la aplicación destino no acepta pegar, elige "Teclear siempre".
 
-**Nota:** El proceso comenzará después de una cuenta regresiva de 5 segundos.
+**Ritmo:** con "Pausas fijas" se espera siempre lo mismo entre acciones. Para pantallas lentas elige "Esperar cambio de título" o "Esperar cambio en región": después de cada tecla o clic se espera (hasta 3 segundos) a que cambie el título de la ventana o el contenido de la región indicada antes de seguir.
+
+**Repetir la lista:** escribe la lista completa N veces seguidas (útil para cargar datos de prueba). El contador muestra la iteración y la serie dentro de ella; {contador} y {total} se refieren a la iteración actual.
+
+**Teclas para cancelar:** una o varias combinaciones separadas por comas, por ejemplo "ctrl+alt+x" si la aplicación destino usa ESC. Las letras y números necesitan un modificador (ctrl, alt, shift o cmd).
+
+**Sesión:** las series, la fecha y los filtros se guardan al cerrar la aplicación y se recuperan al volver a abrirla.
+
+**Historial:** cada ejecución queda registrada. Selecciona una para cargarla, re-ejecutarla o reanudar solo las series pendientes.
+
+**Nota:** El proceso comenzará después de una cuenta regresiva de 5 segundos. Al terminar o interrumpirse se reproduce un sonido y se muestra una notificación del sistema.
 `)
 	helpText.Wrapping = fyne.TextWrapWord
 
@@ -244,53 +766,339 @@ func createAutocopiadorTab(window fyne.Window) *fyne.Container {
 	// Cards
 	inputCard := widget.NewCard("📋 Datos de Entrada", "",
 		container.NewVBox(
-			widget.NewLabel("Series:"),
+			container.NewBorder(nil, nil, widget.NewLabel("Series:"), rangeButton),
 			seriesScroll,
+			scanCheck,
+			scanBox,
+			container.NewBorder(nil, nil, widget.NewLabel("Orden:"), nil, a.orderSelect),
+			a.createFilterPanel(),
+			container.NewBorder(nil, nil, widget.NewLabel("Dígito verificador:"), nil, a.checkDigitSelect),
 			widget.NewLabel("Fecha:"),
-			dateInput,
+			a.dateInput,
+			datePreview,
+			widget.NewLabel("Velocidad:"),
+			a.speedSelect,
+			container.NewBorder(nil, nil, widget.NewLabel("Secuencia por serie:"),
+				container.NewHBox(macroRecordButton, macroClickButton, macroWaitButton)),
+			a.macroInput,
+			container.NewBorder(nil, nil, widget.NewLabel("Al terminar cada registro:"), nil, a.terminatorSelect),
+			a.terminatorInput,
+			container.NewBorder(nil, nil, a.jitterCheck, jitterLabel, a.jitterSlider),
+			container.NewBorder(nil, nil, a.pauseCheck, nil, a.pauseEveryInput),
+			container.NewBorder(nil, nil, a.repeatCheck, nil, a.repeatInput),
+			a.focusCheck,
+			a.lockCheck,
+			a.userKeysCheck,
+			a.createAlternateWindowsPanel(),
+			container.NewBorder(nil, nil, a.scheduleCheck, nil, a.scheduleInput),
+			container.NewBorder(nil, nil, a.ocrCheck, ocrPickButton, a.ocrRegionInput),
+			container.NewBorder(nil, nil, widget.NewLabel("Escritura:"), nil, a.typingSelect),
+			container.NewBorder(nil, nil, widget.NewLabel("Ritmo:"), nil, a.pacingSelect),
+			container.NewBorder(nil, nil, nil, pacingPickButton, a.pacingRegion),
 		),
 	)
 
 	controlCard := widget.NewCard("🎮 Controles", "",
 		container.NewVBox(
-			container.NewHBox(startButton, cancelButton),
+			container.NewHBox(startButton, cancelButton, exportButton, importButton),
+			container.NewBorder(nil, nil, widget.NewLabel("Teclas para cancelar:"), applyKeysButton, a.cancelKeysInput),
+			container.NewBorder(nil, nil, widget.NewLabel("Confirmar lotes de más de:"), widget.NewLabel("series"), a.longRunInput),
+			a.overlayCheck,
 			widget.NewSeparator(),
-			statusLabel,
-			copiedCounter,
+			a.statusLabel,
+			a.copiedCounter,
 		),
 	)
 
 	helpCard := widget.NewCard("ℹ️ Ayuda", "", helpScroll)
+	historyCard := a.createHistoryCard()
+	queueCard := a.createQueueCard()
 
 	return container.NewVBox(
 		widget.NewLabel("Autocopiador de Series"),
 		container.NewHBox(
-			container.NewVBox(inputCard, controlCard),
-			helpCard,
+			container.NewVBox(inputCard, controlCard, a.createStatsPanel()),
+			container.NewVBox(helpCard, historyCard, queueCard),
 		),
 	)
 }
 
+// buildJob valida el formulario y arma el trabajo a ejecutar
+func (a *Autocopiador) buildJob() (*AutocopyJob, error) {
+	rawSeries := a.seriesInput.Text
+	date := strings.TrimSpace(a.dateInput.Text)
+
+	if len(splitRecords(rawSeries)) == 0 {
+		return nil, fmt.Errorf("debes ingresar al menos una serie")
+	}
+	// La fecha general es opcional si cada serie trae sus propias fechas
+	if date != "" {
+		var err error
+		if date, err = normalizeDate(date); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := findSpeedPreset(a.speedSelect.Selected); !ok {
+		return nil, fmt.Errorf("selecciona una velocidad válida")
+	}
+
+	steps, err := parseMacro(a.macroInput.Text)
+	if err != nil {
+		return nil, fmt.Errorf("secuencia inválida: %v", err)
+	}
+	terminator, err := parseTerminator(a.terminatorSelect.Selected, a.terminatorInput.Text)
+	if err != nil {
+		return nil, err
+	}
+	// Avisar ya si falta el valor copiado; al iniciar se vuelve a leer
+	if macroUsesPlaceholder(append(steps, terminator...), "portapapeles") {
+		if _, err := readClipboardValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	job := &AutocopyJob{
+		Series:    rawSeries,
+		Fecha:     date,
+		Velocidad: a.speedSelect.Selected,
+		Macro:     a.macroInput.Text,
+		Orden:     a.orderSelect.Selected,
+		Incluir:   a.includeInput.Text,
+		Excluir:   a.excludeInput.Text,
+
+		Terminador:              a.terminatorSelect.Selected,
+		TerminadorPersonalizado: a.terminatorInput.Text,
+		VigilarVentana:          a.focusCheck.Checked,
+		VigilarBloqueo:          a.lockCheck.Checked,
+		CancelarSiTeclea:        a.userKeysCheck.Checked,
+	}
+	if a.jitterCheck.Checked {
+		job.Variacion = time.Duration(a.jitterSlider.Value) * time.Millisecond
+	}
+	if a.pauseCheck.Checked {
+		n, err := strconv.Atoi(strings.TrimSpace(a.pauseEveryInput.Text))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("el número de series por lote debe ser un entero mayor a 0")
+		}
+		job.PausarCada = n
+	}
+	if a.repeatCheck.Checked {
+		n, err := strconv.Atoi(strings.TrimSpace(a.repeatInput.Text))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("el número de repeticiones debe ser un entero mayor a 0")
+		}
+		job.Repeticiones = n
+	}
+	if a.altCheck.Checked {
+		windows := append([]VentanaDestino(nil), a.altWindows[:]...)
+		if err := validateAlternateWindows(windows); err != nil {
+			return nil, err
+		}
+		job.Ventanas = windows
+	}
+	if a.checkDigitSelect.Selected != checkDigitNone {
+		job.DigitoVerificador = a.checkDigitSelect.Selected
+	}
+	if a.typingSelect.Selected != typingAuto {
+		job.Escritura = a.typingSelect.Selected
+	}
+	if a.pacingSelect.Selected != pacingFixed {
+		job.Ritmo = a.pacingSelect.Selected
+	}
+	if job.Ritmo == pacingRegion {
+		region, err := parseRegion(a.pacingRegion.Text)
+		if err != nil {
+			return nil, err
+		}
+		job.RegionRitmo = region
+	}
+	if a.ocrCheck.Checked {
+		if !ocrAvailable {
+			return nil, fmt.Errorf("esta versión no incluye OCR: compila con -tags ocr y Tesseract instalado")
+		}
+		region, err := parseRegion(a.ocrRegionInput.Text)
+		if err != nil {
+			return nil, err
+		}
+		job.VerificarOCR = true
+		job.RegionOCR = region
+	}
+	if a.scheduleCheck.Checked {
+		start, err := nextClockTime(a.scheduleInput.Text, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		job.Programado = start
+	}
+	// Las fechas de cada registro se validan ya con la secuencia elegida
+	if _, err := job.records(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// loadJob vuelca un trabajo guardado en el formulario
+func (a *Autocopiador) loadJob(job *AutocopyJob) {
+	a.seriesInput.SetText(job.Series)
+	a.includeInput.SetText(job.Incluir)
+	a.excludeInput.SetText(job.Excluir)
+	if job.DigitoVerificador == "" {
+		a.checkDigitSelect.SetSelected(checkDigitNone)
+	} else {
+		a.checkDigitSelect.SetSelected(job.DigitoVerificador)
+	}
+	a.dateInput.SetText(job.Fecha)
+	if job.Terminador == "" {
+		a.terminatorSelect.SetSelected(terminatorDown)
+	} else {
+		a.terminatorSelect.SetSelected(job.Terminador)
+	}
+	a.terminatorInput.SetText(job.TerminadorPersonalizado)
+	if job.Orden == "" {
+		a.orderSelect.SetSelected(orderOriginal)
+	} else {
+		a.orderSelect.SetSelected(job.Orden)
+	}
+	if strings.TrimSpace(job.Macro) == "" {
+		a.macroInput.SetText(defaultMacro)
+	} else {
+		a.macroInput.SetText(job.Macro)
+	}
+	if _, ok := findSpeedPreset(job.Velocidad); ok {
+		a.speedSelect.SetSelected(job.Velocidad)
+	} else {
+		a.speedSelect.SetSelected(defaultSpeedPreset)
+	}
+
+	a.jitterCheck.SetChecked(job.Variacion > 0)
+	if job.Variacion > 0 {
+		a.jitterSlider.SetValue(float64(job.Variacion / time.Millisecond))
+	}
+
+	a.pauseCheck.SetChecked(job.PausarCada > 0)
+	if job.PausarCada > 0 {
+		a.pauseEveryInput.SetText(strconv.Itoa(job.PausarCada))
+	} else {
+		a.pauseEveryInput.SetText("")
+	}
+
+	a.repeatCheck.SetChecked(job.Repeticiones > 1)
+	if job.Repeticiones > 1 {
+		a.repeatInput.SetText(strconv.Itoa(job.Repeticiones))
+	} else {
+		a.repeatInput.SetText("")
+	}
+
+	a.focusCheck.SetChecked(job.VigilarVentana)
+	a.lockCheck.SetChecked(job.VigilarBloqueo)
+	a.userKeysCheck.SetChecked(job.CancelarSiTeclea)
+	a.loadAlternateWindows(job.Ventanas)
+	a.ocrCheck.SetChecked(job.VerificarOCR)
+	if job.VerificarOCR {
+		a.ocrRegionInput.SetText(job.RegionOCR.String())
+	}
+
+	if job.Escritura == "" {
+		a.typingSelect.SetSelected(typingAuto)
+	} else {
+		a.typingSelect.SetSelected(job.Escritura)
+	}
+
+	if job.Ritmo == "" {
+		a.pacingSelect.SetSelected(pacingFixed)
+	} else {
+		a.pacingSelect.SetSelected(job.Ritmo)
+	}
+	if job.Ritmo == pacingRegion {
+		a.pacingRegion.SetText(job.RegionRitmo.String())
+	}
+
+	// La hora programada no se reutiliza
+	a.scheduleCheck.SetChecked(false)
+}
+
+// start muestra la vista previa de lo que se va a escribir y, al confirmarla, lanza el
+// autocopiado en segundo plano; solo se permite un trabajo a la vez
+func (a *Autocopiador) start(job *AutocopyJob) {
+	if a.running {
+		dialog.ShowError(fmt.Errorf("ya hay un autocopiado programado o en curso, cancélalo antes de iniciar otro"), a.window)
+		return
+	}
+	if a.recorder != nil {
+		dialog.ShowError(fmt.Errorf("detén la grabación de la secuencia antes de iniciar"), a.window)
+		return
+	}
+
+	records, err := job.records()
+	if err != nil {
+		dialog.ShowError(err, a.window)
+		return
+	}
+	a.confirmCheckDigits(job, records, func() {
+		a.showPreview(records, job, func() {
+			a.confirmLongRun(job, records, func() {
+				a.launch(job)
+			})
+		})
+	})
+}
+
+// launch programa o inicia el trabajo ya confirmado
+func (a *Autocopiador) launch(job *AutocopyJob) {
+	a.setRunning(true)
+
+	if job.Programado.IsZero() {
+		a.statusLabel.SetText(fmt.Sprintf("Iniciando en %d segundos...", a.countdown))
+	} else {
+		a.statusLabel.SetText(fmt.Sprintf("Programado para las %s", job.Programado.Format("15:04")))
+	}
+	a.copiedCounter.SetText("Copiadas: 0 / 0")
+
+	cancel = make(chan struct{})
+	a.showOverlay()
+
+	go func() {
+		a.autocopiar(job)
+		fyne.Do(func() {
+			a.setRunning(false)
+			a.closeOverlay()
+		})
+	}()
+}
+
+// setRunning marca el estado del trabajo y habilita o deshabilita los botones de inicio
+func (a *Autocopiador) setRunning(running bool) {
+	a.running = running
+	for _, c := range a.runControls {
+		if running {
+			c.Disable()
+		} else {
+			c.Enable()
+		}
+	}
+}
+
 func (r *RotuloGenerator) createRotuloTab(window fyne.Window) *fyne.Container {
 	// Inicializar vista previa
 	r.preview = widget.NewRichText()
 	r.preview.Wrapping = fyne.TextWrapWord
+	r.rasterTool, r.rasterPath = findRasterizer()
+	r.pdfPreview = widget.NewLabel("Generando vista previa...")
+	r.pdfPreview.Wrapping = fyne.TextWrapWord
+	r.pdfImage = &canvas.Image{FillMode: canvas.ImageFillContain}
 
 	// Selección de empresa
-	r.empresaCheck = widget.NewRadioGroup([]string{"ZETTACOM", "COMSITEC"}, func(selected string) {
+	r.empresaCheck = widget.NewRadioGroup(companyNames(), func(selected string) {
 		r.data.Empresa = selected
 
-		// Autocompletar datos
-		if empresaData, ok := empresasData[selected]; ok {
-			r.inputs["remitenteNombre"].SetText(empresaData.Nombre)
-			r.inputs["remitenteDireccion"].SetText(empresaData.Direccion)
-			r.inputs["remitenteTelefono"].SetText(empresaData.Telefono)
-		}
+		// Autocompletar datos con el remitente principal
+		r.refreshRemitentes()
 
 		r.updateLogoPreview(selected)
 		r.updatePreview()
 	})
 	r.empresaCheck.Horizontal = true
+	r.sucursal = widget.NewSelect(nil, r.applyRemitente)
 
 	// Logo preview
 	r.logoPreview = &canvas.Image{}
@@ -299,7 +1107,7 @@ func (r *RotuloGenerator) createRotuloTab(window fyne.Window) *fyne.Container {
 
 	// Configuración
 	r.tamanoHoja = widget.NewSelect(
-		[]string{"A4", "A5", "Carta"},
+		paperSizeNames,
 		func(selected string) {
 			r.data.TamanoHoja = selected
 			r.updatePreview()
@@ -317,6 +1125,20 @@ func (r *RotuloGenerator) createRotuloTab(window fyne.Window) *fyne.Container {
 	r.orientacion.Horizontal = true
 	r.orientacion.SetSelected("Vertical")
 
+	// Varios rótulos por hoja siempre van en A4 vertical
+	r.distribucion = widget.NewSelect(sheetLayoutNames(), func(selected string) {
+		r.data.Distribucion = selected
+		if findSheetLayout(selected).perSheet() > 1 {
+			r.tamanoHoja.Disable()
+			r.orientacion.Disable()
+		} else {
+			r.tamanoHoja.Enable()
+			r.orientacion.Enable()
+		}
+		r.updatePreview()
+	})
+	r.distribucion.SetSelected(sheetLayouts[0].Name)
+
 	// Crear inputs
 	r.createInputs()
 
@@ -331,6 +1153,22 @@ func (r *RotuloGenerator) createRotuloTab(window fyne.Window) *fyne.Container {
 	})
 	printButton.Importance = widget.MediumImportance
 
+	batchButton := widget.NewButton("📚 Generación masiva (CSV)", func() {
+		r.showBatch(window)
+	})
+
+	thermalButton := widget.NewButton("🏷️ Térmica (ZPL/TSPL/EPL)", func() {
+		r.showThermal(window)
+	})
+
+	saveDraftButton := widget.NewButton("📝 Guardar borrador", func() {
+		r.saveDraft(window)
+	})
+
+	openDraftButton := widget.NewButton("📂 Abrir borrador", func() {
+		r.openDraft(window)
+	})
+
 	clearButton := widget.NewButton("🗑️ Limpiar", func() {
 		r.clearFields()
 	})
@@ -342,17 +1180,26 @@ func (r *RotuloGenerator) createRotuloTab(window fyne.Window) *fyne.Container {
 	// Vista previa
 	previewScroll := container.NewScroll(r.preview)
 	previewScroll.SetMinSize(fyne.NewSize(400, 500))
+	previewContent := fyne.CanvasObject(previewScroll)
+	if r.rasterTool != "" {
+		// Con un programa que dibuje PDF se ve la página tal como se imprime y el resumen
+		// queda solo para cuando no hay ninguno
+		r.pdfImage.SetMinSize(fyne.NewSize(400, 500))
+		previewContent = container.NewBorder(r.pdfPreview, nil, nil, nil, r.pdfImage)
+	}
 
 	// Layout del formulario
 	formCard := r.createFormLayout()
 
 	// Card de vista previa
-	previewCard := widget.NewCard("👁️ Vista Previa del Rótulo", "", previewScroll)
+	previewCard := widget.NewCard("👁️ Vista Previa del Rótulo", "", previewContent)
 
 	// Card de controles
 	controlCard := widget.NewCard("🎮 Acciones", "",
 		container.NewVBox(
 			container.NewGridWithColumns(2, generateButton, printButton),
+			container.NewGridWithColumns(2, batchButton, thermalButton),
+			container.NewGridWithColumns(2, saveDraftButton, openDraftButton),
 			container.NewGridWithColumns(2, autoFillButton, clearButton),
 			widget.NewSeparator(),
 			widget.NewLabel("✨ Rótulo profesional con logo y QR"),
@@ -362,9 +1209,10 @@ func (r *RotuloGenerator) createRotuloTab(window fyne.Window) *fyne.Container {
 	)
 
 	// Establecer valores por defecto
-	r.empresaCheck.SetSelected("ZETTACOM")
-	r.data.Empresa = "ZETTACOM"
-	r.updateLogoPreview("ZETTACOM")
+	empresa := defaultEmpresa()
+	r.empresaCheck.SetSelected(empresa)
+	r.data.Empresa = empresa
+	r.updateLogoPreview(empresa)
 	r.updatePreview()
 
 	// Layout principal
@@ -406,8 +1254,15 @@ func (r *RotuloGenerator) createInputs() {
 	r.inputs["destinatarioNombre"].SetPlaceHolder("Nombre completo del destinatario")
 	r.inputs["destinatarioNombre"].OnChanged = func(text string) {
 		r.data.DestinatarioNombre = text
+		// Otro destinatario es otro envío: la guía apartada para el anterior no se repite
+		if r.autoGuia {
+			r.inputs["numeroGuia"].SetText("")
+		}
+		r.updateSuggestions(text)
 		r.updatePreview()
 	}
+	r.suggestions = container.NewVBox()
+	r.suggestions.Hide()
 
 	r.inputs["destinatarioDireccion"] = widget.NewMultiLineEntry()
 	r.inputs["destinatarioDireccion"].SetPlaceHolder("Dirección completa del destinatario")
@@ -424,6 +1279,13 @@ func (r *RotuloGenerator) createInputs() {
 		r.updatePreview()
 	}
 
+	r.inputs["destinatarioDNI"] = widget.NewEntry()
+	r.inputs["destinatarioDNI"].SetPlaceHolder("DNI del destinatario (opcional)")
+	r.inputs["destinatarioDNI"].OnChanged = func(text string) {
+		r.data.DestinatarioDNI = text
+		r.updatePreview()
+	}
+
 	r.inputs["peso"] = widget.NewEntry()
 	r.inputs["peso"].SetPlaceHolder("Peso del paquete (opcional)")
 	r.inputs["peso"].OnChanged = func(text string) {
@@ -435,6 +1297,7 @@ func (r *RotuloGenerator) createInputs() {
 	r.inputs["numeroGuia"].SetPlaceHolder("Número de guía (se genera automático)")
 	r.inputs["numeroGuia"].OnChanged = func(text string) {
 		r.data.NumeroGuia = text
+		r.autoGuia = false
 		r.updatePreview()
 	}
 
@@ -449,15 +1312,26 @@ func (r *RotuloGenerator) createInputs() {
 
 func (r *RotuloGenerator) createFormLayout() *widget.Card {
 	// Empresa y logo
+	empresasButton := widget.NewButton("🏢 Empresas", func() {
+		r.showEmpresas(r.window)
+	})
 	empresaForm := container.NewVBox(
-		widget.NewLabel("EMPRESA:"),
+		container.NewBorder(nil, nil, widget.NewLabel("EMPRESA:"), empresasButton),
 		r.empresaCheck,
 		container.NewCenter(r.logoPreview),
 	)
 
 	// Remitente
+	saveRemitenteButton := widget.NewButton("💾", func() {
+		r.saveRemitente(r.window)
+	})
+	deleteRemitenteButton := widget.NewButton("🗑️", func() {
+		r.deleteSelectedRemitente(r.window)
+	})
 	remitenteForm := container.NewVBox(
 		widget.NewLabel("REMITENTE:"),
+		widget.NewLabel("Sucursal:"),
+		container.NewBorder(nil, nil, nil, container.NewHBox(saveRemitenteButton, deleteRemitenteButton), r.sucursal),
 		widget.NewLabel("Nombre:"),
 		r.inputs["remitenteNombre"],
 		widget.NewLabel("Dirección:"),
@@ -467,14 +1341,24 @@ func (r *RotuloGenerator) createFormLayout() *widget.Card {
 	)
 
 	// Destinatario
+	libretaButton := widget.NewButton("📒 Libreta", func() {
+		r.showAddressBook(r.window)
+	})
+	saveContactButton := widget.NewButton("💾 Guardar en libreta", func() {
+		r.saveDestinatario(r.window)
+	})
 	destinatarioForm := container.NewVBox(
-		widget.NewLabel("DESTINATARIO:"),
+		container.NewBorder(nil, nil, widget.NewLabel("DESTINATARIO:"), libretaButton),
 		widget.NewLabel("Nombre:"),
 		r.inputs["destinatarioNombre"],
+		r.suggestions,
 		widget.NewLabel("Dirección:"),
 		r.inputs["destinatarioDireccion"],
 		widget.NewLabel("Teléfono:"),
 		r.inputs["destinatarioTelefono"],
+		widget.NewLabel("DNI:"),
+		r.inputs["destinatarioDNI"],
+		saveContactButton,
 	)
 
 	// Detalles
@@ -507,6 +1391,8 @@ func (r *RotuloGenerator) createFormLayout() *widget.Card {
 				r.orientacion,
 			),
 		),
+		widget.NewLabel("Rótulos por hoja:"),
+		r.distribucion,
 		widget.NewLabel("💡 El diseño se adaptará automáticamente"),
 		widget.NewLabel("📄 Todo el contenido en una sola página"),
 	)
@@ -530,9 +1416,16 @@ func (r *RotuloGenerator) generateProfessionalPDF(window fyne.Window) {
 		return
 	}
 
-	// Generar número de guía si está vacío
-	if r.data.NumeroGuia == "" {
-		r.data.NumeroGuia = fmt.Sprintf("%s%d", r.data.Empresa[:3], time.Now().Unix()%1000000)
+	// Apartar el siguiente número de guía de la empresa si está vacío
+	if err := r.ensureGuia(); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	// El número va en un Code 128 que deben poder leer los couriers; se revisa antes de
+	// elegir dónde guardar para no dejar un PDF vacío
+	if _, err := code128Values(r.data.NumeroGuia); err != nil {
+		dialog.ShowError(err, window)
+		return
 	}
 
 	timestamp := time.Now().Format("20060102_150405")
@@ -562,8 +1455,8 @@ func (r *RotuloGenerator) generateProfessionalPDF(window fyne.Window) {
 				return
 			}
 
-			r.pdfCounter++
 			filePath := writer.URI().Path()
+			r.recordLabels([]RotuloData{*r.data}, filePath)
 
 			dialog.ShowInformation("✅ Rótulo Generado",
 				fmt.Sprintf("Rótulo profesional generado exitosamente:\n\n"+
@@ -586,6 +1479,7 @@ func (r *RotuloGenerator) generateProfessionalPDF(window fyne.Window) {
 					r.data.Orientacion,
 					r.data.RemitenteNombre,
 					r.data.DestinatarioNombre), window)
+			r.releaseGuia(r.data.NumeroGuia)
 		},
 		window)
 
@@ -594,9 +1488,31 @@ func (r *RotuloGenerator) generateProfessionalPDF(window fyne.Window) {
 	saveDialog.Show()
 }
 
+// createProfessionalPDF arma el rótulo del formulario; si van varios por hoja la llena con
+// copias del mismo
 func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
+	return createRotuloPDF(*r.data)
+}
+
+// createRotuloPDF arma el PDF de un rótulo, repetido para llenar la hoja si van varios
+func createRotuloPDF(data RotuloData) ([]byte, error) {
+	labels := make([]RotuloData, findSheetLayout(data.Distribucion).perSheet())
+	for i := range labels {
+		labels[i] = data
+	}
+	return createLabelsPDF(labels)
+}
+
+// createLabelsPDF arma un PDF con una página por rótulo, o con varios por hoja A4 si el
+// primero tiene otra distribución; todos usan el tamaño y la orientación del primero
+func createLabelsPDF(labels []RotuloData) ([]byte, error) {
+	first := labels[0]
+	layout := findSheetLayout(first.Distribucion)
+	if layout.perSheet() > 1 {
+		first.TamanoHoja, first.Orientacion = "A4", "Vertical"
+	}
 	// Obtener dimensiones según tamaño y orientación
-	paperSize, ok := paperSizes[r.data.TamanoHoja]
+	paperSize, ok := paperSizes[first.TamanoHoja]
 	if !ok {
 		paperSize = paperSizes["A4"] // Default
 	}
@@ -606,13 +1522,21 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	width := paperSize.Width
 	height := paperSize.Height
 
-	if r.data.Orientacion == "Horizontal" {
+	if first.Orientacion == "Horizontal" {
 		orientation = "L" // Landscape (horizontal)
 		width, height = height, width
 	}
 
-	// Crear PDF con gofpdf
-	pdf := gofpdf.New(orientation, "mm", r.data.TamanoHoja, "")
+	// Crear PDF con gofpdf; el tamaño va en mm porque gofpdf no conoce los nombres Carta
+	// ni los de etiquetas térmicas
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: orientation,
+		UnitStr:        "mm",
+		Size:           gofpdf.SizeType{Wd: paperSize.Width, Ht: paperSize.Height},
+	})
+	// El diseño usa posiciones fijas hasta el borde inferior; con el salto automático el pie
+	// pasaba a otras páginas y cada rótulo ocupaba tres
+	pdf.SetAutoPageBreak(false, 0)
 
 	// Intentar cargar fuentes UTF-8, si no existen usar Arial
 	fontFamily := "Arial"
@@ -622,21 +1546,41 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 		fontFamily = "DejaVu"
 	}
 
-	pdf.AddPage()
-
-	// Obtener datos de la empresa
-	empresaData := empresasData[r.data.Empresa]
+	if layout.perSheet() > 1 {
+		if err := drawLabelSheets(pdf, fontFamily, layout, labels); err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range labels {
+			pdf.AddPage()
+			if err := drawLabelPage(pdf, fontFamily, width, height, paperSize.Scale, &labels[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
 
-	// Calcular factor de escala basado en el tamaño
-	scale := 1.0
-	if r.data.TamanoHoja == "A5" {
-		scale = 0.7
-	} else if r.data.TamanoHoja == "Carta" {
-		scale = 1.03
+	// Usar bytes.Buffer para capturar el output
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("error generando PDF: %v", err)
 	}
 
-	// Configurar colores corporativos
-	pdf.SetFillColor(empresaData.Color.R, empresaData.Color.G, empresaData.Color.B)
+	return buf.Bytes(), nil
+}
+
+// drawLabelPage dibuja el rótulo de data en un área de width x height que empieza en el
+// origen de la página actual de pdf
+func drawLabelPage(pdf *gofpdf.Fpdf, fontFamily string, width, height, scale float64, data *RotuloData) error {
+	// Obtener datos de la empresa
+	empresaData := empresasData[data.Empresa]
+
+	// En las etiquetas térmicas de 10 cm no entran remitente y destinatario lado a lado ni
+	// el tracking junto al nombre: van uno debajo del otro
+	narrow := width < 180*scale
+
+	// Configurar colores corporativos
+	pdf.SetFillColor(empresaData.Color.R, empresaData.Color.G, empresaData.Color.B)
 	pdf.SetTextColor(255, 255, 255)
 
 	// HEADER - Banda superior con color corporativo
@@ -644,26 +1588,32 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	pdf.Rect(0, 0, width, headerHeight, "F")
 
 	// Logo (si existe)
-	logoPath := zettacomLogo
-	if r.data.Empresa == "COMSITEC" {
-		logoPath = comsitecLogo
-	}
+	logoPath := empresaData.Logo
 
-	if _, err := os.Stat(logoPath); err == nil {
+	if _, err := os.Stat(logoPath); logoPath != "" && err == nil {
 		logoWidth := 25.0 * scale
 		logoHeight := 12.0 * scale
 		pdf.Image(logoPath, 5*scale, 4*scale, logoWidth, logoHeight, false, "", 0, "")
 	}
 
-	// Título de la empresa
-	pdf.SetFont(fontFamily, "B", 14*scale)
-	pdf.SetXY(35*scale, 6*scale)
-	pdf.Cell(80*scale, 8*scale, empresaData.Nombre)
-
-	// Número de tracking prominente
-	pdf.SetFont(fontFamily, "B", 12*scale)
-	pdf.SetXY(width-70*scale, 6*scale)
-	pdf.Cell(60*scale, 8*scale, "TRACKING: "+r.data.NumeroGuia)
+	if narrow {
+		pdf.SetFont(fontFamily, "B", 14*scale)
+		pdf.SetXY(35*scale, 3*scale)
+		pdf.Cell(width-40*scale, 7*scale, empresaData.Nombre)
+		pdf.SetFont(fontFamily, "B", 10*scale)
+		pdf.SetXY(35*scale, 11*scale)
+		pdf.Cell(width-40*scale, 6*scale, "TRACKING: "+data.NumeroGuia)
+	} else {
+		// Título de la empresa
+		pdf.SetFont(fontFamily, "B", 14*scale)
+		pdf.SetXY(35*scale, 6*scale)
+		pdf.Cell(80*scale, 8*scale, empresaData.Nombre)
+
+		// Número de tracking prominente
+		pdf.SetFont(fontFamily, "B", 12*scale)
+		pdf.SetXY(width-70*scale, 6*scale)
+		pdf.Cell(60*scale, 8*scale, "TRACKING: "+data.NumeroGuia)
+	}
 
 	// Resetear color de texto
 	pdf.SetTextColor(0, 0, 0)
@@ -671,8 +1621,11 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	// Posición inicial después del header
 	currentY := headerHeight + 5*scale
 
-	// SECCIÓN FROM y TO en la misma línea
+	// SECCIÓN FROM y TO en la misma línea, o una debajo de la otra si la hoja es angosta
 	sectionWidth := (width - 15*scale) / 2
+	if narrow {
+		sectionWidth = width - 10*scale
+	}
 
 	// FROM (Remitente)
 	pdf.SetFont(fontFamily, "B", 10*scale)
@@ -685,46 +1638,53 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	pdf.SetXY(5*scale, currentY+6*scale)
 
 	// Texto del remitente en líneas controladas
-	fromText := fmt.Sprintf("%s", r.data.RemitenteNombre)
+	fromText := fmt.Sprintf("%s", data.RemitenteNombre)
 	pdf.Cell(sectionWidth, 3*scale, fromText)
 	pdf.SetXY(5*scale, currentY+10*scale)
 
 	// Dirección del remitente (máximo 2 líneas)
-	fromAddr := strings.ReplaceAll(r.data.RemitenteDireccion, "\n", " ")
+	fromAddr := strings.ReplaceAll(data.RemitenteDireccion, "\n", " ")
 	if len(fromAddr) > 40 {
 		fromAddr = fromAddr[:40] + "..."
 	}
 	pdf.Cell(sectionWidth, 3*scale, fromAddr)
 	pdf.SetXY(5*scale, currentY+14*scale)
-	pdf.Cell(sectionWidth, 3*scale, "Tel: "+r.data.RemitenteTelefono)
+	pdf.Cell(sectionWidth, 3*scale, "Tel: "+data.RemitenteTelefono)
 
 	// TO (Destinatario)
-	toX := 5*scale + sectionWidth + 5*scale
+	toX, toY := 5*scale+sectionWidth+5*scale, currentY
+	if narrow {
+		toX, toY = 5*scale, currentY+22*scale
+	}
 	pdf.SetFont(fontFamily, "B", 10*scale)
-	pdf.SetXY(toX, currentY)
+	pdf.SetXY(toX, toY)
 	pdf.SetFillColor(240, 240, 240)
-	pdf.Rect(toX, currentY, sectionWidth, 4*scale, "F")
+	pdf.Rect(toX, toY, sectionWidth, 4*scale, "F")
 	pdf.Cell(sectionWidth, 4*scale, "TO / DESTINATARIO")
 
 	pdf.SetFont(fontFamily, "", 8*scale)
-	pdf.SetXY(toX, currentY+6*scale)
+	pdf.SetXY(toX, toY+6*scale)
 
 	// Texto del destinatario
-	toText := fmt.Sprintf("%s", r.data.DestinatarioNombre)
+	toText := fmt.Sprintf("%s", data.DestinatarioNombre)
 	pdf.Cell(sectionWidth, 3*scale, toText)
-	pdf.SetXY(toX, currentY+10*scale)
+	pdf.SetXY(toX, toY+10*scale)
 
 	// Dirección del destinatario (máximo 2 líneas)
-	toAddr := strings.ReplaceAll(r.data.DestinatarioDireccion, "\n", " ")
+	toAddr := strings.ReplaceAll(data.DestinatarioDireccion, "\n", " ")
 	if len(toAddr) > 40 {
 		toAddr = toAddr[:40] + "..."
 	}
 	pdf.Cell(sectionWidth, 3*scale, toAddr)
-	pdf.SetXY(toX, currentY+14*scale)
-	pdf.Cell(sectionWidth, 3*scale, "Tel: "+r.data.DestinatarioTelefono)
+	pdf.SetXY(toX, toY+14*scale)
+	pdf.Cell(sectionWidth, 3*scale, "Tel: "+data.DestinatarioTelefono)
+	if data.DestinatarioDNI != "" {
+		pdf.SetXY(toX, toY+18*scale)
+		pdf.Cell(sectionWidth, 3*scale, "DNI: "+data.DestinatarioDNI)
+	}
 
 	// Actualizar posición Y
-	currentY += 25 * scale
+	currentY = toY + 25*scale
 
 	// INFORMACIÓN DEL ENVÍO
 	pdf.SetFont(fontFamily, "B", 10*scale)
@@ -738,18 +1698,18 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 
 	// Detalles en líneas controladas
 	pdf.SetXY(5*scale, currentY)
-	pdf.Cell(width-10*scale, 3*scale, fmt.Sprintf("Fecha/Date: %s", r.data.FechaEnvio.Format("02/01/2006 15:04")))
+	pdf.Cell(width-10*scale, 3*scale, fmt.Sprintf("Fecha/Date: %s", data.FechaEnvio.Format("02/01/2006 15:04")))
 	currentY += 4 * scale
 
-	if r.data.Peso != "" {
+	if data.Peso != "" {
 		pdf.SetXY(5*scale, currentY)
-		pdf.Cell(width-10*scale, 3*scale, fmt.Sprintf("Peso/Weight: %s", r.data.Peso))
+		pdf.Cell(width-10*scale, 3*scale, fmt.Sprintf("Peso/Weight: %s", data.Peso))
 		currentY += 4 * scale
 	}
 
-	if r.data.Observaciones != "" {
+	if data.Observaciones != "" {
 		pdf.SetXY(5*scale, currentY)
-		obsText := r.data.Observaciones
+		obsText := data.Observaciones
 		if len(obsText) > 60 {
 			obsText = obsText[:60] + "..."
 		}
@@ -758,7 +1718,7 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	}
 
 	pdf.SetXY(5*scale, currentY)
-	pdf.Cell(width-10*scale, 3*scale, fmt.Sprintf("Servicio/Service: Express | Tamaño/Size: %s - %s", r.data.TamanoHoja, r.data.Orientacion))
+	pdf.Cell(width-10*scale, 3*scale, fmt.Sprintf("Servicio/Service: Express | Tamaño/Size: %s - %s", data.TamanoHoja, data.Orientacion))
 	currentY += 8 * scale
 
 	// CÓDIGO DE BARRAS
@@ -767,22 +1727,13 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	pdf.Cell(width-8*scale, 6*scale, "TRACKING NUMBER")
 	currentY += 8 * scale
 
-	// Código de barras simplificado con líneas
+	// Código de barras Code 128 con el número de guía, centrado y de hasta 100 mm de ancho
+	// para que las barras no queden tan anchas que el lector no las tome
 	pdf.SetFillColor(0, 0, 0) // Negro para las barras
-	barWidth := 1.0 * scale
 	barHeight := 12.0 * scale
-	barSpacing := 2.0 * scale
-
-	// Calcular número de barras que caben
-	availableWidth := width - 20*scale
-	numBars := int(availableWidth / barSpacing)
-
-	startX := 10 * scale
-	for i := 0; i < numBars; i++ {
-		// Patrón simple: barra cada 3 posiciones
-		if i%3 == 0 || i%7 == 0 {
-			pdf.Rect(startX+float64(i)*barSpacing, currentY, barWidth, barHeight, "F")
-		}
+	barcodeWidth := math.Min(width-20*scale, 100*scale)
+	if err := drawCode128(pdf, (width-barcodeWidth)/2, currentY, barcodeWidth, barHeight, data.NumeroGuia); err != nil {
+		return err
 	}
 
 	currentY += barHeight + 3*scale
@@ -790,7 +1741,7 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 	// Número debajo del código de barras
 	pdf.SetFont("Arial", "", 10*scale)
 	pdf.SetXY(5*scale, currentY)
-	pdf.Cell(width-10*scale, 4*scale, r.data.NumeroGuia)
+	pdf.CellFormat(width-10*scale, 4*scale, data.NumeroGuia, "", 0, "C", false, 0, "")
 	currentY += 8 * scale
 
 	// Calcular espacio restante
@@ -802,19 +1753,18 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 		qrX := width - qrSize - 5*scale
 		qrY := currentY
 
-		qrData := "https://www.comsitec.tech" + r.data.NumeroGuia
+		qrData := "https://www.comsitec.tech" + data.NumeroGuia
 		qrCode, err := qrcode.Encode(qrData, qrcode.Medium, 256)
 		if err == nil {
-			qrPath := "temp_qr.png"
-			err = ioutil.WriteFile(qrPath, qrCode, 0644)
-			if err == nil {
-				pdf.Image(qrPath, qrX, qrY, qrSize, qrSize, false, "", 0, "")
-				os.Remove(qrPath)
-
-				pdf.SetFont(fontFamily, "", 6*scale)
-				pdf.SetXY(qrX, qrY+qrSize+2*scale)
-				pdf.Cell(qrSize, 2*scale, "Escanea para tracking")
-			}
+			// Cada página lleva su QR: gofpdf guarda las imágenes por nombre, así que el
+			// nombre incluye la guía
+			qrName := "qr_" + data.NumeroGuia
+			pdf.RegisterImageOptionsReader(qrName, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrCode))
+			pdf.ImageOptions(qrName, qrX, qrY, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+			pdf.SetFont(fontFamily, "", 6*scale)
+			pdf.SetXY(qrX, qrY+qrSize+2*scale)
+			pdf.Cell(qrSize, 2*scale, "Escanea para tracking")
 		}
 	}
 
@@ -848,23 +1798,13 @@ func (r *RotuloGenerator) createProfessionalPDF() ([]byte, error) {
 		empresaData.Direccion,
 		time.Now().Format("02/01/2006 15:04")), "", "", false)
 
-	// Usar bytes.Buffer para capturar el output
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, fmt.Errorf("error generando PDF: %v", err)
-	}
-
-	return buf.Bytes(), nil
+	return nil
 }
 
 func (r *RotuloGenerator) updateLogoPreview(empresa string) {
-	logoPath := zettacomLogo
-	if empresa == "COMSITEC" {
-		logoPath = comsitecLogo
-	}
-
-	if _, err := os.Stat(logoPath); os.IsNotExist(err) {
+	logoPath := empresasData[empresa].Logo
+	if _, err := os.Stat(logoPath); logoPath == "" || os.IsNotExist(err) {
+		r.logoPreview.File = ""
 		r.logoPreview.Resource = nil
 		r.logoPreview.Refresh()
 		return
@@ -879,17 +1819,28 @@ func (r *RotuloGenerator) updatePreview() {
 		return
 	}
 
-	if r.data.NumeroGuia == "" {
+	// La guía se aparta al generar; mientras tanto se muestra la que tocará
+	guia, pdfGuia := r.data.NumeroGuia, r.data.NumeroGuia
+	if guia == "" {
+		guia, pdfGuia = "[se asigna al generar]", "000000"
 		if r.data.Empresa != "" {
-			r.data.NumeroGuia = fmt.Sprintf("%s%d", r.data.Empresa[:3], time.Now().Unix()%1000000)
-		} else {
-			r.data.NumeroGuia = fmt.Sprintf("GEN%d", time.Now().Unix()%1000000)
+			if next := peekGuia(r.data.Empresa); next != "" {
+				guia, pdfGuia = next+" (se asigna al generar)", next
+			}
 		}
 	}
+	r.schedulePDFPreview(pdfGuia)
 
 	empresaData := empresasData[r.data.Empresa]
 	showQR := empresaData.NeedQR
 
+	dniLine := ""
+	if r.data.DestinatarioDNI != "" {
+		dniLine = "\n🪪 DNI " + r.data.DestinatarioDNI
+	}
+	if r.rasterTool != "" {
+		return
+	}
 	preview := fmt.Sprintf(`# 🏷️ RÓTULO PROFESIONAL - %s
 
 ---
@@ -904,7 +1855,7 @@ func (r *RotuloGenerator) updatePreview() {
 ## 📥 TO / DESTINATARIO  
 **%s**
 %s
-📞 %s
+📞 %s%s
 
 ---
 
@@ -919,7 +1870,8 @@ func (r *RotuloGenerator) updatePreview() {
 		getValueOrDefault(r.data.DestinatarioNombre, "[Nombre del destinatario]"),
 		getValueOrDefault(r.data.DestinatarioDireccion, "[Dirección del destinatario]"),
 		getValueOrDefault(r.data.DestinatarioTelefono, "[Teléfono del destinatario]"),
-		r.data.NumeroGuia,
+		dniLine,
+		guia,
 		time.Now().Format("02/01/2006 15:04"),
 		r.data.TamanoHoja,
 		r.data.Orientacion,
@@ -933,9 +1885,13 @@ func (r *RotuloGenerator) updatePreview() {
 		preview += fmt.Sprintf("\n- **📝 Observaciones:** %s", r.data.Observaciones)
 	}
 
+	if layout := findSheetLayout(r.data.Distribucion); layout.perSheet() > 1 {
+		preview += fmt.Sprintf("\n- **✂️ Por hoja:** %s, en A4 vertical con guías de corte", layout.Name)
+	}
+
 	preview += "\n\n---\n\n## ✨ CARACTERÍSTICAS PROFESIONALES\n"
 	preview += "✅ Logo corporativo en header\n"
-	preview += "✅ Código de barras para tracking\n"
+	preview += "✅ Código de barras Code 128 con el número de guía\n"
 	preview += "✅ Diseño adaptado al tamaño seleccionado\n"
 	preview += "✅ Soporte para caracteres especiales (ñ, á, é, etc.)\n"
 	preview += "✅ Todo el contenido en una sola página\n"
@@ -945,6 +1901,7 @@ func (r *RotuloGenerator) updatePreview() {
 	}
 
 	preview += "\n---\n*Rótulo profesional generado automáticamente*"
+	preview += "\n\n💡 Para ver aquí el PDF tal como se imprime instala Poppler (pdftoppm), MuPDF o Ghostscript y vuelve a abrir el programa."
 
 	r.preview.ParseMarkdown(preview)
 }
@@ -956,59 +1913,6 @@ func getValueOrDefault(value, defaultValue string) string {
 	return value
 }
 
-func (r *RotuloGenerator) printRotulo(window fyne.Window) {
-	if r.data.RemitenteNombre == "" || r.data.DestinatarioNombre == "" {
-		dialog.ShowError(fmt.Errorf("debes completar al menos el nombre del remitente y destinatario"), window)
-		return
-	}
-
-	printerOptions := []string{"HP LaserJet Pro", "Epson L3150", "Brother DCP-T510W", "Canon PIXMA", "Impresora predeterminada"}
-
-	printerSelect := widget.NewSelect(printerOptions, nil)
-	printerSelect.SetSelected("Impresora predeterminada")
-
-	colorCheck := widget.NewCheck("Imprimir en color", nil)
-	colorCheck.SetChecked(true)
-	qualityCheck := widget.NewCheck("Alta calidad", nil)
-	qualityCheck.SetChecked(true)
-
-	content := container.NewVBox(
-		widget.NewLabel("Selecciona la impresora:"),
-		printerSelect,
-		widget.NewSeparator(),
-		widget.NewLabel("Configuración:"),
-		colorCheck,
-		qualityCheck,
-		widget.NewSeparator(),
-		widget.NewLabel(fmt.Sprintf("📄 Tamaño: %s - %s", r.data.TamanoHoja, r.data.Orientacion)),
-		widget.NewLabel("🎨 Se recomienda impresión en color para mejor resultado"),
-	)
-
-	printerDialog := dialog.NewCustomConfirm("Imprimir Rótulo", "Imprimir", "Cancelar", content,
-		func(confirmed bool) {
-			if confirmed {
-				selectedPrinter := printerSelect.Selected
-				dialog.ShowInformation("✅ Impresión Enviada",
-					fmt.Sprintf("Rótulo profesional enviado a: %s\n\n"+
-						"🏢 Empresa: %s\n"+
-						"📦 Tracking: %s\n"+
-						"📏 Tamaño: %s - %s\n"+
-						"🎨 Color: %v\n"+
-						"⭐ Alta calidad: %v\n\n"+
-						"El rótulo incluye logo, código de barras y diseño profesional.",
-						selectedPrinter,
-						r.data.Empresa,
-						r.data.NumeroGuia,
-						r.data.TamanoHoja,
-						r.data.Orientacion,
-						colorCheck.Checked,
-						qualityCheck.Checked), window)
-			}
-		}, window)
-
-	printerDialog.Show()
-}
-
 func (r *RotuloGenerator) clearFields() {
 	for _, entry := range r.inputs {
 		entry.SetText("")
@@ -1018,22 +1922,29 @@ func (r *RotuloGenerator) clearFields() {
 		Orientacion: "Vertical",
 		FechaEnvio:  time.Now(),
 	}
-	r.empresaCheck.SetSelected("ZETTACOM")
-	r.data.Empresa = "ZETTACOM"
+	empresa := defaultEmpresa()
+	r.empresaCheck.SetSelected(empresa)
+	r.data.Empresa = empresa
 	r.tamanoHoja.SetSelected("A4")
 	r.orientacion.SetSelected("Vertical")
-	r.updateLogoPreview("ZETTACOM")
+	r.distribucion.SetSelected(sheetLayouts[0].Name)
+	r.updateLogoPreview(empresa)
 	r.updatePreview()
 }
 
 func (r *RotuloGenerator) fillTestData() {
-	r.empresaCheck.SetSelected("COMSITEC")
-	r.data.Empresa = "COMSITEC"
-	r.updateLogoPreview("COMSITEC")
+	empresa := "COMSITEC"
+	if _, ok := empresasData[empresa]; !ok {
+		empresa = defaultEmpresa()
+	}
+	r.empresaCheck.SetSelected(empresa)
+	r.data.Empresa = empresa
+	r.updateLogoPreview(empresa)
 
 	r.inputs["destinatarioNombre"].SetText("María González López")
 	r.inputs["destinatarioDireccion"].SetText("Jr. Los Olivos 456\nMiraflores, Lima 15074\nPerú")
 	r.inputs["destinatarioTelefono"].SetText("+51 888 777 666")
+	r.inputs["destinatarioDNI"].SetText("45678912")
 	r.inputs["peso"].SetText("2.5 kg")
 	r.inputs["observaciones"].SetText("FRÁGIL - Manejar con cuidado")
 	r.inputs["numeroGuia"].SetText("COM123456")
@@ -1043,36 +1954,62 @@ func (r *RotuloGenerator) fillTestData() {
 
 // Funciones del notepad (mantenidas igual)...
 
-func (n *NotePad) createPersonalTab(window fyne.Window) *fyne.Container {
-	n.multiLine = widget.NewMultiLineEntry()
+func (n *NotePad) createPersonalTab(window fyne.Window) fyne.CanvasObject {
+	n.window = window
+	n.multiLine = newNoteEntry()
 	n.multiLine.Wrapping = fyne.TextWrapOff
 	n.multiLine.Resize(fyne.NewSize(600, 300))
 
 	n.multiLine.OnChanged = func(content string) {
+		before := n.lastContent
 		n.lastContent = content
 		n.lastSaveTime = time.Now()
-		n.lastUserEdit = time.Now()
+		n.trackEdit(before)
+		n.markDirty()
+		n.updatePreview()
+		n.updateCounts()
+		n.updateGutter()
+		n.updateTable()
+		n.updateFilter()
+		n.updateFolds()
 		if n.statusLabel != nil {
 			n.statusLabel.SetText("Estado: Modificado (guardado automático)")
 		}
+		n.scheduleRecovery()
+		n.formatTypedLine(before, content)
 	}
 
+	notesDir = loadNotesLocation()
+	n.keepVersions = loadVersionSettings().Conservar
+	n.archive = loadSnapshotSettings()
+	n.autoSave.set(loadSaveSettings())
+	n.daily = loadDailySettings().Activo
+	n.formatRule = loadFormatRule()
+	n.clock = loadClockSettings()
+	n.recent = loadRecent()
+	if err := n.setTimePatterns(loadTimePatterns()); err != nil {
+		log.Printf("Error en los patrones de actualización: %v", err)
+	}
+	n.loadNotes()
 	n.loadContent()
 
-	scroll := container.NewScroll(n.multiLine)
+	scroll := n.createEditorScroll()
 	scroll.SetMinSize(fyne.NewSize(600, 300))
 
 	n.statusLabel = widget.NewLabel("Estado: Listo")
 	timeLabel := widget.NewLabel(fmt.Sprintf("Última actualización: %s", time.Now().Format("15:04:05")))
+	n.countLabel = widget.NewLabel("")
+	n.updateCounts()
 
-	saveButton := widget.NewButton("💾 Guardar Ahora", func() {
+	saveNow := func() {
 		n.saveContent()
 		n.statusLabel.SetText("Estado: Guardado manualmente")
 		go func() {
 			time.Sleep(2 * time.Second)
 			n.statusLabel.SetText("Estado: Listo")
 		}()
-	})
+	}
+	saveButton := widget.NewButton("💾 Guardar Ahora", saveNow)
 
 	reloadButton := widget.NewButton("🔄 Recargar", func() {
 		n.loadContent()
@@ -1083,26 +2020,164 @@ func (n *NotePad) createPersonalTab(window fyne.Window) *fyne.Container {
 		}()
 	})
 
-	clearButton := widget.NewButton("🗑️ Limpiar", func() {
-		dialog.ShowConfirm("Confirmar", "¿Estás seguro de que quieres limpiar todo el contenido?", func(confirmed bool) {
-			if confirmed {
-				n.multiLine.SetText("")
-				n.statusLabel.SetText("Estado: Contenido limpiado")
-			}
-		}, window)
+	versionsButton := widget.NewButton("🕘 Versiones", func() {
+		n.saveContent()
+		n.showVersions(window)
+	})
+	snapshotsButton := widget.NewButton("🗂️ Historial", func() {
+		n.saveContent()
+		n.showSnapshots(window)
 	})
 
+	sendButton := widget.NewButton("📤 Enviar al Autocopiador", func() {
+		n.sendToAutocopiador()
+	})
+
+	mailButton := widget.NewButton("✉️ Enviar por correo", func() {
+		n.showSendMail(n.window)
+	})
+	printButton := widget.NewButton("🖨️ Imprimir", func() {
+		n.showPrint(n.window)
+	})
+	labelButton := widget.NewButton("🏷️ Enviar al Rótulo", func() {
+		n.sendToRotulo()
+	})
+
+	clearButton, undoClearButton := n.createClearButtons(window)
+
 	autoUpdateInfo := widget.NewRichTextFromMarkdown(`
 **Actualización Automática de Hora:**
 
 La hora se actualiza automáticamente cada segundo en el texto.
 - Detecta patrones como "11:24", "17:11", etc.
-- Solo actualiza si no has editado recientemente (2 segundos de pausa)
+- Para congelar una hora (por ejemplo la hora real de salida de un envío) empieza la línea con ! o escríbela entre acentos graves
+- Las fechas como 27/05/2025 también se actualizan al día de hoy, y los marcadores {FECHA} y {HORA} se cambian por la fecha y la hora actuales (útil para encabezados diarios)
+- Con ⚙️ Patrones eliges qué se actualiza: la hora, la fecha o expresiones propias, cada una con su formato (por ejemplo HH:mm o dd/MM/yyyy) y activable por separado
+- En la columna Secciones de ⚙️ Patrones puedes limitar un patrón a ciertas secciones: con REPOSICI la hora solo se actualiza bajo ***LISTA REPOSICIÓN*** y las horas de ZETTACOM quedan como están. Vacía vale para toda la nota
+- Solo actualiza si no has editado recientemente (2 segundos de pausa, se cambia en 💾 Guardado)
+- En la tarjeta de estado, "⏱️ Hora automática" pausa y reanuda la actualización, y la lista de al lado elige cada cuánto se hace: cada 1, 10 o 60 segundos. Si el editor se traba en un equipo lento usa 60 segundos (las horas HH:mm igual cambian al empezar cada minuto). Cada equipo lo recuerda en hora_notas.json
 - Preserva la posición del cursor
 - No interfiere con tu escritura
 
 **Ejemplo:**
 Si escribes "REPOSICION 15:30 JRIOS", la hora se actualizará automáticamente a la hora actual.
+
+**Notas:**
+Cada nota es un archivo propio dentro de la carpeta "notas". Usa ➕ para crear una, ✏️ para renombrar la abierta y 🗑️ para eliminarla. Al cambiar de nota la anterior se guarda sola. El contenido del antiguo bloc_notas.txt pasa a la nota "General".
+
+**Cambios sin guardar:**
+Un segundo después de cada ráfaga de teclas lo que hay en el editor se copia a notas.recovery, junto al programa, aunque la nota esté vacía o el guardado automático desactivado. Si el programa se cierra mal (un corte de luz o un cierre forzado), al volver a abrirlo muestra la diferencia con el archivo y ofrece 🛟 restaurar esos cambios; Ctrl+Z vuelve a lo que había en el archivo. Al cerrar el programa normalmente la copia se borra. Con el bloc cifrado la copia también va cifrada y se revisa después de escribir la contraseña.
+
+**Abrir y guardar como:**
+📂 Abrir edita cualquier archivo .txt en esta pestaña y 📄 Guardar como guarda el contenido en otro archivo, que pasa a ser el abierto. Mientras tanto el guardado automático escribe en ese archivo, tal cual y sin la línea "# Guardado: ..." ni cifrado, para que los demás programas lo sigan leyendo. Esos archivos no guardan versiones; elige una nota de la lista para volver a las notas de la carpeta. También puedes arrastrar un .txt desde el explorador y soltarlo en la ventana con esta pestaña a la vista; si es una nota de la carpeta se abre como nota.
+
+**Importar notas viejas:**
+📥 Importar copia a la carpeta de notas todos los .txt de la carpeta que elijas, por ejemplo los registros de turno de años anteriores, y cada uno pasa a ser una nota con el nombre del archivo (con (2), (3)... si ya hay una con ese nombre). Los archivos guardados en ANSI (Latin-1) o Unicode por el Bloc de notas de Windows se pasan a UTF-8, así las tildes y las ñ se ven bien y se encuentran con Ctrl+F y 🔽 Filtrar. Los archivos originales no se tocan, las notas importadas conservan su fecha de modificación y con el bloc cifrado se guardan cifradas. Los archivos con nombre de fecha (2024-03-15.txt) aparecen en "📅 Día...".
+
+**Recientes:**
+La lista "🕑 Recientes..." sobre las notas guarda las últimas 10 notas y archivos abiertos, el último primero, para pasar rápido de la lista de reposición a la nota de entrega de turno o a tus notas personales. Las notas aparecen por su nombre, los días del archivo diario por su fecha y los archivos abiertos con 📂 Abrir con su carpeta. Si uno ya no existe se quita de la lista. Cada equipo recuerda sus recientes en recientes_notas.json.
+
+**Archivo diario:**
+Marca "Archivo diario" en la barra de notas para escribir cada día en una nota nueva (notas/2025-05-27.txt) en lugar de un archivo que crece sin fin. Cada día empieza con la plantilla de siempre y después de medianoche se pasa solo a la nota del día nuevo. Los días anteriores se eligen en "📅 Día..."; las notas con nombre siguen en la lista de siempre.
+
+**Guardado automático:**
+La nota se guarda sola cada 5 segundos después de 2 segundos sin escribir. Con 💾 Guardado puedes cambiar ambos tiempos o desactivarlo, por ejemplo si la carpeta está en una unidad de red y se nota lento; en ese caso guarda con "💾 Guardar Ahora" (al cerrar la ventana también se guarda).
+
+**Conteos:**
+La tarjeta de estado muestra mientras escribes cuántas líneas, palabras y caracteres tiene la nota, y cuántas líneas de reposición (las que dicen REPOSICIÓN, sin contar títulos ni comentarios con #).
+
+**Carpeta compartida:**
+Con 📁 Carpeta eliges dónde se guardan las notas, por ejemplo una unidad de red, para que todo el equipo trabaje sobre la misma lista de reposición. Los cambios que guarde otro equipo se cargan solos mientras no estés escribiendo; si los dos modificaron la nota a la vez, antes de guardar se muestran las diferencias y eliges entre 🔀 Combinar (junta las líneas de las dos), 💾 Guardar la mía o 🔄 Cargar la suya. La versión que no queda se guarda en 🕘 Versiones, y mientras no decidas el guardado automático no escribe la nota. Cada equipo recuerda su carpeta en ubicacion_notas.json.
+
+**Cifrado:**
+Con 🔒 Cifrado las notas y sus versiones se guardan cifradas (AES-GCM) con una contraseña, útil si guardas claves o datos personales en un equipo compartido. Al abrir el programa se pide la contraseña y, hasta escribirla, el editor queda bloqueado. Si olvidas la contraseña no hay forma de recuperar las notas. El mismo botón quita el cifrado.
+
+**Versiones anteriores:**
+Al guardar se conserva una copia con fecha de la nota cada vez que cambia (como mucho una por minuto) en la carpeta "notas/.versiones". 🕘 Versiones muestra la lista: al elegir una se ve en rojo lo que tenía y ya no está y en verde lo que se agregó después. ↩️ Restaurar la devuelve al editor guardando antes el contenido actual como otra versión. Por defecto se conservan las últimas 50 de cada nota.
+
+**Historial del turno:**
+Además de las versiones, al guardar se archiva una copia de la nota por hora en la carpeta "historial" de la carpeta de notas (o una por guardado, si lo eliges), y se conservan 30 días. 🗂️ Historial muestra la línea de tiempo con las líneas agregadas y quitadas en cada copia respecto de la anterior. Al elegir una se ve qué cambió desde la copia anterior; con Desde y Hasta comparas dos momentos cualesquiera o uno con lo que hay ahora en el editor. Al abrirlo se ve lo que cambió desde la primera copia del día.
+
+**Insertar la hora:**
+Ctrl+T (o 🕐 Hora) escribe la hora actual (HH:MM) donde está el cursor y Ctrl+Shift+T (o 📅 Fecha y hora) la fecha y la hora. Lo insertado se sigue actualizando solo como el resto de las horas; empieza la línea con ! si quieres conservarla.
+
+**Vista previa:**
+Marca "👁️ Vista previa" para ver la nota formateada al lado del editor mientras escribes. Las líneas que empiezan con # se muestran como títulos, los títulos entre asteriscos (la línea de LISTA REPOSICIÓN) como encabezados y las líneas de solo asteriscos o guiones como separadores. Con "🎨 Resaltar" marcado (lo normal) la vista previa muestra las líneas tal cual pero con las horas y fechas en azul, los códigos como ......0154 en verde, los usuarios como JRIOS en naranja y los comentarios en gris, para revisar la lista de un vistazo; desmárcalo para ver el formato de títulos. El editor en sí no admite colores, por eso el resaltado va en la vista previa.
+
+**Deshacer y rehacer:**
+Ctrl+Z deshace y Ctrl+Y rehace, también con los botones del historial de cambios. Lo que escribes sin pausas de más de 2 segundos se deshace de una vez, y las actualizaciones automáticas de hora seguidas forman su propio paso, así que nunca se mezclan con tu escritura. Elegir un paso de la lista deshace hasta dejar el texto como estaba antes de ese cambio.
+
+**Buscar y reemplazar:**
+Ctrl+F abre la barra de búsqueda (si hay texto seleccionado se usa como búsqueda). Enter o ▶ pasa a la siguiente coincidencia y ◀ a la anterior; la coincidencia queda seleccionada en el editor. Marca "Regex" para usar expresiones regulares y "Aa" para distinguir mayúsculas. ⇄ muestra el reemplazo: con regex puedes usar $1, $2... para los grupos capturados.
+
+**Limpiar:**
+🗑️ Limpiar vacía la nota después de confirmar, pero lo que había no se pierde: queda en la papelera de la sesión y ♻️ Deshacer limpieza lo devuelve, aunque hayas cambiado de nota mientras tanto o el historial de cambios ya no llegue. Si escribiste algo después de limpiar, queda debajo de lo recuperado. Cada limpieza se deshace por separado, la última primero. Lo limpiado de las notas de la carpeta también queda en 🗂️ Historial, así que se puede recuperar después de cerrar el programa.
+
+**Atajos de teclado:**
+Ctrl+S guarda la nota como 💾 Guardar Ahora, Ctrl+F abre la búsqueda, Ctrl+L limpia el contenido después de confirmar (igual que 🗑️ Limpiar) y Ctrl+D duplica la línea del cursor, o las líneas seleccionadas, justo debajo, con el cursor en la copia. En macOS funcionan también con Cmd. Solo actúan con esta pestaña a la vista. Ctrl+Z deshace la línea duplicada o el contenido limpiado.
+
+**Letra del editor:**
+Con 🔤 Letra cambias el tamaño de la letra del editor y puedes usar letra monoespaciada para que las columnas de las listas queden alineadas. Se ve el cambio mientras lo ajustas; cada equipo guarda su elección en fuente_notas.json.
+
+**Ordenar sección:**
+En "↕️ Ordenar sección" elige ordenar por código, por técnico, por quién registró o alfabéticamente. Se ordenan las líneas seleccionadas o, si no hay selección, las que están bajo el título entre asteriscos donde está el cursor. Los títulos, comentarios y líneas vacías no se mueven y cada sección se ordena por separado. Ctrl+Z lo deshace.
+
+**Plegar secciones:**
+En "📁 Plegar sección" aparecen los títulos entre asteriscos de la nota: elige uno para ocultar las líneas que tiene debajo, por ejemplo ZETTACOM mientras trabajas en la lista de reposición. En su lugar queda una línea "⋯ [plegado N] 12 líneas"; elige otra vez el título (marcado con ▶) o "Desplegar todas" para verlas. La nota se guarda siempre completa. Las horas de una sección plegada no se actualizan hasta desplegarla y al cambiar de nota todo vuelve a verse. No borres la línea del pliegue: lo oculto se perdería al guardar (Ctrl+Z la devuelve).
+
+**Editar en columna:**
+"▥ Columna" cambia lo mismo en varias líneas de una vez, por ejemplo el usuario al final de veinte líneas de reposición. Selecciona las líneas (o deja el cursor en la sección) y elige "Último campo" para reemplazar la última palabra de cada línea por el texto nuevo. Con "Columnas" se reemplaza un bloque: si seleccionaste desde la columna donde empieza en la primera línea hasta donde termina en la última, el bloque ya viene con esas columnas; con 0 caracteres se inserta el texto en esa columna en todas las líneas. Abajo se ve cómo quedan antes de aplicar. Los títulos, comentarios y líneas vacías no se tocan y Ctrl+Z lo deshace de una vez.
+
+**Recordatorios:**
+Escribe "@recordar 16:30" en una línea y a esa hora aparece una notificación del sistema con el texto de la línea, por ejemplo "Pasar a recoger el equipo de ZETTACOM @recordar 16:30". Esa hora no se actualiza sola. También avisan las líneas congeladas con ! que tengan una hora que todavía no llegó. La tarjeta de estado muestra el próximo recordatorio; solo se revisa la nota abierta y el programa tiene que estar abierto.
+
+**Casillas de hecho:**
+Ctrl+Enter (o ☑️ Marcar) pone una casilla [ ] al principio de la línea del cursor o de las líneas seleccionadas, y al repetirlo la marca como hecha [x] o la vuelve a desmarcar. Así puedes ir tachando cada reposición a medida que se completa; la tarjeta de estado muestra cuántas van hechas. Las líneas con casilla siguen apareciendo en la vista de tabla.
+
+**Códigos repetidos:**
+Si un código aparece más de una vez en la misma sección (una reposición registrada dos veces) la tarjeta de estado muestra un aviso ⚠️. 🔁 Repetidos lista esas líneas: elige una para ir a ella o pulsa 🧹 Quitar repetidos para dejar solo la primera de cada código. El código 9999 de la plantilla de LISTA REPOSICIÓN no se cuenta.
+
+**Ortografía:**
+Copia un diccionario de hunspell en español (es_ES.dic y es_ES.aff, los mismos de LibreOffice) en la carpeta "diccionario" junto al programa. Con "🎨 Resaltar" marcado la vista previa subraya en rojo las palabras mal escritas, ya que el editor no admite subrayados. 📖 Ortografía lista esas palabras: elige una para ir a su línea, escoge una sugerencia y pulsa ✔️ Reemplazar, o ➕ Agregar al diccionario para apellidos y nombres de clientes (se guardan en diccionario_personal.txt). No se revisan las palabras en mayúsculas (usuarios como JRIOS), los códigos ni los títulos entre asteriscos. Conviene revisar las observaciones antes de copiarlas a un rótulo.
+
+**Carga por usuario:**
+La tarjeta 👥 Carga por usuario muestra cuántas líneas de reposición registró cada usuario (JRIOS, MGAVINO...) en la nota abierta, con una barra para comparar de un vistazo. Con el archivo diario activo también resume los últimos 7 días, con el total de cada día y el reparto por usuario. Las líneas de la plantilla con el código 9999 no cuentan.
+
+**Vista de tabla:**
+Marca "📋 Tabla" para ver las líneas de reposición (como "......0154 LGARCIA 15:04 MGAVINO") separadas en columnas: sección, código, técnico, hora y quién la registró. Al editar una celda se cambia la línea en el texto de la nota, que sigue siendo lo que se guarda; las columnas no pueden quedar vacías ni llevar espacios. Los títulos y comentarios no aparecen en la tabla pero se conservan.
+
+**Modo oscuro:**
+Marca "🌙 Modo oscuro" para ver esta pestaña con fondo oscuro en los turnos de noche sin cambiar el resto del programa. Cada equipo recuerda la elección en tema_notas.json.
+
+**Filtrar líneas:**
+Escribe en "🔽 Filtrar" (por ejemplo JRIOS) para ver solo las líneas que lo contienen, con su número de línea; elige una para volver al editor en esa línea. ✖ Quitar filtro muestra de nuevo la nota completa. Filtrar no cambia ni guarda nada.
+
+**Números de línea:**
+El margen izquierdo del editor numera las líneas, así por teléfono basta decir "revisa la línea 37". Ctrl+G (o ↪️ Ir a línea) pide un número y lleva el cursor al principio de esa línea.
+
+**Formato de líneas:**
+Escribe solo el código y el técnico, por ejemplo "154 LGARCIA", y al pulsar Enter la línea se completa como "......0154 LGARCIA 15:04 JRIOS": puntos, código con ceros a la izquierda, técnico en mayúsculas, la hora actual y quién registra. Si escribes también quién registra ("154 LGARCIA MGAVINO") se usa ese. En 🪄 Formato eliges cuántos puntos y dígitos lleva el código y tu usuario (por defecto el de Windows), o desactivas el formato; cada equipo lo recuerda en formato_notas.json. Ctrl+Z deshace el formato y deja la línea como la escribiste.
+
+**Continuar la lista:**
+Al pulsar Enter al final de una línea de código como "......0154 LGARCIA 15:04 JRIOS" la línea siguiente empieza sola con "......0155 ", como una lista numerada, y basta escribir el técnico. En 🪄 Formato puedes elegir "Código en blanco" para que empiece solo con los puntos y escribir el código, o no continuar la lista. Si pulsas Enter otra vez sin escribir nada en esa línea se borra y la lista termina. Ctrl+Z quita la línea agregada.
+
+**Capturar portapapeles:**
+Marca "📎 Capturar portapapeles" y todo lo que copies, en este programa o en cualquier otro sistema, se agrega con la fecha y hora a la sección CAPTURAS de la nota abierta (se crea al final si no existe). Sirve para juntar números de serie de varios sistemas sin pegar uno por uno; si copias varias líneas se agrega cada una. La hora de la captura queda entre comillas invertidas para que no se actualice. Lo que ya estaba copiado al marcar la casilla no se agrega y al volver a abrir el programa la captura empieza apagada.
+
+**Enviar al Autocopiador:**
+Selecciona una o varias líneas (o deja el cursor en una) y pulsa "Enviar al Autocopiador": los códigos como 0154 o ZET00154 se agregan a la lista de series. Las horas y fechas se ignoran.
+
+**Plantillas de empresa:**
+"🏢 Plantilla de empresa" agrega sobre la línea del cursor el bloque de ZETTACOM o COMSITEC con los datos de la empresa: el título entre asteriscos, razón social, dirección y teléfono, la fecha del turno (entre acentos graves para que no cambie), el responsable (el usuario de 🪄 Formato), una línea 9999 de ejemplo y los campos Pendientes y Observaciones. Al crear una nota con ➕ también puedes elegir una plantilla para empezarla, así todas las notas de turno quedan iguales. Ctrl+Z quita la plantilla agregada.
+
+**Enviar al Rótulo:**
+Selecciona el bloque con los datos de un envío que pegaste en la nota y pulsa "🏷️ Enviar al Rótulo": se llenan el nombre, la dirección y el teléfono del destinatario en la pestaña Rótulo Profesional. Las líneas pueden llevar etiqueta ("Dirección: Av. Arequipa 123", "Tel: 987 654 321"); sin etiqueta la primera línea es el nombre, la que solo tiene números el teléfono y el resto la dirección. Los datos del destinatario anterior se reemplazan.
+
+**Enviar por correo:**
+"✉️ Enviar por correo" manda la nota abierta completa a la lista de distribución, con las secciones plegadas incluidas y, si se marca, también adjunta en PDF. La primera vez pide la cuenta de correo: servidor SMTP, puerto (587 con STARTTLS o 465 con TLS), usuario, clave, remitente, destinatarios separados por comas o punto y coma y el asunto, donde {nota} y {fecha} se cambian por el nombre de la nota y el día. Se guarda en correo_notas.json junto al programa, así que cada equipo configura la suya. La clave no se guarda: se pide en el primer envío de cada sesión. Antes de enviar se pueden cambiar los destinatarios y el asunto de ese envío.
+
+**Imprimir:**
+"🖨️ Imprimir" muestra cómo quedará la hoja y la manda a la impresora que elijas de las instaladas en el equipo; la primera vez aparece la predeterminada y luego la última usada. La hoja lleva arriba el nombre de la nota, la fecha y quién la imprimió, y debajo la nota completa en letra monoespaciada para que las columnas queden alineadas. Los comentarios con # no se imprimen. En Windows imprime PowerShell y en Linux y macOS CUPS (lp).
 `)
 	autoUpdateInfo.Wrapping = fyne.TextWrapWord
 
@@ -1111,97 +2186,185 @@ Si escribes "REPOSICION 15:30 JRIOS", la hora se actualizará automáticamente a
 
 	go n.startTimeUpdates(timeLabel)
 	go n.startAutoSave()
+	go n.startReminders()
+	go n.startDailyRollover()
+	go n.startSpellCheck()
+
+	sidebar := n.createNotesSidebar(window)
+	n.selectNoteInList()
+	n.initEncryption(window)
+	if !n.locked {
+		n.checkRecovery(window)
+	}
+	n.rememberRecent()
+	find := n.createFindBar(window)
+	n.createShortcuts(window, saveNow, func() { n.clearContent(window) })
+	previewPane, previewToggle := n.createPreviewPane()
+	timeButton, stampButton := n.createTimestampButtons()
+	openButton, saveAsButton := n.createFileButtons(window)
+	goToLineButton := n.createGoToLine(window)
+	columnButton := widget.NewButton("▥ Columna", func() {
+		n.showColumnEdit(window)
+	})
+	themeToggle := n.createThemeToggle()
+	editorPane, tableToggle := n.createTablePane(scroll)
+	filterBar, filteredPane := n.createFilterBar(editorPane, window)
+	sortSelect := n.createSortSelect()
+	foldSelect := n.createFoldSelect()
+	templateSelect := n.createTemplateSelect()
+	checkButton := n.createCheckButton()
+	captureToggle := n.createCaptureToggle()
+	duplicatesButton := widget.NewButton("🔁 Repetidos", func() {
+		n.showDuplicates(window)
+	})
+	spellButton := widget.NewButton("📖 Ortografía", func() {
+		n.showSpelling(window)
+	})
 
 	editorCard := widget.NewCard("📝 Editor de Texto", "",
 		container.NewVBox(
-			container.NewHBox(saveButton, reloadButton, clearButton),
-			scroll,
+			container.NewHBox(saveButton, saveAsButton, openButton, reloadButton, versionsButton, snapshotsButton, clearButton, undoClearButton, sendButton, mailButton, printButton, labelButton, timeButton, stampButton, goToLineButton, columnButton, checkButton, sortSelect, foldSelect, templateSelect, duplicatesButton, spellButton, previewToggle, tableToggle, themeToggle, captureToggle),
+			find.box,
+			filterBar,
+			container.NewBorder(nil, nil, sidebar, previewPane, filteredPane),
 		),
 	)
 
 	infoCard := widget.NewCard("ℹ️ Actualización Automática", "", infoScroll)
 
+	patternsButton := widget.NewButton("⚙️ Patrones", func() {
+		n.showTimePatterns(window)
+	})
+
+	saveSettingsButton := widget.NewButton("💾 Guardado", func() {
+		n.showSaveSettings(window)
+	})
+
+	encryptionButton := widget.NewButton("🔒 Cifrado", func() {
+		n.showEncryption(window)
+	})
+
+	locationButton := widget.NewButton("📁 Carpeta", func() {
+		n.showNotesLocation(window)
+	})
+
+	importButton := widget.NewButton("📥 Importar", func() {
+		n.showImport(window)
+	})
+
+	formatButton := widget.NewButton("🪄 Formato", func() {
+		n.showFormatRule(window)
+	})
+
+	fontButton := widget.NewButton("🔤 Letra", func() {
+		n.showFontSettings(window)
+	})
+
 	statusCard := widget.NewCard("📊 Estado", "",
-		container.NewVBox(n.statusLabel, timeLabel),
+		container.NewVBox(n.statusLabel, n.countLabel, container.NewBorder(nil, nil, n.createClockControls(), container.NewHBox(patternsButton, saveSettingsButton, encryptionButton, locationButton, importButton, fontButton, formatButton), timeLabel)),
 	)
 
-	return container.NewVBox(
+	historyCard := n.createHistoryCard()
+	userStatsCard := n.createUserStatsCard()
+
+	return n.wrapTabTheme(container.NewVBox(
 		widget.NewLabel("Bloc de notas con fecha actualizada"),
 		container.NewHBox(
-			container.NewVBox(editorCard, statusCard),
+			container.NewVBox(editorCard, statusCard, historyCard, userStatsCard),
 			infoCard,
 		),
-	)
+	))
 }
 
+// startTimeUpdates revisa cada segundo si toca actualizar las horas según n.clock; en
+// pausa o entre una actualización y otra no se toca el editor
 func (n *NotePad) startTimeUpdates(timeLabel *widget.Label) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
 	for range ticker.C {
 		now := time.Now()
-		currentTime := now.Format("15:04")
-		content := n.multiLine.Text
-
-		timeLabel.SetText(fmt.Sprintf("Última actualización: %s", now.Format("15:04:05")))
-
-		if time.Since(n.lastUserEdit) < 2*time.Second {
-			continue
-		}
-
-		timeRegex := regexp.MustCompile(`\b\d{1,2}:\d{2}\b`)
-
-		if timeRegex.MatchString(content) {
-			newContent := timeRegex.ReplaceAllString(content, currentTime)
+		fyne.Do(func() {
+			if !n.clock.Activo {
+				timeLabel.SetText("⏸️ Hora automática en pausa")
+				return
+			}
+			if !clockDue(n.lastClockRun, now, time.Duration(n.clock.Cada)*time.Second) {
+				return
+			}
+			// Si se está escribiendo se vuelve a intentar en el siguiente segundo, sin esperar
+			// otro período completo
+			if time.Since(n.lastUserEdit) < n.autoSave.get().pause() {
+				return
+			}
+			n.lastClockRun = now
+			timeLabel.SetText(fmt.Sprintf("Última actualización: %s", now.Format("15:04:05")))
 
-			if newContent != content {
-				cursorRow := n.multiLine.CursorRow
-				cursorCol := n.multiLine.CursorColumn
+			content := n.multiLine.Text
+			newContent := applyTimePatterns(content, n.autoPatterns, now)
+			if newContent == content {
+				return
+			}
 
-				n.multiLine.SetText(newContent)
+			cursorRow := n.multiLine.CursorRow
+			cursorCol := n.multiLine.CursorColumn
 
-				n.multiLine.CursorRow = cursorRow
-				n.multiLine.CursorColumn = cursorCol
+			n.applyEdit(historyAuto, newContent)
 
-				n.lastContent = newContent
-			}
-		}
+			n.multiLine.CursorRow = cursorRow
+			n.multiLine.CursorColumn = cursorCol
+		})
 	}
 }
 
 func (n *NotePad) startAutoSave() {
-	ticker := time.NewTicker(autoSaveInterval)
-	defer ticker.Stop()
+	// El intervalo se lee en cada vuelta para aplicar los cambios de 💾 Guardado sin reiniciar
+	for {
+		settings := n.autoSave.get()
+		time.Sleep(settings.interval())
 
-	for range ticker.C {
-		if time.Since(n.lastSaveTime) >= 2*time.Second && n.lastContent != "" {
+		n.syncRemoteChanges()
+		if settings.Activo && n.isDirty() && !n.hasConflict() && time.Since(n.lastSaveTime) >= settings.pause() && n.lastContent != "" {
 			n.saveContent()
 		}
 	}
 }
 
 func (n *NotePad) saveContent() {
-	content := n.multiLine.Text
-	if content == "" {
+	n.saveMu.Lock()
+	defer n.saveMu.Unlock()
+
+	content := unfoldSections(n.multiLine.Text, n.folds)
+	if content == "" || n.current == "" || n.locked {
 		return
 	}
 
-	dir := filepath.Dir(saveFile)
-	if dir != "." {
-		os.MkdirAll(dir, 0755)
-	}
+	os.MkdirAll(notesDir, 0755)
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	contentWithTimestamp := fmt.Sprintf("# Guardado: %s\n%s", timestamp, content)
+	contentWithTimestamp := fmt.Sprintf("%s %s\n%s", saveHeaderPrefix, timestamp, content)
+	if n.external {
+		contentWithTimestamp = content
+	}
 
-	err := ioutil.WriteFile(saveFile, []byte(contentWithTimestamp), 0644)
+	path, key := n.currentFile()
+	err := writeNoteFile(path, []byte(contentWithTimestamp), key)
 	if err != nil {
 		log.Printf("Error guardando archivo: %v", err)
+		return
+	}
+	n.markWritten(path)
+	n.snapshotVersion(content, false)
+	n.archiveSnapshot(content)
+	if n.recovered {
+		discardRecovery()
 	}
 }
 
 func (n *NotePad) loadContent() {
-	if _, err := os.Stat(saveFile); os.IsNotExist(err) {
+	n.clearFolds()
+	path, key := n.currentFile()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
 		defaultContent := `***********LISTA REPOSICIÓN*********
 ......9999 REPOSICION 15:04 MGAVINO
 ......9999 REPOSICION 15:04 JRIOS
@@ -1217,87 +2380,378 @@ func (n *NotePad) loadContent() {
 # Las horas se actualizan automáticamente cada segundo
 # Puedes editar el texto libremente
 # Solo espera 2 segundos después de escribir para que se actualice la hora`
+		if n.external {
+			defaultContent = "" // Un archivo abierto que ya no existe queda vacío, sin la plantilla
+		}
 
-		n.multiLine.SetText(defaultContent)
-		n.lastContent = defaultContent
+		n.applyEdit(historySkip, defaultContent)
+		n.history.reset()
+		n.refreshHistory()
 		return
 	}
 
-	data, err := ioutil.ReadFile(saveFile)
+	data, err := readNoteFile(path, key)
 	if err != nil {
 		log.Printf("Error cargando archivo: %v", err)
 		return
 	}
 
-	content := string(data)
-	lines := strings.Split(content, "\n")
-	if len(lines) > 0 && strings.HasPrefix(lines[0], "# Guardado:") {
-		content = strings.Join(lines[1:], "\n")
-	}
+	content := stripSaveHeader(string(data))
+
+	n.applyEdit(historySkip, content)
+	n.history.reset()
+	n.refreshHistory()
 
-	n.multiLine.SetText(content)
-	n.lastContent = content
+	n.saveMu.Lock()
+	n.markWritten(path)
+	n.saveMu.Unlock()
+}
+
+// humanDelay aplica una variación aleatoria de ±jitter al retardo base
+func humanDelay(base, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return base
+	}
+	d := base + time.Duration(rand.Int63n(int64(2*jitter)+1)) - jitter
+	if d < 0 {
+		return 0
+	}
+	return d
 }
 
-func globalEscapeListener(statusLabel *widget.Label) {
-	fmt.Println("Listener global de ESC activado.")
-	hook.Register(hook.KeyDown, []string{"esc"}, func(e hook.Event) {
+// autocopiar ejecuta el trabajo y devuelve true si se escribieron todas las series
+func (a *Autocopiador) autocopiar(job *AutocopyJob) (completed bool) {
+	// Con repeticiones la lista se escribe completa una vez por iteración
+	iterations := job.iterations()
+	series := repeatList(job.seriesList(), iterations)
+	total := len(series)
+	perIteration := total / iterations
+	copied := 0
+	started := time.Now()
+	var typing time.Duration
+	// Registrado antes de la espera programada para avisar también si se cancela antes de iniciar
+	a.stats.addRun()
+	defer func() {
+		if !completed {
+			a.stats.addCancel()
+		}
+		a.refreshStats()
+		a.recordRun(job, series, started, copied, completed)
+		a.recordDailyRun(started, copied, typing)
+		notifyAutocopyEnd(completed, copied, total)
+	}()
+
+	if !job.Programado.IsZero() && !waitUntil(job.Programado, a.statusLabel) {
+		return
+	}
+
+	time.Sleep(3 * time.Second)
+
+	preset, ok := findSpeedPreset(job.Velocidad)
+	if !ok {
+		log.Printf("Velocidad desconocida %q, se usa %s", job.Velocidad, defaultSpeedPreset)
+		preset, _ = findSpeedPreset(defaultSpeedPreset)
+	}
+
+	steps, err := parseMacro(job.Macro)
+	if err != nil {
+		a.statusLabel.SetText(fmt.Sprintf("Estado: Secuencia inválida (%v)", err))
+		a.stats.addError()
+		return
+	}
+	terminator, err := parseTerminator(job.Terminador, job.TerminadorPersonalizado)
+	if err != nil {
+		a.statusLabel.SetText(fmt.Sprintf("Estado: %v", err))
+		a.stats.addError()
+		return
+	}
+	records, err := job.records()
+	if err != nil {
+		a.statusLabel.SetText(fmt.Sprintf("Estado: %v", err))
+		a.stats.addError()
+		return
+	}
+	estimated, err := estimateRun(job, records)
+	if err != nil {
+		log.Printf("No se pudo estimar la duración: %v", err)
+	}
+	records = repeatList(records, iterations)
+
+	// El valor copiado justo antes de iniciar se usa en todos los registros
+	clipboard := ""
+	if macroUsesPlaceholder(append(steps, terminator...), "portapapeles") {
+		if clipboard, err = readClipboardValue(); err != nil {
+			a.statusLabel.SetText(fmt.Sprintf("Estado: %v", err))
+			a.stats.addError()
+			return
+		}
+	}
+
+	if !runCountdown(a.countdown, a.statusLabel) {
+		return
+	}
+
+	a.statusLabel.SetText("Copiando...")
+	if job.CancelarSiTeclea {
+		defer a.watchUserInput()()
+	}
+
+	// La ventana activa al terminar la cuenta regresiva es la de destino
+	target := activeWindow()
+	runStart := time.Now()
+	a.copiedCounter.SetText(progressText(0, total, iterations) + " · faltan ~" + formatRemaining(estimated))
+	var locks lockWatcher
+
+	for i, rec := range records {
 		select {
 		case <-cancel:
+			a.statusLabel.SetText("Estado: Cancelado.")
+			return
 		default:
-			close(cancel)
-			if statusLabel != nil {
-				statusLabel.SetText("Estado: Cancelado con ESC.")
+		}
+
+		// Con dos ventanas cada registro va a la que le toca, que pasa a ser la de destino
+		if len(job.Ventanas) > 0 {
+			window := windowForRecord(job.Ventanas, i)
+			if err := activateWindow(window); err != nil {
+				a.statusLabel.SetText(fmt.Sprintf("Estado: %v", err))
+				a.stats.addError()
+				return
 			}
-			fmt.Println("Escape presionado.")
+			target = window.target()
+		}
+
+		// Los registros con pausa propia la usan entre acciones y al cerrar la fila
+		stepDelay, rowSettle := preset.Delay, preset.RowSettle
+		if rec.Espera > 0 {
+			stepDelay, rowSettle = rec.Espera, rec.Espera
 		}
-	})
 
-	s := hook.Start()
-	<-hook.Process(s)
+		a.updateOverlay(rec.Serie, i+1, total)
+		entryStart := time.Now()
+		chars := 0
+		vars := macroVars(rec, job.Fecha, i%perIteration, perIteration, time.Now())
+		vars["iteracion"] = strconv.Itoa(i/perIteration + 1)
+		vars["portapapeles"] = clipboard
+		for _, step := range steps {
+			if job.VigilarVentana && !a.guardFocus(target) {
+				return
+			}
+			if job.VigilarBloqueo && !a.guardLock(&locks) {
+				return
+			}
+			typed, ok := a.runStep(job, step, vars, preset.KeyDelay)
+			if !ok {
+				return
+			}
+			chars += typed
+			if step.Tipo != stepWait {
+				time.Sleep(humanDelay(stepDelay, job.Variacion))
+			}
+		}
+		entryElapsed := time.Since(entryStart)
+
+		if job.VerificarOCR {
+			if !a.verifyEntry(job.RegionOCR, rec.Serie) {
+				return
+			}
+			a.statusLabel.SetText("Copiando...")
+		}
+
+		for _, step := range terminator {
+			if job.VigilarVentana && !a.guardFocus(target) {
+				return
+			}
+			if job.VigilarBloqueo && !a.guardLock(&locks) {
+				return
+			}
+			if _, ok := a.runStep(job, step, vars, preset.KeyDelay); !ok {
+				return
+			}
+		}
+		time.Sleep(humanDelay(rowSettle, job.Variacion))
+
+		copied++
+		recordAudit(rec.Serie)
+		remaining := remainingTime(estimated, time.Since(runStart), copied, total)
+		a.copiedCounter.SetText(progressText(copied, total, iterations) + " · faltan ~" + formatRemaining(remaining))
+		a.stats.addEntry(chars, entryElapsed)
+		typing += entryElapsed
+		a.refreshStats()
+
+		if job.PausarCada > 0 && copied%job.PausarCada == 0 && copied < total {
+			if !waitBatchConfirmation(copied, total, a.window, a.statusLabel) {
+				return
+			}
+			// Cuenta corta para volver a enfocar la aplicación destino
+			if !runCountdown(resumeCountdown, a.statusLabel) {
+				return
+			}
+			a.statusLabel.SetText("Copiando...")
+		}
+	}
+
+	completed = true
+	a.statusLabel.SetText("Estado: Finalizado correctamente.")
+	return
 }
 
-func autocopiar(rawSeries string, date string, delay time.Duration, countdown int, statusLabel, copiedCounter *widget.Label) {
-	time.Sleep(3 * time.Second)
+// runStep ejecuta un paso de la secuencia con sus esperas: las de color y, con ritmo
+// adaptativo, la respuesta de la aplicación tras cada tecla o clic. Devuelve los
+// caracteres escritos y false si se canceló.
+func (a *Autocopiador) runStep(job *AutocopyJob, step MacroStep, vars map[string]string, keyDelay int) (int, bool) {
+	if step.Tipo == stepWait {
+		return 0, a.waitForPixel(step)
+	}
+	if !job.adaptivePacing() || step.Tipo == stepText {
+		return runSyntheticStep(step, vars, keyDelay, job.Escritura), true
+	}
 
-	series := strings.Fields(rawSeries)
-	total := len(series)
-	copied := 0
+	before := pacingSnapshot(job)
+	typed := runSyntheticStep(step, vars, keyDelay, job.Escritura)
+	return typed, waitForResponse(job, before)
+}
+
+// runSyntheticStep ejecuta el paso avisando al detector de teclas del usuario que las
+// pulsaciones son del propio proceso
+func runSyntheticStep(step MacroStep, vars map[string]string, keyDelay int, typing string) int {
+	userInput.beginSynthetic()
+	defer userInput.endSynthetic()
+	return runMacroStep(step, vars, keyDelay, typing)
+}
+
+// notifyAutocopyEnd avisa con sonido y notificación del sistema que el proceso terminó
+func notifyAutocopyEnd(completed bool, copied, total int) {
+	title := "✅ Autocopiado finalizado"
+	content := fmt.Sprintf("Se copiaron %d de %d series correctamente.", copied, total)
+	if !completed {
+		title = "⚠️ Autocopiado interrumpido"
+		content = fmt.Sprintf("El proceso se detuvo después de copiar %d de %d series.", copied, total)
+	}
+
+	playNotificationSound()
+	if a := fyne.CurrentApp(); a != nil {
+		fyne.Do(func() {
+			a.SendNotification(fyne.NewNotification(title, content))
+		})
+	}
+}
+
+// playNotificationSound reproduce el sonido del sistema según la plataforma
+func playNotificationSound() {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", "[System.Media.SystemSounds]::Asterisk.Play()")
+	case "darwin":
+		cmd = exec.Command("afplay", "/System/Library/Sounds/Glass.aiff")
+	default:
+		cmd = exec.Command("paplay", "/usr/share/sounds/freedesktop/stereo/complete.oga")
+	}
+
+	hideConsoleWindow(cmd)
+
+	if err := cmd.Start(); err != nil {
+		log.Printf("No se pudo reproducir el sonido de notificación: %v", err)
+		return
+	}
+	go cmd.Wait()
+}
+
+// nextClockTime devuelve la próxima ocurrencia de la hora HH:MM a partir de now
+func nextClockTime(hhmm string, now time.Time) (time.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(hhmm))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("hora de inicio inválida, usa el formato HH:MM")
+	}
+
+	start := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !start.After(now) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start, nil
+}
+
+// waitUntil espera hasta la hora programada mostrando el tiempo restante; devuelve false si se canceló
+func waitUntil(start time.Time, statusLabel *widget.Label) bool {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		remaining := time.Until(start)
+		if remaining <= 0 {
+			return true
+		}
+		statusLabel.SetText(fmt.Sprintf("Programado para las %s (faltan %s)",
+			start.Format("15:04"), remaining.Round(time.Second)))
 
-	for i := countdown; i > 0; i-- {
-		statusLabel.SetText(fmt.Sprintf("Comenzando en %d...", i))
 		select {
 		case <-cancel:
-			return
-		default:
+			return false
+		case <-ticker.C:
 		}
-		time.Sleep(time.Second)
 	}
+}
 
-	statusLabel.SetText("Copiando...")
-
-	for _, s := range series {
+// runCountdown muestra la cuenta regresiva; devuelve false si se canceló
+func runCountdown(countdown int, statusLabel *widget.Label) bool {
+	// Durante la cuenta el usuario vuelve a la ventana destino, quizá con el teclado
+	userInput.pause()
+	defer userInput.resume()
+	for i := countdown; i > 0; i-- {
+		statusLabel.SetText(fmt.Sprintf("Comenzando en %d...", i))
 		select {
 		case <-cancel:
-			statusLabel.SetText("Estado: Cancelado.")
-			return
+			return false
 		default:
 		}
-		robotgo.TypeStrDelay(s, 2)
-		time.Sleep(delay)
+		time.Sleep(time.Second)
+	}
+	return true
+}
 
-		robotgo.KeyTap("tab")
-		time.Sleep(delay)
+// waitBatchConfirmation detiene el proceso hasta que el usuario confirme el lote
+func waitBatchConfirmation(copied, total int, window fyne.Window, statusLabel *widget.Label) bool {
+	statusLabel.SetText(fmt.Sprintf("Pausado: verifica el lote (%d / %d)", copied, total))
 
-		robotgo.TypeStrDelay(date, 2)
-		time.Sleep(delay)
+	return waitConfirmation("Lote completado",
+		fmt.Sprintf("Se copiaron %d de %d series.\n\nVerifica que el sistema aceptó el lote.\n¿Continuar con el siguiente?", copied, total),
+		window, statusLabel)
+}
 
-		robotgo.KeyTap("down")
-		time.Sleep(60 * time.Millisecond)
+// waitConfirmation muestra un diálogo de confirmación y bloquea el proceso hasta la respuesta.
+// Devuelve false si el usuario declina o si se cancela con el botón o las teclas de cancelación.
+func waitConfirmation(title, message string, window fyne.Window, statusLabel *widget.Label) bool {
+	userInput.pause()
+	defer userInput.resume()
+	resume := make(chan bool, 1)
+	var confirm *dialog.ConfirmDialog
+	fyne.Do(func() {
+		confirm = dialog.NewConfirm(title, message,
+			func(confirmed bool) {
+				resume <- confirmed
+			}, window)
+		confirm.Show()
+	})
 
-		copied++
-		copiedCounter.SetText(fmt.Sprintf("Copiadas: %d / %d", copied, total))
+	select {
+	case <-cancel:
+		// Cancelado con el botón o las teclas: cerrar el diálogo pendiente
+		fyne.Do(func() {
+			if confirm != nil {
+				confirm.Hide()
+			}
+		})
+		return false
+	case confirmed := <-resume:
+		if !confirmed {
+			select {
+			case <-cancel:
+			default:
+				close(cancel)
+			}
+			statusLabel.SetText("Estado: Cancelado manualmente.")
+		}
+		return confirmed
 	}
-
-	statusLabel.SetText("Estado: Finalizado correctamente.")
 }
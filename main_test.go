@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanDelayBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   time.Duration
+		jitter time.Duration
+	}{
+		{"sin variación", 90 * time.Millisecond, 0},
+		{"variación mínima", 90 * time.Millisecond, 20 * time.Millisecond},
+		{"variación máxima", 90 * time.Millisecond, 50 * time.Millisecond},
+		{"base menor que la variación", 30 * time.Millisecond, 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower := tt.base - tt.jitter
+			if lower < 0 {
+				lower = 0
+			}
+			upper := tt.base + tt.jitter
+
+			for i := 0; i < 1000; i++ {
+				d := humanDelay(tt.base, tt.jitter)
+				if d < lower || d > upper {
+					t.Fatalf("humanDelay(%v, %v) = %v, fuera de [%v, %v]", tt.base, tt.jitter, d, lower, upper)
+				}
+			}
+		})
+	}
+}
+
+func TestSpeedPresets(t *testing.T) {
+	names := speedPresetNames()
+	if len(names) != len(speedPresets) {
+		t.Fatalf("speedPresetNames() devolvió %d nombres, se esperaban %d", len(names), len(speedPresets))
+	}
+	for _, name := range names {
+		if _, ok := findSpeedPreset(name); !ok {
+			t.Errorf("findSpeedPreset(%q) no encontró el preset listado", name)
+		}
+	}
+	if _, ok := findSpeedPreset(defaultSpeedPreset); !ok {
+		t.Errorf("el preset por defecto %q no existe", defaultSpeedPreset)
+	}
+	if _, ok := findSpeedPreset("Turbo"); ok {
+		t.Error("findSpeedPreset aceptó un preset inexistente")
+	}
+}
+
+func TestNextClockTime(t *testing.T) {
+	now := time.Date(2025, 5, 27, 14, 30, 15, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"más tarde hoy", "18:05", time.Date(2025, 5, 27, 18, 5, 0, 0, time.Local), false},
+		{"con espacios", " 18:05 ", time.Date(2025, 5, 27, 18, 5, 0, 0, time.Local), false},
+		{"hora ya pasada pasa a mañana", "09:00", time.Date(2025, 5, 28, 9, 0, 0, 0, time.Local), false},
+		{"minuto actual pasa a mañana", "14:30", time.Date(2025, 5, 28, 14, 30, 0, 0, time.Local), false},
+		{"medianoche", "00:00", time.Date(2025, 5, 28, 0, 0, 0, 0, time.Local), false},
+		{"vacío", "", time.Time{}, true},
+		{"hora fuera de rango", "25:00", time.Time{}, true},
+		{"minutos fuera de rango", "18:75", time.Time{}, true},
+		{"sin dos puntos", "1805", time.Time{}, true},
+		{"texto", "tarde", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextClockTime(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("nextClockTime(%q) = %v, se esperaba error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nextClockTime(%q) error inesperado: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextClockTime(%q) = %v, se esperaba %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultGuiaMask = "{EMP}-{######}" // ZET-000123, el formato de siempre
+	maxGuiaLength   = 24               // Más largo el código de barras no entra en una etiqueta de 10 cm
+)
+
+// guiaDateTokens son los segmentos de fecha que se pueden poner en la máscara, con su
+// formato de Go
+var guiaDateTokens = map[string]string{
+	"YYYYMMDD": "20060102",
+	"YYMMDD":   "060102",
+	"YYYY":     "2006",
+	"YY":       "06",
+	"MM":       "01",
+	"DD":       "02",
+}
+
+// guiaMask es la máscara de las guías de e, o la de siempre si no tiene una
+func (e Empresa) guiaMask() string {
+	if e.Mascara == "" {
+		return defaultGuiaMask
+	}
+	return e.Mascara
+}
+
+// expandGuiaMask arma la guía número n de la empresa key con mask. Entre llaves van {EMP},
+// las tres primeras letras de la clave; la fecha de now ({YYYYMMDD}, {YYMMDD}, {YYYY}, {YY},
+// {MM}, {DD}) y el contador {####}, rellenado con ceros hasta tantos dígitos como #. El resto
+// del texto se copia tal cual.
+func expandGuiaMask(mask, key string, n int, now time.Time) (string, error) {
+	var b strings.Builder
+	counters := 0
+	for rest := mask; rest != ""; {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			b.WriteString(rest)
+			break
+		}
+		if rest[open] == '}' {
+			return "", fmt.Errorf("la máscara tiene una } sin su {")
+		}
+		b.WriteString(rest[:open])
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("la máscara tiene una { sin cerrar")
+		}
+		token := rest[open+1 : open+end]
+		rest = rest[open+end+1:]
+		switch {
+		case token == "EMP":
+			b.WriteString(key[:3])
+		case guiaDateTokens[token] != "":
+			b.WriteString(now.Format(guiaDateTokens[token]))
+		case token != "" && strings.Trim(token, "#") == "":
+			counters++
+			fmt.Fprintf(&b, "%0*d", len(token), n)
+		default:
+			return "", fmt.Errorf("la máscara no reconoce {%s}; usa {EMP}, {YYYYMMDD}, {YYYY}, {MM}, {DD} o {####}", token)
+		}
+	}
+	if counters != 1 {
+		return "", fmt.Errorf("la máscara debe tener un contador, como {####}")
+	}
+	return b.String(), nil
+}
+
+// validateGuiaMask revisa que la máscara arme guías que entren en el código de barras
+func validateGuiaMask(mask, key string) error {
+	sample, err := expandGuiaMask(mask, key, 1, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(sample) > maxGuiaLength {
+		return fmt.Errorf("la guía %s es muy larga para el código de barras; máximo %d caracteres", sample, maxGuiaLength)
+	}
+	if _, err := code128Values(sample); err != nil {
+		return err
+	}
+	return nil
+}
+
+// formatGuia arma la guía número n de la empresa con su máscara. El contador no vuelve a
+// empezar cada día aunque la máscara lleve la fecha, así ninguna guía se repite si la
+// máscara cambia.
+func formatGuia(empresa string, n int, now time.Time) string {
+	guia, err := expandGuiaMask(empresasData[empresa].guiaMask(), empresa, n, now)
+	if err != nil {
+		// empresas.json se valida al cargarlo; por si acaso, el formato de siempre
+		guia, _ = expandGuiaMask(defaultGuiaMask, empresa, n, now)
+	}
+	return guia
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandGuiaMask(t *testing.T) {
+	now := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		mask string
+		n    int
+		want string
+	}{
+		{defaultGuiaMask, 123, "COM-000123"},
+		{"COM-{YYYYMMDD}-{####}", 7, "COM-20240305-0007"},
+		{"{EMP}{YY}{MM}{DD}{###}-PE", 42, "COM240305042-PE"},
+		{"{YYYY}/{#}", 15, "2024/15"}, // El contador crece si pasa del relleno
+		{"{YYMMDD}{######}", 1, "240305000001"},
+	}
+	for _, tt := range tests {
+		if got, err := expandGuiaMask(tt.mask, "COMSITEC", tt.n, now); err != nil || got != tt.want {
+			t.Errorf("expandGuiaMask(%q, %d) = %q, %v; quería %q", tt.mask, tt.n, got, err, tt.want)
+		}
+	}
+	for _, mask := range []string{"", "COM-{YYYY}", "{####}-{##}", "COM-{FECHA}-{####}", "COM-{####", "COM}-{####}", "{}{####}"} {
+		if got, err := expandGuiaMask(mask, "COMSITEC", 1, now); err == nil {
+			t.Errorf("se aceptó la máscara %q: %q", mask, got)
+		}
+	}
+}
+
+func TestValidateGuiaMask(t *testing.T) {
+	if err := validateGuiaMask("COM-{YYYYMMDD}-{####}", "COMSITEC"); err != nil {
+		t.Errorf("máscara válida rechazada: %v", err)
+	}
+	if validateGuiaMask("COMSITEC-ENVIOS-{YYYYMMDD}-{######}", "COMSITEC") == nil {
+		t.Error("se aceptó una guía demasiado larga para el código de barras")
+	}
+	if validateGuiaMask("Envío-{####}", "COMSITEC") == nil {
+		t.Error("se aceptó una tilde, que no entra en el código de barras")
+	}
+}
+
+func TestFormatGuia(t *testing.T) {
+	old := empresasData
+	defer func() { empresasData = old }()
+	empresasData = map[string]Empresa{
+		"COMSITEC": {Nombre: "COMSITEC S.A.C", Mascara: "COM-{YYYYMMDD}-{####}"},
+		"ZETTACOM": {Nombre: "ZETTACOM S.A.C"},
+	}
+	now := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	if got := formatGuia("COMSITEC", 12, now); got != "COM-20240305-0012" {
+		t.Errorf("con máscara = %s", got)
+	}
+	if got := formatGuia("ZETTACOM", 12, now); got != "ZET-000012" {
+		t.Errorf("sin máscara = %s", got)
+	}
+}
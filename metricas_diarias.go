@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	metricsFile      = "metricas_autocopiado.json"
+	metricsDayLayout = "2006-01-02"
+	metricsMaxDays   = 365 // Días que se conservan en el archivo
+	metricsChartDays = 14  // Días que se muestran en el gráfico
+	metricsBarHeight = 80  // Alto de la barra del día con más series
+)
+
+// DailyMetric acumula las ejecuciones del autocopiador de un día
+type DailyMetric struct {
+	Dia         string // AAAA-MM-DD
+	Ejecuciones int
+	Series      int
+	Tecleando   time.Duration // Tiempo efectivo escribiendo series
+}
+
+func (m DailyMetric) entriesPerMinute() float64 {
+	if m.Tecleando <= 0 {
+		return 0
+	}
+	return float64(m.Series) / m.Tecleando.Minutes()
+}
+
+// addDailyRun suma una ejecución al día correspondiente, manteniendo los días en orden
+// ascendente y descartando los más antiguos
+func addDailyRun(days []DailyMetric, when time.Time, series int, typing time.Duration) []DailyMetric {
+	key := when.Format(metricsDayLayout)
+	i := sort.Search(len(days), func(i int) bool { return days[i].Dia >= key })
+	if i == len(days) || days[i].Dia != key {
+		days = append(days, DailyMetric{})
+		copy(days[i+1:], days[i:])
+		days[i] = DailyMetric{Dia: key}
+	}
+	days[i].Ejecuciones++
+	days[i].Series += series
+	days[i].Tecleando += typing
+
+	if len(days) > metricsMaxDays {
+		days = days[len(days)-metricsMaxDays:]
+	}
+	return days
+}
+
+// lastDays devuelve los últimos n días hasta hoy, con ceros en los días sin ejecuciones
+func lastDays(days []DailyMetric, now time.Time, n int) []DailyMetric {
+	byDay := make(map[string]DailyMetric, len(days))
+	for _, d := range days {
+		byDay[d.Dia] = d
+	}
+
+	result := make([]DailyMetric, n)
+	for i := 0; i < n; i++ {
+		key := now.AddDate(0, 0, i-n+1).Format(metricsDayLayout)
+		if d, ok := byDay[key]; ok {
+			result[i] = d
+		} else {
+			result[i] = DailyMetric{Dia: key}
+		}
+	}
+	return result
+}
+
+// metricsSummary resume el período mostrado: el día de hoy y el total acumulado
+func metricsSummary(days []DailyMetric) string {
+	var total DailyMetric
+	for _, d := range days {
+		total.Ejecuciones += d.Ejecuciones
+		total.Series += d.Series
+		total.Tecleando += d.Tecleando
+	}
+	today := days[len(days)-1]
+
+	return fmt.Sprintf("Hoy: %d series en %d ejecuciones (%.1f series/min)\n"+
+		"Últimos %d días: %d series en %d ejecuciones (%.1f series/min)",
+		today.Series, today.Ejecuciones, today.entriesPerMinute(),
+		len(days), total.Series, total.Ejecuciones, total.entriesPerMinute())
+}
+
+func (a *Autocopiador) loadDailyMetrics() {
+	data, err := ioutil.ReadFile(metricsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Error cargando métricas: %v", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &a.daily); err != nil {
+		log.Printf("Error leyendo métricas: %v", err)
+	}
+}
+
+func (a *Autocopiador) saveDailyMetrics() {
+	data, err := json.MarshalIndent(a.daily, "", "  ")
+	if err != nil {
+		log.Printf("Error serializando métricas: %v", err)
+		return
+	}
+
+	if err := ioutil.WriteFile(metricsFile, data, 0644); err != nil {
+		log.Printf("Error guardando métricas: %v", err)
+	}
+}
+
+// recordDailyRun suma la ejecución a las métricas del día y las persiste.
+// Se llama desde el goroutine del autocopiado, por eso todo ocurre dentro de fyne.Do.
+func (a *Autocopiador) recordDailyRun(started time.Time, copied int, typing time.Duration) {
+	fyne.Do(func() {
+		a.daily = addDailyRun(a.daily, started, copied, typing)
+		a.saveDailyMetrics()
+		a.refreshDailyMetrics()
+	})
+}
+
+// refreshDailyMetrics redibuja el gráfico de barras con las series escritas por día
+func (a *Autocopiador) refreshDailyMetrics() {
+	if a.dailyChart == nil {
+		return
+	}
+
+	days := lastDays(a.daily, time.Now(), metricsChartDays)
+	highest := 0
+	for _, d := range days {
+		if d.Series > highest {
+			highest = d.Series
+		}
+	}
+
+	bars := make([]fyne.CanvasObject, len(days))
+	for i, d := range days {
+		height := float32(0)
+		if highest > 0 {
+			height = float32(d.Series) / float32(highest) * metricsBarHeight
+		}
+		bar := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+		bar.SetMinSize(fyne.NewSize(8, height))
+
+		day, _ := time.Parse(metricsDayLayout, d.Dia)
+		count := widget.NewLabel(fmt.Sprintf("%d", d.Series))
+		count.Alignment = fyne.TextAlignCenter
+		date := widget.NewLabel(day.Format("02/01"))
+		date.Alignment = fyne.TextAlignCenter
+
+		bars[i] = container.NewVBox(layout.NewSpacer(), count, bar, date)
+	}
+
+	a.dailyChart.Objects = bars
+	a.dailyChart.Refresh()
+	a.dailySummary.SetText(metricsSummary(days))
+}
+
+func (a *Autocopiador) createDailyMetricsPanel() fyne.CanvasObject {
+	a.dailyChart = container.NewGridWithColumns(metricsChartDays)
+	a.dailySummary = widget.NewLabel("")
+	a.refreshDailyMetrics()
+
+	chartScroll := container.NewHScroll(a.dailyChart)
+	chartScroll.SetMinSize(fyne.NewSize(350, metricsBarHeight+70))
+
+	return container.NewVBox(chartScroll, a.dailySummary)
+}
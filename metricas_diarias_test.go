@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddDailyRun(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.Local) }
+
+	var days []DailyMetric
+	days = addDailyRun(days, day(5, 9), 10, time.Minute)
+	days = addDailyRun(days, day(3, 9), 4, 0)
+	days = addDailyRun(days, day(5, 17), 20, time.Minute)
+	days = addDailyRun(days, day(4, 12), 0, 0)
+
+	want := []DailyMetric{
+		{Dia: "2024-03-03", Ejecuciones: 1, Series: 4},
+		{Dia: "2024-03-04", Ejecuciones: 1},
+		{Dia: "2024-03-05", Ejecuciones: 2, Series: 30, Tecleando: 2 * time.Minute},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("addDailyRun() = %v, se esperaba %v", days, want)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("día %d = %+v, se esperaba %+v", i, days[i], want[i])
+		}
+	}
+	if got := days[2].entriesPerMinute(); got != 15 {
+		t.Errorf("entriesPerMinute() = %v, se esperaba 15", got)
+	}
+}
+
+func TestAddDailyRunKeepsLastDays(t *testing.T) {
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.Local)
+	var days []DailyMetric
+	for i := 0; i < metricsMaxDays+5; i++ {
+		days = addDailyRun(days, start.AddDate(0, 0, i), 1, 0)
+	}
+	if len(days) != metricsMaxDays {
+		t.Fatalf("len = %d, se esperaba %d", len(days), metricsMaxDays)
+	}
+	if got, want := days[0].Dia, start.AddDate(0, 0, 5).Format(metricsDayLayout); got != want {
+		t.Errorf("primer día = %s, se esperaba %s", got, want)
+	}
+}
+
+func TestLastDays(t *testing.T) {
+	days := []DailyMetric{
+		{Dia: "2024-02-20", Series: 99},
+		{Dia: "2024-02-28", Series: 5},
+		{Dia: "2024-03-01", Series: 7},
+	}
+	now := time.Date(2024, 3, 1, 18, 0, 0, 0, time.Local)
+
+	got := lastDays(days, now, 3)
+	want := []DailyMetric{
+		{Dia: "2024-02-28", Series: 5},
+		{Dia: "2024-02-29"},
+		{Dia: "2024-03-01", Series: 7},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("lastDays()[%d] = %+v, se esperaba %+v", i, got[i], want[i])
+		}
+	}
+}
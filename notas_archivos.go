@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// currentFile devuelve la ruta de lo abierto en el editor y la clave para leerlo. Los
+// archivos abiertos con 📂 Abrir se guardan tal cual, sin cifrar ni encabezado, para que
+// los demás programas los sigan leyendo.
+func (n *NotePad) currentFile() (string, []byte) {
+	if n.external {
+		return n.current, nil
+	}
+	return notePath(n.current), n.key
+}
+
+// saveAsName propone el nombre del archivo en "Guardar como"
+func saveAsName(current string, external bool) string {
+	if external {
+		return filepath.Base(current)
+	}
+	return current + ".txt"
+}
+
+// openExternal guarda lo abierto y pasa a editar el archivo path; desde ahí el guardado
+// automático escribe en ese archivo
+func (n *NotePad) openExternal(path string) {
+	n.saveContent()
+	n.current = path
+	n.external = true
+	n.loadContent()
+	n.notesList.UnselectAll()
+	n.refreshDays()
+	n.rememberRecent()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Editando %s", path))
+}
+
+// openPath abre path en el editor: si está en la carpeta de notas se abre como nota y si
+// no como archivo externo
+func (n *NotePad) openPath(path string) {
+	if name, ok := recentNoteName(path, notesDir); ok {
+		n.switchNote(name)
+		n.selectNoteInList()
+		return
+	}
+	if current, _ := n.currentFile(); !samePath(current, path) {
+		n.openExternal(path)
+	}
+}
+
+// droppedTextFiles devuelve los .txt de lo soltado sobre la ventana, en el mismo orden
+func droppedTextFiles(paths []string) []string {
+	var files []string
+	for _, p := range paths {
+		if strings.EqualFold(filepath.Ext(p), ".txt") {
+			files = append(files, p)
+		}
+	}
+	return files
+}
+
+// openDropped abre el .txt que se arrastró a la ventana; si se sueltan varios se abre el
+// primero, ya que el editor muestra un archivo a la vez
+func (n *NotePad) openDropped(uris []fyne.URI) {
+	if n.locked {
+		n.statusLabel.SetText("Estado: Desbloquea el bloc de notas con 🔒 Cifrado antes de abrir otro archivo")
+		return
+	}
+	var paths []string
+	for _, u := range uris {
+		if u.Scheme() == "file" {
+			paths = append(paths, u.Path())
+		}
+	}
+	files := droppedTextFiles(paths)
+	if len(files) == 0 {
+		n.statusLabel.SetText("Estado: Solo se pueden abrir archivos .txt")
+		return
+	}
+	n.openPath(files[0])
+	if len(files) > 1 {
+		n.statusLabel.SetText(fmt.Sprintf("Estado: Abierto %s; se soltaron %d archivos y se abre uno a la vez", filepath.Base(files[0]), len(files)))
+	}
+}
+
+// showOpenFile elige un .txt cualquiera para editarlo en la pestaña
+func (n *NotePad) showOpenFile(window fyne.Window) {
+	if n.locked {
+		dialog.ShowInformation("📂 Abrir", "Desbloquea el bloc de notas con 🔒 Cifrado antes de abrir otro archivo.", window)
+		return
+	}
+	openDialog := dialog.NewFileOpen(
+		func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			path := reader.URI().Path()
+			reader.Close()
+			if path == n.current && n.external {
+				return
+			}
+			n.openExternal(path)
+		},
+		window)
+
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".txt"}))
+	openDialog.Show()
+}
+
+// showSaveAs guarda el contenido del editor en otro archivo, que pasa a ser el abierto
+func (n *NotePad) showSaveAs(window fyne.Window) {
+	if n.locked {
+		dialog.ShowInformation("📄 Guardar como", "Desbloquea el bloc de notas con 🔒 Cifrado antes de guardarlo en otro archivo.", window)
+		return
+	}
+	saveDialog := dialog.NewFileSave(
+		func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			path := writer.URI().Path()
+			writer.Close()
+
+			n.saveContent()
+			n.current = path
+			n.external = true
+			n.markDirty()
+			n.saveContent()
+			n.notesList.UnselectAll()
+			n.refreshDays()
+			n.rememberRecent()
+			n.statusLabel.SetText(fmt.Sprintf("Estado: Guardado en %s; el guardado automático sigue en ese archivo", path))
+		},
+		window)
+
+	saveDialog.SetFileName(saveAsName(n.current, n.external))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".txt"}))
+	saveDialog.Show()
+}
+
+// createFileButtons devuelve los botones 📂 Abrir y 📄 Guardar como de la barra
+func (n *NotePad) createFileButtons(window fyne.Window) (*widget.Button, *widget.Button) {
+	openButton := widget.NewButton("📂 Abrir", func() { n.showOpenFile(window) })
+	saveAsButton := widget.NewButton("📄 Guardar como", func() { n.showSaveAs(window) })
+	return openButton, saveAsButton
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCurrentFile(t *testing.T) {
+	key := []byte("clave")
+	n := &NotePad{current: "General", key: key}
+	if path, got := n.currentFile(); path != notePath("General") || string(got) != "clave" {
+		t.Errorf("una nota de la carpeta debería usar notePath y la clave: %q, %q", path, got)
+	}
+
+	external := filepath.Join(t.TempDir(), "pedidos.txt")
+	n.current, n.external = external, true
+	if path, got := n.currentFile(); path != external || got != nil {
+		t.Errorf("un archivo abierto debería usar su ruta y no cifrarse: %q, %q", path, got)
+	}
+}
+
+func TestSaveAsName(t *testing.T) {
+	if got := saveAsName("General", false); got != "General.txt" {
+		t.Errorf("saveAsName de una nota = %q", got)
+	}
+	if got := saveAsName(filepath.Join("C:", "compartido", "pedidos.txt"), true); got != "pedidos.txt" {
+		t.Errorf("saveAsName de un archivo abierto = %q", got)
+	}
+}
+
+func TestDroppedTextFiles(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{
+		filepath.Join(dir, "export.csv"),
+		filepath.Join(dir, "Semana 41.TXT"),
+		filepath.Join(dir, "carpeta"),
+		filepath.Join(dir, "pedidos.txt"),
+	}
+	got := droppedTextFiles(paths)
+	if len(got) != 2 || got[0] != paths[1] || got[1] != paths[3] {
+		t.Errorf("droppedTextFiles = %q", got)
+	}
+	if got := droppedTextFiles([]string{filepath.Join(dir, "foto.png")}); got != nil {
+		t.Errorf("sin .txt no debería devolver nada: %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// duplicateRows repite las filas first..last justo debajo de la última
+func duplicateRows(text string, first, last int) string {
+	lines := strings.Split(text, "\n")
+	if first < 0 || first > last || last >= len(lines) {
+		return text
+	}
+	copied := append([]string{}, lines[first:last+1]...)
+	lines = append(lines[:last+1], append(copied, lines[last+1:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// duplicateLine duplica la línea del cursor o las líneas seleccionadas; el cursor baja a la
+// copia para poder seguir editándola, por ejemplo cambiando solo el código
+func (n *NotePad) duplicateLine() {
+	text := n.multiLine.Text
+	first, last := n.multiLine.CursorRow, n.multiLine.CursorRow
+	if selected := n.multiLine.SelectedText(); selected != "" {
+		cursor := rowColToOffset(text, n.multiLine.CursorRow, n.multiLine.CursorColumn)
+		first, last = selectionRows(text, cursor, selected)
+	}
+	edited := duplicateRows(text, first, last)
+	if edited == text {
+		return
+	}
+
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	n.applyEdit(historyDupLine, edited)
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow+last-first+1, cursorCol
+	n.multiLine.Refresh()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: %d líneas duplicadas", last-first+1))
+}
+
+// addNoteShortcut registra el atajo en el editor y en la ventana. Desde la ventana solo actúa
+// con la pestaña Personal a la vista, para no tocar la nota escondida desde otra pestaña.
+func (n *NotePad) addNoteShortcut(window fyne.Window, s noteShortcut, action func()) {
+	n.multiLine.shortcuts[s] = action
+	addCanvasShortcut(window, s, func() {
+		if n.onPersonal == nil || n.onPersonal() {
+			action()
+		}
+	})
+}
+
+// createShortcuts registra Ctrl+S (guardar), Ctrl+L (limpiar, con confirmación) y Ctrl+D
+// (duplicar línea) en el editor y en la ventana. Guardar y limpiar hacen lo mismo que sus
+// botones; Ctrl+F lo registra la barra de búsqueda.
+func (n *NotePad) createShortcuts(window fyne.Window, save, clear func()) {
+	for key, action := range map[fyne.KeyName]func(){
+		fyne.KeyS: save,
+		fyne.KeyL: clear,
+		fyne.KeyD: n.duplicateLine,
+	} {
+		n.addNoteShortcut(window, noteShortcut{key: key}, action)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestDuplicateRows(t *testing.T) {
+	text := "***LISTA***\n......0154 LGARCIA 15:04 JRIOS\n......0201 MGAVINO 15:10 JRIOS"
+	tests := []struct {
+		first, last int
+		want        string
+	}{
+		{1, 1, "***LISTA***\n......0154 LGARCIA 15:04 JRIOS\n......0154 LGARCIA 15:04 JRIOS\n......0201 MGAVINO 15:10 JRIOS"},
+		{1, 2, text + "\n......0154 LGARCIA 15:04 JRIOS\n......0201 MGAVINO 15:10 JRIOS"},
+		{0, 0, "***LISTA***\n" + text},
+		{2, 3, text},
+	}
+	for _, tt := range tests {
+		if got := duplicateRows(text, tt.first, tt.last); got != tt.want {
+			t.Errorf("duplicateRows(%d, %d) = %q, quería %q", tt.first, tt.last, got, tt.want)
+		}
+	}
+	if got := duplicateRows("", 0, 0); got != "\n" {
+		t.Errorf("una nota vacía debería quedar con dos líneas vacías: %q", got)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/go-vgo/robotgo"
+)
+
+const (
+	captureTitle    = "**************CAPTURAS**********" // Sección donde se agrega lo copiado
+	captureInterval = 500 * time.Millisecond
+	captureLayout   = "02/01/2006 15:04:05"
+)
+
+// captureLines arma una línea por cada línea no vacía de lo copiado. La fecha va entre
+// `...` para que la actualización automática no la cambie por la hora actual.
+func captureLines(copied string, at time.Time) []string {
+	var lines []string
+	for _, line := range strings.Split(copied, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, fmt.Sprintf("`%s` %s", at.Format(captureLayout), line))
+		}
+	}
+	return lines
+}
+
+// appendCapture agrega lines al final de la sección de capturas, antes de las líneas vacías
+// que la separan de la siguiente. Si la nota no tiene la sección la crea al final.
+func appendCapture(text string, lines []string) string {
+	rows := strings.Split(text, "\n")
+	for i, row := range rows {
+		if strings.TrimSpace(row) != captureTitle {
+			continue
+		}
+		last := i
+		for last+1 < len(rows) && !starTitleRegex.MatchString(strings.TrimSpace(rows[last+1])) {
+			last++
+		}
+		for last > i && strings.TrimSpace(rows[last]) == "" {
+			last--
+		}
+		merged := append(append(append([]string{}, rows[:last+1]...), lines...), rows[last+1:]...)
+		return strings.Join(merged, "\n")
+	}
+
+	text = strings.TrimRight(text, "\n")
+	if text != "" {
+		text += "\n\n"
+	}
+	return text + captureTitle + "\n" + strings.Join(lines, "\n")
+}
+
+// addCapture agrega lo copiado a la nota sin mover el cursor
+func (n *NotePad) addCapture(copied string) {
+	lines := captureLines(copied, time.Now())
+	if len(lines) == 0 {
+		return
+	}
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	n.applyEdit(historyCapture, appendCapture(n.multiLine.Text, lines))
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+	n.multiLine.Refresh()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: 📎 %d líneas capturadas del portapapeles", len(lines)))
+}
+
+// watchClipboard revisa el portapapeles hasta que se cierre stop. Lo que ya estaba copiado
+// al empezar no se captura.
+func (n *NotePad) watchClipboard(stop chan struct{}) {
+	last, _ := robotgo.ReadAll()
+	ticker := time.NewTicker(captureInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		copied, err := robotgo.ReadAll()
+		if err != nil {
+			log.Printf("Error leyendo el portapapeles: %v", err)
+			continue
+		}
+		if copied == last {
+			continue
+		}
+		last = copied
+		fyne.Do(func() {
+			select {
+			case <-stop:
+				// Se desactivó mientras se leía
+			default:
+				n.addCapture(copied)
+			}
+		})
+	}
+}
+
+// createCaptureToggle devuelve la casilla que activa la captura del portapapeles. No se
+// recuerda al cerrar el programa para no seguir copiando a la nota sin darse cuenta.
+func (n *NotePad) createCaptureToggle() *widget.Check {
+	return widget.NewCheck("📎 Capturar portapapeles", func(on bool) {
+		if on {
+			n.captureStop = make(chan struct{})
+			go n.watchClipboard(n.captureStop)
+			n.statusLabel.SetText("Estado: Lo que copies se agrega a la sección CAPTURAS")
+			return
+		}
+		if n.captureStop != nil {
+			close(n.captureStop)
+			n.captureStop = nil
+		}
+		n.statusLabel.SetText("Estado: Captura del portapapeles desactivada")
+	})
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCaptureLines(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 5, 7, 0, time.Local)
+	got := captureLines("ZET00154\r\n\n  SN-778  \n", at)
+	want := []string{"`16/10/2026 09:05:07` ZET00154", "`16/10/2026 09:05:07` SN-778"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("captureLines = %q, quería %q", got, want)
+	}
+	if got := captureLines(" \n\t", at); got != nil {
+		t.Errorf("lo copiado en blanco no debería capturarse: %q", got)
+	}
+}
+
+func TestAppendCapture(t *testing.T) {
+	lines := []string{"c1", "c2"}
+	tests := []struct {
+		name, text, want string
+	}{
+		{"nota vacía", "", captureTitle + "\nc1\nc2"},
+		{"sin sección se crea al final", "***LISTA***\na\n\n", "***LISTA***\na\n\n" + captureTitle + "\nc1\nc2"},
+		{"sección al final", "a\n" + captureTitle + "\nx", "a\n" + captureTitle + "\nx\nc1\nc2"},
+		{"título como última línea", captureTitle, captureTitle + "\nc1\nc2"},
+		{"antes de la siguiente sección", captureTitle + "\nx\n\n***ZETTACOM***\nz",
+			captureTitle + "\nx\nc1\nc2\n\n***ZETTACOM***\nz"},
+	}
+	for _, tt := range tests {
+		if got := appendCapture(tt.text, lines); got != tt.want {
+			t.Errorf("%s: appendCapture = %q, quería %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Casillas "[ ]" y "[x]" al principio de la línea, después del ! de las líneas congeladas
+var checkboxRegex = regexp.MustCompile(`^(\s*!?\s*)\[([ xX])\]`)
+
+const (
+	checkboxEmpty = "[ ]"
+	checkboxDone  = "[x]"
+)
+
+// isCheckedLine indica si la línea tiene la casilla marcada; ok es false si no tiene casilla
+func isCheckedLine(line string) (checked, ok bool) {
+	m := checkboxRegex.FindStringSubmatch(line)
+	if m == nil {
+		return false, false
+	}
+	return m[2] != " ", true
+}
+
+// setCheckbox deja la casilla de la línea marcada o sin marcar, agregándola si no la tiene
+func setCheckbox(line string, checked bool) string {
+	box := checkboxEmpty
+	if checked {
+		box = checkboxDone
+	}
+	if m := checkboxRegex.FindStringSubmatchIndex(line); m != nil {
+		return line[:m[3]] + box + line[m[1]:]
+	}
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	if rest := line[indent:]; strings.HasPrefix(rest, frozenLinePrefix) {
+		indent += len(frozenLinePrefix)
+	}
+	return line[:indent] + box + " " + line[indent:]
+}
+
+// toggleCheckLines marca las casillas de las líneas o, si ya estaban todas marcadas, las
+// desmarca. Las líneas vacías no reciben casilla.
+func toggleCheckLines(lines []string) []string {
+	allChecked := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if checked, _ := isCheckedLine(line); !checked {
+			allChecked = false
+			break
+		}
+	}
+	toggled := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			toggled[i] = line
+			continue
+		}
+		toggled[i] = setCheckbox(line, !allChecked)
+	}
+	return toggled
+}
+
+// checklistProgress cuenta las casillas marcadas y el total de la nota
+func checklistProgress(text string) (done, total int) {
+	for _, line := range strings.Split(text, "\n") {
+		if checked, ok := isCheckedLine(line); ok {
+			total++
+			if checked {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// checklistSummary es el avance de las casillas que se agrega a los conteos
+func checklistSummary(text string) string {
+	if done, total := checklistProgress(text); total > 0 {
+		return fmt.Sprintf(" · ☑️ %d/%d hechas", done, total)
+	}
+	return ""
+}
+
+// toggleChecks marca o desmarca las líneas seleccionadas o la línea del cursor
+func (n *NotePad) toggleChecks() {
+	text := n.multiLine.Text
+	lines := strings.Split(text, "\n")
+	first, last := n.multiLine.CursorRow, n.multiLine.CursorRow
+	if selected := n.multiLine.SelectedText(); selected != "" {
+		cursor := rowColToOffset(text, n.multiLine.CursorRow, n.multiLine.CursorColumn)
+		first, last = selectionRows(text, cursor, selected)
+	}
+	if first < 0 || last >= len(lines) {
+		return
+	}
+	copy(lines[first:last+1], toggleCheckLines(lines[first:last+1]))
+
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	n.applyEdit(historyCheck, strings.Join(lines, "\n"))
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+	n.multiLine.Refresh()
+
+	done, total := checklistProgress(n.multiLine.Text)
+	n.statusLabel.SetText(fmt.Sprintf("Estado: %d de %d líneas marcadas como hechas", done, total))
+}
+
+// createCheckButton registra Ctrl+Enter en el editor y devuelve el botón de la barra
+func (n *NotePad) createCheckButton() *widget.Button {
+	n.multiLine.shortcuts[noteShortcut{key: fyne.KeyReturn}] = n.toggleChecks
+	return widget.NewButton("☑️ Marcar", n.toggleChecks)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetCheckbox(t *testing.T) {
+	cases := []struct {
+		line    string
+		checked bool
+		want    string
+	}{
+		{"......0154 LGARCIA 15:04 MGAVINO", false, "[ ] ......0154 LGARCIA 15:04 MGAVINO"},
+		{"[ ] ......0154 LGARCIA", true, "[x] ......0154 LGARCIA"},
+		{"[X] hecho", false, "[ ] hecho"},
+		{"  !......0017 NCRISOSTOMO", true, "  ![x] ......0017 NCRISOSTOMO"},
+		{"![ ] congelada", true, "![x] congelada"},
+	}
+	for _, c := range cases {
+		if got := setCheckbox(c.line, c.checked); got != c.want {
+			t.Errorf("setCheckbox(%q, %v) = %q, se esperaba %q", c.line, c.checked, got, c.want)
+		}
+	}
+}
+
+func TestToggleCheckLines(t *testing.T) {
+	lines := []string{"[x] uno", "", "dos"}
+	checked := toggleCheckLines(lines)
+	if !reflect.DeepEqual(checked, []string{"[x] uno", "", "[x] dos"}) {
+		t.Errorf("con una sin marcar deberían marcarse todas: %q", checked)
+	}
+	if got := toggleCheckLines(checked); !reflect.DeepEqual(got, []string{"[ ] uno", "", "[ ] dos"}) {
+		t.Errorf("todas marcadas deberían desmarcarse: %q", got)
+	}
+}
+
+func TestChecklistProgress(t *testing.T) {
+	text := "[x] ......0154 LGARCIA 15:04 MGAVINO\n[ ] ......0083 JVILCATOMA 15:04 JRIOS\nsin casilla [x]"
+	if done, total := checklistProgress(text); done != 1 || total != 2 {
+		t.Errorf("checklistProgress = %d/%d", done, total)
+	}
+	if checklistSummary("nada") != "" {
+		t.Error("sin casillas no debería mostrarse el avance")
+	}
+	rows := parseReposicionTable(text)
+	if len(rows) != 2 || rows[0].Prefijo != "[x] ......" || rows[0].Codigo != "0154" {
+		t.Errorf("las líneas con casilla deberían seguir en la tabla: %+v", rows)
+	}
+}
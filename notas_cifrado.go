@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	vaultFile          = "cifrado.json"          // Sal y verificador de la contraseña, dentro de la carpeta de notas
+	encryptedMagic     = "BLOCNOTAS-AESGCM-1\n"  // Encabezado de los archivos cifrados
+	vaultVerifier      = "bloc de notas cifrado" // Texto conocido para comprobar la contraseña
+	vaultKDFIterations = 200000
+	vaultMinPassword   = 6
+)
+
+var errNotesLocked = errors.New("la nota está cifrada y el bloc sigue bloqueado")
+
+// noteVault se guarda en cifrado.json. La clave se deriva de la contraseña con PBKDF2 una
+// sola vez al desbloquear; Verificador es vaultVerifier cifrado con esa clave.
+type noteVault struct {
+	Sal         []byte
+	Verificador []byte
+}
+
+func deriveNoteKey(password string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, password, salt, vaultKDFIterations, 32)
+}
+
+// newVault crea la configuración de cifrado para password y devuelve la clave
+func newVault(password string) (noteVault, []byte, error) {
+	if len([]rune(password)) < vaultMinPassword {
+		return noteVault{}, nil, fmt.Errorf("la contraseña debe tener al menos %d caracteres", vaultMinPassword)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return noteVault{}, nil, err
+	}
+	key, err := deriveNoteKey(password, salt)
+	if err != nil {
+		return noteVault{}, nil, err
+	}
+	verifier, err := sealNote(key, []byte(vaultVerifier))
+	if err != nil {
+		return noteVault{}, nil, err
+	}
+	return noteVault{Sal: salt, Verificador: verifier}, key, nil
+}
+
+// unlock devuelve la clave si password es la contraseña del bloc
+func (v noteVault) unlock(password string) ([]byte, error) {
+	key, err := deriveNoteKey(password, v.Sal)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := openNote(key, v.Verificador)
+	if err != nil || string(plain) != vaultVerifier {
+		return nil, fmt.Errorf("contraseña incorrecta")
+	}
+	return key, nil
+}
+
+// sealNote cifra data con AES-GCM; sin clave devuelve data tal cual
+func sealNote(key, data []byte) ([]byte, error) {
+	if key == nil {
+		return data, nil
+	}
+	gcm, err := noteCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(encryptedMagic), nonce...)
+	return gcm.Seal(out, nonce, data, []byte(encryptedMagic)), nil
+}
+
+// openNote descifra lo escrito por sealNote; los archivos sin cifrar se devuelven igual
+func openNote(key, data []byte) ([]byte, error) {
+	if !isEncryptedNote(data) {
+		return data, nil
+	}
+	if key == nil {
+		return nil, errNotesLocked
+	}
+	gcm, err := noteCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data = data[len(encryptedMagic):]
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("archivo cifrado incompleto")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], []byte(encryptedMagic))
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo descifrar (contraseña distinta o archivo dañado)")
+	}
+	return plain, nil
+}
+
+func isEncryptedNote(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedMagic))
+}
+
+func noteCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readNoteFile lee una nota o versión y la descifra si hace falta
+func readNoteFile(path string, key []byte) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return openNote(key, data)
+}
+
+// writeNoteFile escribe una nota o versión, cifrada si hay clave
+func writeNoteFile(path string, data, key []byte) error {
+	sealed, err := sealNote(key, data)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, sealed, 0644)
+}
+
+// recryptNotes vuelve a escribir todas las notas y versiones de dir pasando de la clave
+// from a la clave to (nil significa sin cifrar)
+func recryptNotes(dir string, from, to []byte) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".txt") {
+			return err
+		}
+		data, err := readNoteFile(path, from)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		return writeNoteFile(path, data, to)
+	})
+}
+
+func loadVault() (noteVault, bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(notesDir, vaultFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return noteVault{}, false, nil
+		}
+		return noteVault{}, false, err
+	}
+	var v noteVault
+	if err := json.Unmarshal(data, &v); err != nil {
+		return noteVault{}, false, fmt.Errorf("%s ilegible: %v", vaultFile, err)
+	}
+	return v, true, nil
+}
+
+func saveVault(v noteVault) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(notesDir, vaultFile), data, 0644)
+}
+
+// recrypt cambia la clave de todas las notas sin que el guardado automático escriba en
+// medio; commit guarda o borra cifrado.json y solo si todo sale bien se usa la clave nueva
+func (n *NotePad) recrypt(from, to []byte, commit func() error) error {
+	n.saveMu.Lock()
+	defer n.saveMu.Unlock()
+	if err := recryptNotes(notesDir, from, to); err != nil {
+		return err
+	}
+	if err := commit(); err != nil {
+		return err
+	}
+	n.key = to
+	return nil
+}
+
+// setLocked bloquea el editor mientras falte la contraseña, así nada se guarda sin cifrar
+// encima de una nota cifrada
+func (n *NotePad) setLocked(locked bool) {
+	n.saveMu.Lock()
+	n.locked = locked
+	n.saveMu.Unlock()
+	if locked {
+		n.applyEdit(historySkip, "")
+		n.history.reset()
+		n.refreshHistory()
+		n.multiLine.Disable()
+		n.multiLine.SetPlaceHolder("🔒 Bloc de notas cifrado: pulsa 🔒 Cifrado para desbloquearlo")
+		n.statusLabel.SetText("Estado: Bloqueado")
+		return
+	}
+	n.multiLine.SetPlaceHolder("")
+	n.multiLine.Enable()
+}
+
+// askPassword pide una contraseña en un formulario
+func askPassword(title, confirm string, repeat bool, window fyne.Window, onPassword func(string)) {
+	password := widget.NewPasswordEntry()
+	items := []*widget.FormItem{widget.NewFormItem("Contraseña", password)}
+	again := widget.NewPasswordEntry()
+	if repeat {
+		items = append(items, widget.NewFormItem("Repetir", again))
+	}
+	dialog.ShowForm(title, confirm, "Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if repeat && password.Text != again.Text {
+			dialog.ShowError(fmt.Errorf("las contraseñas no coinciden"), window)
+			return
+		}
+		onPassword(password.Text)
+	}, window)
+}
+
+// unlockNotes pide la contraseña y carga la nota abierta
+func (n *NotePad) unlockNotes(vault noteVault, window fyne.Window) {
+	askPassword("🔒 Desbloquear bloc de notas", "Desbloquear", false, window, func(password string) {
+		key, err := vault.unlock(password)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.saveMu.Lock()
+		n.key = key
+		n.saveMu.Unlock()
+		n.setLocked(false)
+		n.loadContent()
+		n.statusLabel.SetText("Estado: Bloc desbloqueado")
+		n.checkRecovery(window)
+	})
+}
+
+// initEncryption se llama al abrir la pestaña: si el bloc está cifrado lo deja bloqueado
+// hasta que se escriba la contraseña
+func (n *NotePad) initEncryption(window fyne.Window) {
+	vault, ok, err := loadVault()
+	if err != nil {
+		dialog.ShowError(err, window)
+	}
+	if !ok {
+		return
+	}
+	n.setLocked(true)
+	n.unlockNotes(vault, window)
+}
+
+// showEncryption activa, desactiva o desbloquea el cifrado de las notas
+func (n *NotePad) showEncryption(window fyne.Window) {
+	vault, enabled, err := loadVault()
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	if enabled && n.locked {
+		n.unlockNotes(vault, window)
+		return
+	}
+
+	if !enabled {
+		askPassword("🔒 Cifrar notas", "Cifrar", true, window, func(password string) {
+			v, key, err := newVault(password)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.saveContent()
+			if err := n.recrypt(nil, key, func() error { return saveVault(v) }); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			// El bloc único anterior quedó como respaldo sin cifrar; su contenido ya está en la nota inicial
+			if err := os.Remove(legacyNoteFile); err != nil && !os.IsNotExist(err) {
+				dialog.ShowError(fmt.Errorf("no se pudo eliminar %s: %v", legacyNoteFile, err), window)
+			}
+			n.statusLabel.SetText("Estado: Notas cifradas")
+			dialog.ShowInformation("🔒 Notas cifradas",
+				"Las notas y sus versiones quedaron cifradas. Al abrir el programa se pedirá la contraseña; si la olvidas no hay forma de recuperarlas.",
+				window)
+		})
+		return
+	}
+
+	askPassword("🔓 Quitar cifrado", "Quitar cifrado", false, window, func(password string) {
+		if _, err := vault.unlock(password); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.saveContent()
+		err := n.recrypt(n.key, nil, func() error {
+			return os.Remove(filepath.Join(notesDir, vaultFile))
+		})
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.statusLabel.SetText("Estado: Cifrado desactivado")
+	})
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSealOpenNote(t *testing.T) {
+	vault, key, err := newVault("clave-segura")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := []byte("# Guardado: 2026-10-16 09:00:00\nusuario: jrios / clave 1234")
+
+	sealed, err := sealNote(key, plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEncryptedNote(sealed) || bytes.Contains(sealed, []byte("clave 1234")) {
+		t.Fatalf("el archivo cifrado no debería contener el texto: %q", sealed)
+	}
+	if got, err := openNote(key, sealed); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("openNote = %q, %v", got, err)
+	}
+	if _, err := openNote(nil, sealed); err != errNotesLocked {
+		t.Errorf("sin clave debería indicar que está bloqueado: %v", err)
+	}
+
+	// Sin cifrar se lee tal cual, con o sin clave
+	if got, err := openNote(key, plain); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("una nota sin cifrar debería leerse igual: %q, %v", got, err)
+	}
+
+	if _, err := vault.unlock("otra-clave"); err == nil {
+		t.Error("una contraseña incorrecta no debería desbloquear")
+	}
+	unlocked, err := vault.unlock("clave-segura")
+	if err != nil || !bytes.Equal(unlocked, key) {
+		t.Errorf("unlock con la contraseña correcta = %v", err)
+	}
+
+	sealed[len(sealed)-1] ^= 1
+	if _, err := openNote(key, sealed); err == nil {
+		t.Error("un archivo alterado no debería descifrarse")
+	}
+
+	if _, _, err := newVault("corta"); err == nil {
+		t.Error("una contraseña corta debería rechazarse")
+	}
+}
+
+func TestRecryptNotes(t *testing.T) {
+	dir := t.TempDir()
+	versions := filepath.Join(dir, versionsDir, "General")
+	os.MkdirAll(versions, 0755)
+	files := map[string]string{
+		filepath.Join(dir, "General.txt"):                "nota",
+		filepath.Join(versions, "2026-10-16_090000.txt"): "versión",
+		filepath.Join(dir, "guardado.json"):              `{"Activo":true}`,
+	}
+	for path, content := range files {
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	_, key, _ := newVault("clave-segura")
+	if err := recryptNotes(dir, nil, key); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range files {
+		data, _ := os.ReadFile(path)
+		if filepath.Ext(path) == ".json" {
+			if string(data) != content {
+				t.Errorf("%s no debería cifrarse", path)
+			}
+			continue
+		}
+		if !isEncryptedNote(data) {
+			t.Errorf("%s debería quedar cifrado", path)
+		}
+		if got, _ := readNoteFile(path, key); string(got) != content {
+			t.Errorf("%s = %q", path, got)
+		}
+	}
+
+	if err := recryptNotes(dir, key, nil); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "General.txt")); string(data) != "nota" {
+		t.Errorf("al quitar el cifrado debería quedar el texto original: %q", data)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Qué parte de cada línea cambia "Editar en columna"
+const (
+	columnLastField = "Último campo (quién registró)"
+	columnBlock     = "Columnas"
+)
+
+var columnModes = []string{columnLastField, columnBlock}
+
+// selectionBlock devuelve el rectángulo que forma la selección: las filas del primer al
+// último renglón y las columnas entre el inicio y el final, de la menor a la mayor
+func selectionBlock(text string, cursor int, selected string) (firstRow, lastRow, fromCol, toCol int) {
+	start, end := cursor, cursor+len(selected)
+	if cursor >= len(selected) && text[cursor-len(selected):cursor] == selected {
+		start, end = cursor-len(selected), cursor
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	firstRow, startCol := offsetToRowCol(text, start)
+	lastRow, endCol := offsetToRowCol(text, end)
+	if startCol > endCol {
+		startCol, endCol = endCol, startCol
+	}
+	return firstRow, lastRow, startCol, endCol
+}
+
+// replaceLastField cambia la última palabra de line por text, sin tocar los espacios que la
+// separan del resto
+func replaceLastField(line, text string) string {
+	trimmed := strings.TrimRightFunc(line, unicode.IsSpace)
+	start := strings.LastIndexFunc(trimmed, unicode.IsSpace) + 1
+	return trimmed[:start] + text + line[len(trimmed):]
+}
+
+// replaceColumns cambia las columnas [from, to) de line por text; con from == to inserta.
+// Si la línea es más corta se completa con espacios para que el bloque quede alineado.
+func replaceColumns(line string, from, to int, text string) string {
+	runes := []rune(line)
+	for len(runes) < from {
+		runes = append(runes, ' ')
+	}
+	if to > len(runes) {
+		to = len(runes)
+	}
+	return string(runes[:from]) + text + string(runes[to:])
+}
+
+// editColumn aplica la edición a las filas first..last; los títulos, comentarios, líneas
+// vacías y secciones plegadas no se tocan. Devuelve el texto y cuántas líneas cambiaron.
+func editColumn(text string, first, last int, mode string, from, to int, replacement string) (string, int) {
+	lines := strings.Split(text, "\n")
+	changed := 0
+	for i := max(first, 0); i <= last && i < len(lines); i++ {
+		if _, folded := foldID(lines[i]); folded || isFixedLine(lines[i]) {
+			continue
+		}
+		edited := lines[i]
+		if mode == columnLastField {
+			edited = replaceLastField(lines[i], replacement)
+		} else {
+			edited = replaceColumns(lines[i], from, to, replacement)
+		}
+		if edited != lines[i] {
+			lines[i] = edited
+			changed++
+		}
+	}
+	return strings.Join(lines, "\n"), changed
+}
+
+// showColumnEdit cambia lo mismo en varias líneas a la vez: las seleccionadas o, sin
+// selección, las de la sección del cursor. Con una selección el bloque de columnas va desde
+// donde empieza hasta donde termina, como al seleccionar en columna en otros editores.
+func (n *NotePad) showColumnEdit(window fyne.Window) {
+	text := n.multiLine.Text
+	lines := strings.Split(text, "\n")
+	var first, last, from, to int
+	if selected := n.multiLine.SelectedText(); selected != "" {
+		cursor := rowColToOffset(text, n.multiLine.CursorRow, n.multiLine.CursorColumn)
+		first, last, from, to = selectionBlock(text, cursor, selected)
+	} else {
+		first, last = sectionRange(lines, n.multiLine.CursorRow)
+		from, to = n.multiLine.CursorColumn, n.multiLine.CursorColumn
+	}
+	if first > last {
+		n.statusLabel.SetText("Estado: La sección está vacía")
+		return
+	}
+
+	modeSelect := widget.NewRadioGroup(columnModes, nil)
+	fromInput := widget.NewEntry()
+	fromInput.SetText(strconv.Itoa(from + 1))
+	widthInput := widget.NewEntry()
+	widthInput.SetText(strconv.Itoa(to - from))
+	textInput := widget.NewEntry()
+	textInput.SetPlaceHolder("Texto nuevo; vacío borra")
+	summary := widget.NewLabel("")
+	previewScroll := container.NewScroll(widget.NewLabel(""))
+	previewScroll.SetMinSize(fyne.NewSize(620, 260))
+	numberSize := fyne.NewSize(60, fromInput.MinSize().Height)
+	columnsBox := container.NewHBox(
+		widget.NewLabel("Desde la columna"), container.NewGridWrap(numberSize, fromInput),
+		widget.NewLabel("caracteres (0 inserta)"), container.NewGridWrap(numberSize, widthInput),
+	)
+
+	// result arma el texto con la edición; ok es false si las columnas no son válidas
+	result := func() (string, int, bool) {
+		if modeSelect.Selected == columnLastField {
+			edited, changed := editColumn(text, first, last, columnLastField, 0, 0, textInput.Text)
+			return edited, changed, true
+		}
+		col, err1 := strconv.Atoi(strings.TrimSpace(fromInput.Text))
+		width, err2 := strconv.Atoi(strings.TrimSpace(widthInput.Text))
+		if err1 != nil || err2 != nil || col < 1 || width < 0 {
+			return text, 0, false
+		}
+		edited, changed := editColumn(text, first, last, columnBlock, col-1, col-1+width, textInput.Text)
+		return edited, changed, true
+	}
+	update := func() {
+		columnsBox.Hidden = modeSelect.Selected != columnBlock
+		columnsBox.Refresh()
+		edited, changed, ok := result()
+		if !ok {
+			summary.SetText("La columna debe ser un número desde 1 y los caracteres desde 0.")
+			return
+		}
+		summary.SetText(fmt.Sprintf("Cambian %d de las líneas %d a %d", changed, first+1, last+1))
+		diff := diffLines(strings.Join(lines[first:last+1], "\n"), strings.Join(strings.Split(edited, "\n")[first:last+1], "\n"))
+		previewScroll.Content = diffView(diff)
+		previewScroll.Refresh()
+	}
+	modeSelect.OnChanged = func(string) { update() }
+	fromInput.OnChanged = func(string) { update() }
+	widthInput.OnChanged = func(string) { update() }
+	textInput.OnChanged = func(string) { update() }
+	if from != to {
+		modeSelect.SetSelected(columnBlock)
+	} else {
+		modeSelect.SetSelected(columnLastField)
+	}
+
+	content := container.NewBorder(
+		container.NewVBox(modeSelect, columnsBox, textInput, summary), nil, nil, nil,
+		previewScroll,
+	)
+	d := dialog.NewCustomConfirm("▥ Editar en columna", "Aplicar", "Cancelar", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		edited, changed, valid := result()
+		if !valid || changed == 0 {
+			n.statusLabel.SetText("Estado: No cambió ninguna línea")
+			return
+		}
+		cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+		n.applyEdit(historyColumn, edited)
+		n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+		n.multiLine.Refresh()
+		n.statusLabel.SetText(fmt.Sprintf("Estado: %d líneas editadas en columna", changed))
+	}, window)
+	d.Resize(fyne.NewSize(700, 500))
+	d.Show()
+	window.Canvas().Focus(textInput)
+}
@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestSelectionBlock(t *testing.T) {
+	text := "***LISTA***\n......0154 LGARCIA 15:04 MGAVINO\n......0083 JVILCATOMA 15:04 JRIOS"
+	// De "LGARCIA" en la segunda línea hasta "JVILC" en la tercera
+	start := rowColToOffset(text, 1, 11)
+	end := rowColToOffset(text, 2, 16)
+	selected := text[start:end]
+
+	for _, cursor := range []int{end, start} {
+		first, last, from, to := selectionBlock(text, cursor, selected)
+		if first != 1 || last != 2 || from != 11 || to != 16 {
+			t.Errorf("cursor %d: selectionBlock = %d, %d, %d, %d", cursor, first, last, from, to)
+		}
+	}
+}
+
+func TestReplaceLastField(t *testing.T) {
+	tests := []struct{ line, text, want string }{
+		{"......0154 LGARCIA 15:04 MGAVINO", "JRIOS", "......0154 LGARCIA 15:04 JRIOS"},
+		{"......0154 LGARCIA 15:04 MGAVINO  ", "JRIOS", "......0154 LGARCIA 15:04 JRIOS  "},
+		{"MGAVINO", "JRIOS", "JRIOS"},
+	}
+	for _, tt := range tests {
+		if got := replaceLastField(tt.line, tt.text); got != tt.want {
+			t.Errorf("replaceLastField(%q) = %q, quería %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceColumns(t *testing.T) {
+	tests := []struct {
+		line     string
+		from, to int
+		text     string
+		want     string
+	}{
+		{"......0154 LGARCIA", 6, 10, "0083", "......0083 LGARCIA"},
+		{"......0154 LGARCIA", 0, 6, "", "0154 LGARCIA"},
+		{"áé", 1, 1, "X", "áXé"},
+		{"ab", 4, 4, "!", "ab  !"},
+		{"abc", 2, 9, "Z", "abZ"},
+	}
+	for _, tt := range tests {
+		if got := replaceColumns(tt.line, tt.from, tt.to, tt.text); got != tt.want {
+			t.Errorf("replaceColumns(%q, %d, %d) = %q, quería %q", tt.line, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestEditColumn(t *testing.T) {
+	text := "***LISTA***\n" +
+		"......0154 LGARCIA 15:04 MGAVINO\n" +
+		"# comentario\n" +
+		"\n" +
+		"......0083 JVILCATOMA 15:04 JRIOS\n" +
+		"......0017 NCRISOSTOMO 15:04 BTAIPE"
+	got, changed := editColumn(text, 0, 5, columnLastField, 0, 0, "BTAIPE")
+	want := "***LISTA***\n" +
+		"......0154 LGARCIA 15:04 BTAIPE\n" +
+		"# comentario\n" +
+		"\n" +
+		"......0083 JVILCATOMA 15:04 BTAIPE\n" +
+		"......0017 NCRISOSTOMO 15:04 BTAIPE"
+	if got != want || changed != 2 {
+		t.Errorf("editColumn = %q, %d; quería %q, 2", got, changed, want)
+	}
+
+	got, changed = editColumn(text, 1, 1, columnBlock, 0, 6, "!")
+	if changed != 1 || got[:len("***LISTA***\n!0154")] != "***LISTA***\n!0154" {
+		t.Errorf("editColumn por columnas = %q, %d", got, changed)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// mergeNotes junta las líneas de las dos versiones de una nota en el orden en que
+// aparecen. Las líneas que solo difieren en la hora cuentan como iguales y queda la propia,
+// así la actualización automática de horas no duplica toda la lista.
+func mergeNotes(theirs, mine string) string {
+	theirLines := strings.Split(theirs, "\n")
+	myLines := strings.Split(mine, "\n")
+	mask := func(text string) string {
+		return noteTimeRegex.ReplaceAllString(text, "HH:MM")
+	}
+
+	var merged []string
+	i, j := 0, 0
+	for _, line := range diffLines(mask(theirs), mask(mine)) {
+		switch line.Tipo {
+		case diffSame:
+			merged = append(merged, myLines[j])
+			i++
+			j++
+		case diffRemoved:
+			merged = append(merged, theirLines[i])
+			i++
+		case diffAdded:
+			merged = append(merged, myLines[j])
+			j++
+		}
+	}
+	return strings.Join(merged, "\n")
+}
+
+func (n *NotePad) hasConflict() bool {
+	n.saveMu.Lock()
+	defer n.saveMu.Unlock()
+	return n.conflict
+}
+
+// readRemoteNote lee lo que otro equipo guardó en la nota abierta
+func (n *NotePad) readRemoteNote() (string, error) {
+	n.saveMu.Lock()
+	path, key := n.currentFile()
+	n.saveMu.Unlock()
+	data, err := readNoteFile(path, key)
+	if err != nil {
+		return "", err
+	}
+	return stripSaveHeader(string(data)), nil
+}
+
+// showConflict pregunta qué hacer cuando otro equipo guardó la nota mientras aquí había
+// cambios sin guardar. Hasta decidir, el guardado automático no escribe la nota.
+func (n *NotePad) showConflict(theirs string) {
+	window := n.window
+	message := widget.NewLabel(fmt.Sprintf("Otro equipo guardó la nota %q mientras la estabas editando. "+
+		"En rojo lo que solo tiene la suya y en verde lo que solo tiene la tuya. Su versión también quedó en 🕘 Versiones.", n.current))
+	message.Wrapping = fyne.TextWrapWord
+	diffScroll := container.NewScroll(diffView(diffLines(theirs, n.noteText())))
+
+	var d dialog.Dialog
+	mergeButton := widget.NewButton("🔀 Combinar", func() {
+		d.Hide()
+		current, err := n.readRemoteNote()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.applyEdit(historyMerge, mergeNotes(current, n.noteText()))
+		n.saveContent()
+		n.statusLabel.SetText("Estado: Nota combinada con los cambios del otro equipo")
+	})
+	overwriteButton := widget.NewButton("💾 Guardar la mía", func() {
+		d.Hide()
+		n.saveContent()
+		n.statusLabel.SetText("Estado: Guardada tu versión; la del otro equipo está en 🕘 Versiones")
+	})
+	reloadButton := widget.NewButton("🔄 Cargar la suya", func() {
+		d.Hide()
+		n.snapshotVersion(n.noteText(), true)
+		n.loadContent()
+		n.statusLabel.SetText("Estado: Cargada la versión del otro equipo; la tuya está en 🕘 Versiones")
+	})
+
+	content := container.NewBorder(message, container.NewHBox(mergeButton, overwriteButton, reloadButton), nil, nil, diffScroll)
+	d = dialog.NewCustom("⚠️ La nota cambió en otro equipo", "Decidir después", content, window)
+	d.SetOnClosed(func() {
+		if n.hasConflict() {
+			n.statusLabel.SetText("Estado: ⚠️ Sin guardado automático hasta resolver el cambio de otro equipo (💾 Guardar Ahora guarda la tuya)")
+		}
+	})
+	d.Resize(fyne.NewSize(700, 480))
+	d.Show()
+	log.Printf("La nota %q cambió en otro equipo mientras había cambios sin guardar", n.current)
+}
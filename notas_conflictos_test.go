@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestMergeNotes(t *testing.T) {
+	tests := []struct {
+		name, theirs, mine, want string
+	}{
+		{"iguales", "a\nb", "a\nb", "a\nb"},
+		{"cada uno agrega una línea", "a\nx\nb", "a\nb\ny", "a\nx\nb\ny"},
+		{"la hora distinta no duplica y queda la propia",
+			"......0154 LGARCIA 10:15 JRIOS\n......0017 NCRISOSTOMO 10:15 MGAVINO",
+			"......0154 LGARCIA 10:20 JRIOS",
+			"......0154 LGARCIA 10:20 JRIOS\n......0017 NCRISOSTOMO 10:15 MGAVINO"},
+		{"línea cambiada en los dos", "a\nviejo\nc", "a\nnuevo\nc", "a\nviejo\nnuevo\nc"},
+	}
+	for _, tt := range tests {
+		if got := mergeNotes(tt.theirs, tt.mine); got != tt.want {
+			t.Errorf("%s: mergeNotes = %q, quería %q", tt.name, got, tt.want)
+		}
+	}
+}
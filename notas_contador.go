@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Líneas de reposición como "......9999 REPOSICION 15:04 JRIOS"
+var reposicionRegex = regexp.MustCompile(`(?i)\breposici[oó]n\b`)
+
+// noteStats son los conteos que se muestran en la tarjeta de estado
+type noteStats struct {
+	Lineas       int
+	Palabras     int
+	Caracteres   int
+	Reposiciones int
+}
+
+// countNote cuenta el texto del editor. Los títulos entre asteriscos y los comentarios
+// con # no cuentan como reposiciones aunque digan REPOSICIÓN.
+func countNote(text string) noteStats {
+	stats := noteStats{
+		Palabras:   len(strings.Fields(text)),
+		Caracteres: utf8.RuneCountInString(text),
+	}
+	if text == "" {
+		return stats
+	}
+	for _, line := range strings.Split(text, "\n") {
+		stats.Lineas++
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || starTitleRegex.MatchString(trimmed) {
+			continue
+		}
+		if reposicionRegex.MatchString(line) {
+			stats.Reposiciones++
+		}
+	}
+	return stats
+}
+
+func (s noteStats) String() string {
+	return fmt.Sprintf("%d líneas · %d palabras · %d caracteres · %d reposiciones",
+		s.Lineas, s.Palabras, s.Caracteres, s.Reposiciones)
+}
+
+// updateCounts actualiza los conteos de la tarjeta de estado con los avisos de casillas,
+// códigos repetidos y el próximo recordatorio
+func (n *NotePad) updateCounts() {
+	if n.countLabel != nil {
+		n.countLabel.SetText("📏 " + countNote(n.multiLine.Text).String() +
+			checklistSummary(n.multiLine.Text) + duplicatesSummary(n.multiLine.Text) +
+			nextReminder(n.multiLine.Text, time.Now()))
+	}
+	n.refreshUserStats()
+}
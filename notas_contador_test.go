@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestCountNote(t *testing.T) {
+	text := "***********LISTA REPOSICIÓN*********\n" +
+		"......9999 REPOSICION 15:04 MGAVINO\n" +
+		"......9999 Reposición 15:04 JRIOS\n" +
+		"\n" +
+		"......0154 LGARCIA 15:04 MGAVINO\n" +
+		"# Cada REPOSICION se envía al final del turno\n" +
+		"REPOSICIONES pendientes: ninguna"
+
+	got := countNote(text)
+	want := noteStats{Lineas: 7, Palabras: 26, Caracteres: len([]rune(text)), Reposiciones: 2}
+	if got != want {
+		t.Errorf("countNote = %+v, se esperaba %+v", got, want)
+	}
+
+	if got := countNote(""); got != (noteStats{}) {
+		t.Errorf("un texto vacío debería dar ceros: %+v", got)
+	}
+	if got := countNote("una línea"); got.Lineas != 1 || got.Palabras != 2 || got.Caracteres != 9 {
+		t.Errorf("countNote de una línea = %+v", got)
+	}
+}
@@ -0,0 +1,461 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	mailSettingsFile = "correo_notas.json" // Junto al programa: la cuenta de correo es de cada equipo
+	mailTimeout      = 30 * time.Second
+)
+
+// NoteMailSettings es la cuenta SMTP y la lista a la que se envía la nota. En Asunto,
+// {nota} se cambia por el nombre de la nota y {fecha} por la fecha del envío.
+type NoteMailSettings struct {
+	Servidor      string
+	Puerto        int // 465 usa TLS directo; los demás, STARTTLS si el servidor lo ofrece
+	Usuario       string
+	Clave         string `json:"-"` // No se guarda: se pide una vez por sesión
+	Remitente     string
+	Destinatarios string // Direcciones separadas por comas o punto y coma
+	Asunto        string
+	AdjuntarPDF   bool
+}
+
+func defaultMailSettings() NoteMailSettings {
+	return NoteMailSettings{Puerto: 587, Asunto: "Notas {nota} {fecha}"}
+}
+
+func (s NoteMailSettings) validate() error {
+	if strings.TrimSpace(s.Servidor) == "" {
+		return fmt.Errorf("falta el servidor de correo")
+	}
+	if s.Puerto < 1 || s.Puerto > 65535 {
+		return fmt.Errorf("el puerto debe estar entre 1 y 65535")
+	}
+	if _, err := mail.ParseAddress(s.Remitente); err != nil {
+		return fmt.Errorf("remitente inválido: %q", s.Remitente)
+	}
+	if _, err := parseRecipients(s.Destinatarios); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseRecipients lee una lista de direcciones separadas por comas, punto y coma o saltos
+// de línea, como se copian de Outlook
+func parseRecipients(list string) ([]string, error) {
+	var recipients []string
+	for _, field := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(field)
+		if err != nil {
+			return nil, fmt.Errorf("dirección inválida: %q", field)
+		}
+		recipients = append(recipients, addr.Address)
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("falta al menos un destinatario")
+	}
+	return recipients, nil
+}
+
+// mailSubject arma el asunto con el nombre de la nota y la fecha del envío
+func mailSubject(template, note string, now time.Time) string {
+	return strings.NewReplacer("{nota}", note, "{fecha}", now.Format("02/01/2006")).Replace(template)
+}
+
+// buildMail arma el mensaje con la nota como texto; si pdf no es nil va adjunto con el
+// nombre pdfName
+func buildMail(from string, to []string, subject, body string, pdf []byte, pdfName string, now time.Time) ([]byte, error) {
+	if addr, err := mail.ParseAddress(from); err == nil {
+		from = addr.String() // Codifica los nombres con tildes
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	text := strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+	if pdf == nil {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuoted(&buf, text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", parts.Boundary())
+
+	textPart, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeQuoted(textPart, text); err != nil {
+		return nil, err
+	}
+
+	pdfPart, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType("application/pdf", map[string]string{"name": pdfName})},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": pdfName})},
+	})
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(pdf)
+	for len(encoded) > 76 {
+		fmt.Fprintf(pdfPart, "%s\r\n", encoded[:76])
+		encoded = encoded[76:]
+	}
+	fmt.Fprintf(pdfPart, "%s\r\n", encoded)
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuoted(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(text)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// notePDF arma un PDF A4 con el título y las líneas de la nota; los títulos entre
+// asteriscos van en negrita. Usa la letra DejaVu del Rótulo si está, si no Courier.
+func notePDF(title, text string, now time.Time) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	fontFamily := "Courier"
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	if _, err := os.Stat("fonts/DejaVuSans.ttf"); err == nil {
+		pdf.AddUTF8Font("DejaVu", "", "fonts/DejaVuSans.ttf")
+		pdf.AddUTF8Font("DejaVu", "B", "fonts/DejaVuSans-Bold.ttf")
+		fontFamily = "DejaVu"
+		tr = func(s string) string { return s }
+	}
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	pdf.SetFont(fontFamily, "B", 13)
+	pdf.CellFormat(0, 8, tr(title), "", 1, "", false, 0, "")
+	pdf.SetFont(fontFamily, "", 8)
+	pdf.CellFormat(0, 5, tr("Enviado el "+now.Format("02/01/2006 15:04")), "", 1, "", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont(fontFamily, "", 9)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if starTitleRegex.MatchString(strings.TrimSpace(line)) {
+			pdf.SetFont(fontFamily, "B", 9)
+			pdf.MultiCell(0, 4.5, tr(line), "", "", false)
+			pdf.SetFont(fontFamily, "", 9)
+			continue
+		}
+		pdf.MultiCell(0, 4.5, tr(line), "", "", false)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("error generando PDF: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sendMail entrega msg por SMTP. Con el puerto 465 la conexión es TLS desde el inicio; con
+// los demás se pasa a TLS si el servidor lo ofrece.
+func sendMail(settings NoteMailSettings, to []string, msg []byte) error {
+	host := strings.TrimSpace(settings.Servidor)
+	addr := net.JoinHostPort(host, strconv.Itoa(settings.Puerto))
+	dialer := &net.Dialer{Timeout: mailTimeout}
+
+	var conn net.Conn
+	var err error
+	if settings.Puerto == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("no se pudo conectar con %s: %v", addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(mailTimeout))
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && settings.Puerto != 465 {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("no se pudo iniciar TLS: %v", err)
+		}
+	}
+	if settings.Usuario != "" {
+		if err := client.Auth(smtp.PlainAuth("", settings.Usuario, settings.Clave, host)); err != nil {
+			return mailAuthError{err}
+		}
+	}
+	from, _ := mail.ParseAddress(settings.Remitente)
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("destinatario rechazado %s: %v", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// mailAuthError es el rechazo del usuario o la clave; la clave de la sesión se vuelve a pedir
+type mailAuthError struct{ err error }
+
+func (e mailAuthError) Error() string {
+	return fmt.Sprintf("usuario o clave rechazados: %v", e.err)
+}
+
+func loadMailSettings() NoteMailSettings {
+	settings := defaultMailSettings()
+	data, err := ioutil.ReadFile(mailSettingsFile)
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", mailSettingsFile, err)
+		return defaultMailSettings()
+	}
+	// Las versiones anteriores guardaban la clave sin cifrar; se vuelve a escribir sin ella
+	var old struct{ Clave string }
+	if json.Unmarshal(data, &old) == nil && old.Clave != "" {
+		if err := saveMailSettings(settings); err != nil {
+			log.Printf("Error quitando la clave de %s: %v", mailSettingsFile, err)
+		}
+	}
+	return settings
+}
+
+func saveMailSettings(settings NoteMailSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(mailSettingsFile, data, 0600)
+}
+
+// mailNoteName es el nombre de la nota abierta para el asunto y el PDF
+func (n *NotePad) mailNoteName() string {
+	if n.external {
+		return strings.TrimSuffix(filepath.Base(n.current), filepath.Ext(n.current))
+	}
+	return n.current
+}
+
+// showMailSettings edita la cuenta de correo; onSaved se llama con los ajustes guardados
+func (n *NotePad) showMailSettings(window fyne.Window, onSaved func(NoteMailSettings)) {
+	current := loadMailSettings()
+
+	serverInput := widget.NewEntry()
+	serverInput.SetPlaceHolder("smtp.office365.com")
+	serverInput.SetText(current.Servidor)
+	portInput := widget.NewEntry()
+	portInput.SetText(strconv.Itoa(current.Puerto))
+	userInput := widget.NewEntry()
+	userInput.SetText(current.Usuario)
+	passwordInput := widget.NewPasswordEntry()
+	passwordInput.SetPlaceHolder("Se pide una vez por sesión; no se guarda")
+	passwordInput.SetText(n.mailClave)
+	fromInput := widget.NewEntry()
+	fromInput.SetPlaceHolder("Soporte <soporte@empresa.com>")
+	fromInput.SetText(current.Remitente)
+	toInput := widget.NewMultiLineEntry()
+	toInput.SetPlaceHolder("jefe@empresa.com; almacen@empresa.com")
+	toInput.SetText(current.Destinatarios)
+	subjectInput := widget.NewEntry()
+	subjectInput.SetText(current.Asunto)
+	pdfCheck := widget.NewCheck("Adjuntar la nota en PDF", nil)
+	pdfCheck.SetChecked(current.AdjuntarPDF)
+
+	d := dialog.NewForm("✉️ Cuenta de correo", "Guardar", "Cancelar",
+		[]*widget.FormItem{
+			widget.NewFormItem("Servidor SMTP", serverInput),
+			widget.NewFormItem("Puerto", portInput),
+			widget.NewFormItem("Usuario", userInput),
+			widget.NewFormItem("Clave", passwordInput),
+			widget.NewFormItem("Remitente", fromInput),
+			widget.NewFormItem("Destinatarios", toInput),
+			widget.NewFormItem("Asunto", subjectInput),
+			widget.NewFormItem("", pdfCheck),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			port, err := strconv.Atoi(strings.TrimSpace(portInput.Text))
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("el puerto debe ser un número"), window)
+				return
+			}
+			settings := NoteMailSettings{
+				Servidor:      strings.TrimSpace(serverInput.Text),
+				Puerto:        port,
+				Usuario:       strings.TrimSpace(userInput.Text),
+				Clave:         passwordInput.Text,
+				Remitente:     strings.TrimSpace(fromInput.Text),
+				Destinatarios: toInput.Text,
+				Asunto:        subjectInput.Text,
+				AdjuntarPDF:   pdfCheck.Checked,
+			}
+			if err := settings.validate(); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := saveMailSettings(settings); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.mailClave = settings.Clave
+			n.statusLabel.SetText("Estado: Cuenta de correo guardada")
+			if onSaved != nil {
+				onSaved(settings)
+			}
+		}, window)
+	d.Resize(fyne.NewSize(480, 520))
+	d.Show()
+}
+
+// showSendMail confirma el envío de la nota abierta a la lista de distribución. Sin cuenta
+// configurada abre primero sus ajustes.
+func (n *NotePad) showSendMail(window fyne.Window) {
+	if n.current == "" || n.locked {
+		return
+	}
+	settings := loadMailSettings()
+	if settings.validate() != nil {
+		n.showMailSettings(window, func(NoteMailSettings) { n.showSendMail(window) })
+		return
+	}
+
+	note := n.mailNoteName()
+	text := n.noteText()
+	toInput := widget.NewMultiLineEntry()
+	toInput.SetText(settings.Destinatarios)
+	subjectInput := widget.NewEntry()
+	subjectInput.SetText(mailSubject(settings.Asunto, note, time.Now()))
+	pdfCheck := widget.NewCheck("Adjuntar la nota en PDF", nil)
+	pdfCheck.SetChecked(settings.AdjuntarPDF)
+	accountButton := widget.NewButton("⚙️ Cuenta de correo", nil)
+	items := []*widget.FormItem{
+		widget.NewFormItem("Para", toInput),
+		widget.NewFormItem("Asunto", subjectInput),
+	}
+	// La clave no está en correo_notas.json: la primera vez en la sesión se pide aquí
+	passwordInput := widget.NewPasswordEntry()
+	passwordInput.SetText(n.mailClave)
+	if settings.Usuario != "" && n.mailClave == "" {
+		items = append(items, widget.NewFormItem("Clave de "+settings.Usuario, passwordInput))
+	}
+	items = append(items, widget.NewFormItem("", pdfCheck), widget.NewFormItem("", accountButton))
+
+	d := dialog.NewForm("✉️ Enviar por correo", "Enviar", "Cancelar", items,
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			to, err := parseRecipients(toInput.Text)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.mailClave = passwordInput.Text
+			settings.Clave = n.mailClave
+			n.sendNoteMail(settings, to, subjectInput.Text, note, text, pdfCheck.Checked)
+		}, window)
+	accountButton.OnTapped = func() {
+		d.Hide()
+		n.showMailSettings(window, func(NoteMailSettings) { n.showSendMail(window) })
+	}
+	d.Resize(fyne.NewSize(480, 320))
+	d.Show()
+}
+
+// sendNoteMail arma y envía el correo sin trabar la ventana; el resultado queda en el estado
+func (n *NotePad) sendNoteMail(settings NoteMailSettings, to []string, subject, note, text string, withPDF bool) {
+	n.statusLabel.SetText(fmt.Sprintf("Estado: ✉️ Enviando la nota a %d destinatarios...", len(to)))
+	window := n.window
+	go func() {
+		err := func() error {
+			now := time.Now()
+			var pdf []byte
+			if withPDF {
+				var err error
+				if pdf, err = notePDF(subject, text, now); err != nil {
+					return err
+				}
+			}
+			msg, err := buildMail(settings.Remitente, to, subject, text, pdf, note+".pdf", now)
+			if err != nil {
+				return err
+			}
+			return sendMail(settings, to, msg)
+		}()
+		fyne.Do(func() {
+			if err != nil {
+				log.Printf("Error enviando la nota por correo: %v", err)
+				if _, ok := err.(mailAuthError); ok {
+					n.mailClave = ""
+				}
+				n.statusLabel.SetText("Estado: ⚠️ No se pudo enviar el correo")
+				dialog.ShowError(err, window)
+				return
+			}
+			n.statusLabel.SetText(fmt.Sprintf("Estado: ✉️ Nota %q enviada a %s", note, strings.Join(to, ", ")))
+		})
+	}()
+}
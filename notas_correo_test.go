@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRecipients(t *testing.T) {
+	got, err := parseRecipients("jefe@empresa.com; Almacén <almacen@empresa.com>,\n soporte@empresa.com ;")
+	want := []string{"jefe@empresa.com", "almacen@empresa.com", "soporte@empresa.com"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRecipients = %q, %v; quería %q", got, err, want)
+	}
+	if _, err := parseRecipients(" ; "); err == nil {
+		t.Error("una lista vacía debería dar error")
+	}
+	if _, err := parseRecipients("jefe@empresa.com; almacen"); err == nil {
+		t.Error("una dirección inválida debería dar error")
+	}
+}
+
+func TestMailSettingsValidate(t *testing.T) {
+	ok := NoteMailSettings{Servidor: "smtp.empresa.com", Puerto: 587, Remitente: "soporte@empresa.com", Destinatarios: "jefe@empresa.com"}
+	if err := ok.validate(); err != nil {
+		t.Errorf("ajustes válidos dieron error: %v", err)
+	}
+	bad := []NoteMailSettings{
+		{Puerto: 587, Remitente: ok.Remitente, Destinatarios: ok.Destinatarios},
+		{Servidor: ok.Servidor, Puerto: 0, Remitente: ok.Remitente, Destinatarios: ok.Destinatarios},
+		{Servidor: ok.Servidor, Puerto: 587, Remitente: "soporte", Destinatarios: ok.Destinatarios},
+		{Servidor: ok.Servidor, Puerto: 587, Remitente: ok.Remitente},
+	}
+	for i, s := range bad {
+		if err := s.validate(); err == nil {
+			t.Errorf("caso %d: se esperaba error para %+v", i, s)
+		}
+	}
+}
+
+func TestMailSubject(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.Local)
+	if got := mailSubject("Notas {nota} {fecha}", "2026-10-16", now); got != "Notas 2026-10-16 16/10/2026" {
+		t.Errorf("mailSubject = %q", got)
+	}
+}
+
+func TestMailSettingsKeepClaveOut(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	settings := NoteMailSettings{Servidor: "smtp.empresa.com", Puerto: 587, Usuario: "soporte", Clave: "secreta"}
+	if err := saveMailSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadFile(mailSettingsFile)
+	if bytes.Contains(data, []byte("secreta")) {
+		t.Errorf("la clave quedó en %s: %s", mailSettingsFile, data)
+	}
+
+	// Un archivo de una versión anterior, con la clave, se vuelve a escribir sin ella
+	if err := ioutil.WriteFile(mailSettingsFile, []byte(`{"Servidor": "smtp.empresa.com", "Puerto": 465, "Clave": "secreta"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadMailSettings(); got.Servidor != "smtp.empresa.com" || got.Puerto != 465 || got.Clave != "" {
+		t.Errorf("loadMailSettings = %+v", got)
+	}
+	data, _ = ioutil.ReadFile(mailSettingsFile)
+	if bytes.Contains(data, []byte("secreta")) || !bytes.Contains(data, []byte("smtp.empresa.com")) {
+		t.Errorf("%s quedó así: %s", mailSettingsFile, data)
+	}
+}
+
+func TestBuildMail(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.Local)
+	body := "***LISTA REPOSICIÓN***\n......0154 LGARCIA 15:04 JRIOS"
+	to := []string{"jefe@empresa.com", "almacen@empresa.com"}
+
+	data, err := buildMail("Soporte Técnico <soporte@empresa.com>", to, "Notas del día ñ", body, nil, "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Notas del día ñ" {
+		t.Errorf("asunto = %q", subject)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || from[0].Name != "Soporte Técnico" {
+		t.Errorf("remitente = %v, %v", from, err)
+	}
+	text, _ := ioutil.ReadAll(quotedprintable.NewReader(msg.Body))
+	if string(text) != strings.ReplaceAll(body, "\n", "\r\n") {
+		t.Errorf("cuerpo = %q", text)
+	}
+
+	pdf := []byte("%PDF-1.3 prueba")
+	data, err = buildMail("soporte@empresa.com", to, "Notas", body, pdf, "2026-10-16.pdf", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err = mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	if _, err := reader.NextPart(); err != nil {
+		t.Fatalf("falta la parte de texto: %v", err)
+	}
+	attachment, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("falta el adjunto: %v", err)
+	}
+	if attachment.FileName() != "2026-10-16.pdf" {
+		t.Errorf("nombre del adjunto = %q", attachment.FileName())
+	}
+	got, _ := ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	if !bytes.Equal(got, pdf) {
+		t.Errorf("adjunto = %q", got)
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("sobran partes: %v", err)
+	}
+}
+
+func TestNotePDF(t *testing.T) {
+	data, err := notePDF("Notas 2026-10-16", "***LISTA REPOSICIÓN***\n......0154 LGARCIA 15:04 JRIOS\n\n# comentario", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Errorf("no parece un PDF: %q", data[:10])
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	dailySettingsFile = "diario.json" // Dentro de la carpeta de notas: el modo es del equipo
+	dailyNameLayout   = "2006-01-02"  // Las notas diarias se llaman notas/2025-05-27.txt
+	dailyLabelLayout  = "02/01/2006"
+	dailyCheckEvery   = 30 * time.Second
+)
+
+// dailySettings guarda si está activo el archivo diario
+type dailySettings struct {
+	Activo bool
+}
+
+func loadDailySettings() dailySettings {
+	var settings dailySettings
+	data, err := ioutil.ReadFile(filepath.Join(notesDir, dailySettingsFile))
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", dailySettingsFile, err)
+		return dailySettings{}
+	}
+	return settings
+}
+
+func saveDailySettings(settings dailySettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(notesDir, dailySettingsFile), data, 0644)
+}
+
+func dailyNoteName(day time.Time) string {
+	return day.Format(dailyNameLayout)
+}
+
+func isDailyNote(name string) bool {
+	_, err := time.Parse(dailyNameLayout, name)
+	return err == nil
+}
+
+// splitDailyNotes separa las notas diarias (la más reciente primero) de las demás
+func splitDailyNotes(names []string) (regular, days []string) {
+	for _, name := range names {
+		if isDailyNote(name) {
+			days = append(days, name)
+		} else {
+			regular = append(regular, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(days)))
+	return regular, days
+}
+
+// dayLabel muestra el nombre de una nota diaria como fecha: 2025-05-27 da 27/05/2025
+func dayLabel(name string) string {
+	day, err := time.Parse(dailyNameLayout, name)
+	if err != nil {
+		return name
+	}
+	return day.Format(dailyLabelLayout)
+}
+
+// dayFromLabel es la inversa de dayLabel
+func dayFromLabel(label string) (string, bool) {
+	day, err := time.Parse(dailyLabelLayout, label)
+	if err != nil {
+		return "", false
+	}
+	return dailyNoteName(day), true
+}
+
+// refreshDays actualiza la lista de días con las notas diarias de la carpeta
+func (n *NotePad) refreshDays() {
+	if n.daySelect == nil {
+		return
+	}
+	labels := make([]string, 0, len(n.days)+1)
+	if today := dailyNoteName(time.Now()); len(n.days) == 0 || n.days[0] != today {
+		labels = append(labels, dayLabel(today)) // Hoy aparece aunque todavía no se haya guardado
+	}
+	for _, name := range n.days {
+		labels = append(labels, dayLabel(name))
+	}
+	n.daySelect.Options = labels
+	if isDailyNote(n.current) {
+		n.daySelect.Selected = dayLabel(n.current)
+	} else {
+		n.daySelect.Selected = ""
+	}
+	n.daySelect.Refresh()
+	n.loadDayStats()
+}
+
+// openToday abre la nota del día; si todavía no existe empieza con la plantilla de siempre
+func (n *NotePad) openToday() {
+	n.today = dailyNoteName(time.Now())
+	n.switchNote(n.today)
+	n.refreshNotes()
+}
+
+// setDaily activa o desactiva el archivo diario
+func (n *NotePad) setDaily(on bool, window fyne.Window) {
+	if err := saveDailySettings(dailySettings{Activo: on}); err != nil {
+		dialog.ShowError(err, window)
+	}
+	n.daily = on
+	n.refreshNotes()
+	if on {
+		n.openToday()
+		n.statusLabel.SetText(fmt.Sprintf("Estado: Archivo diario activo, nota del %s", dayLabel(n.today)))
+	} else {
+		n.statusLabel.SetText("Estado: Archivo diario desactivado")
+	}
+}
+
+// startDailyRollover pasa a la nota del día nuevo después de medianoche, salvo que se esté
+// revisando otro día
+func (n *NotePad) startDailyRollover() {
+	ticker := time.NewTicker(dailyCheckEvery)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		fyne.Do(func() {
+			today := dailyNoteName(time.Now())
+			if !n.daily || today == n.today {
+				return
+			}
+			previous := n.today
+			n.today = today
+			if n.current == previous {
+				n.openToday()
+				n.statusLabel.SetText(fmt.Sprintf("Estado: Nuevo día, nota del %s", dayLabel(today)))
+			} else {
+				n.refreshNotes()
+			}
+		})
+	}
+}
+
+// createDailyBox arma la casilla del archivo diario y la lista de días para la barra de notas
+func (n *NotePad) createDailyBox(window fyne.Window) fyne.CanvasObject {
+	n.daySelect = widget.NewSelect(nil, func(label string) {
+		if name, ok := dayFromLabel(label); ok && name != n.current {
+			n.switchNote(name)
+			n.notesList.UnselectAll()
+		}
+	})
+	n.daySelect.PlaceHolder = "📅 Día..."
+	n.refreshDays()
+
+	dailyCheck := widget.NewCheck("Archivo diario", nil)
+	dailyCheck.SetChecked(n.daily)
+	dailyCheck.OnChanged = func(on bool) { n.setDaily(on, window) }
+
+	return container.NewVBox(dailyCheck, n.daySelect)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitDailyNotes(t *testing.T) {
+	regular, days := splitDailyNotes([]string{"2025-05-26", "General", "2025-05-27", "2025-13-01", "Pendientes"})
+	if !reflect.DeepEqual(regular, []string{"General", "2025-13-01", "Pendientes"}) {
+		t.Errorf("notas normales = %q", regular)
+	}
+	if !reflect.DeepEqual(days, []string{"2025-05-27", "2025-05-26"}) {
+		t.Errorf("días = %q", days)
+	}
+}
+
+func TestDayLabel(t *testing.T) {
+	day := time.Date(2025, 5, 27, 23, 59, 0, 0, time.Local)
+	name := dailyNoteName(day)
+	if name != "2025-05-27" || !isDailyNote(name) {
+		t.Errorf("dailyNoteName = %q", name)
+	}
+	if label := dayLabel(name); label != "27/05/2025" {
+		t.Errorf("dayLabel = %q", label)
+	}
+	if back, ok := dayFromLabel("27/05/2025"); !ok || back != name {
+		t.Errorf("dayFromLabel = %q, %v", back, ok)
+	}
+	if _, ok := dayFromLabel("📅 Día..."); ok {
+		t.Error("un texto que no es fecha no debería aceptarse")
+	}
+}
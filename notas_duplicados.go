@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// placeholderCode es el código de relleno de las líneas de LISTA REPOSICIÓN; se repite a
+// propósito y no cuenta como duplicado
+const placeholderCode = "9999"
+
+// duplicateLine es una línea de reposición cuyo código ya apareció antes en la misma
+// sección; Linea y Primera cuentan desde 0
+type duplicateLine struct {
+	Linea   int
+	Primera int
+	Seccion string
+	Codigo  string
+}
+
+func (d duplicateLine) String() string {
+	section := d.Seccion
+	if section == "" {
+		section = "sin sección"
+	}
+	return fmt.Sprintf("Línea %d: %s ya está en la línea %d (%s)", d.Linea+1, d.Codigo, d.Primera+1, section)
+}
+
+// findDuplicates busca los códigos registrados más de una vez dentro de una sección. Un
+// mismo código en secciones distintas no es un duplicado.
+func findDuplicates(text string) []duplicateLine {
+	type sectionCode struct{ section, code string }
+	first := map[sectionCode]int{}
+	var dups []duplicateLine
+	for _, row := range parseReposicionTable(text) {
+		if row.Codigo == placeholderCode {
+			continue
+		}
+		key := sectionCode{row.Seccion, strings.ToUpper(row.Codigo)}
+		if line, ok := first[key]; ok {
+			dups = append(dups, duplicateLine{Linea: row.Linea, Primera: line, Seccion: row.Seccion, Codigo: row.Codigo})
+			continue
+		}
+		first[key] = row.Linea
+	}
+	return dups
+}
+
+// removeDuplicates quita las repeticiones y deja la primera línea de cada código
+func removeDuplicates(text string, dups []duplicateLine) string {
+	drop := map[int]bool{}
+	for _, d := range dups {
+		drop[d.Linea] = true
+	}
+	var kept []string
+	for i, line := range strings.Split(text, "\n") {
+		if !drop[i] {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// duplicatesSummary es el aviso que se agrega a los conteos de la tarjeta de estado
+func duplicatesSummary(text string) string {
+	if dups := findDuplicates(text); len(dups) > 0 {
+		return fmt.Sprintf(" · ⚠️ %d códigos repetidos", len(dups))
+	}
+	return ""
+}
+
+// showDuplicates lista los códigos repetidos; elegir uno lleva a su línea
+func (n *NotePad) showDuplicates(window fyne.Window) {
+	dups := findDuplicates(n.multiLine.Text)
+	if len(dups) == 0 {
+		dialog.ShowInformation("🔁 Códigos repetidos", "No hay códigos repetidos dentro de una misma sección.", window)
+		return
+	}
+
+	var d dialog.Dialog
+	list := widget.NewList(
+		func() int {
+			return len(dups)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(dups[id].String())
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		d.Hide()
+		n.goToRow(dups[id].Linea, window)
+	}
+
+	removeButton := widget.NewButton("🧹 Quitar repetidos", func() {
+		d.Hide()
+		current := findDuplicates(n.multiLine.Text)
+		n.applyEdit(historyDups, removeDuplicates(n.multiLine.Text, current))
+		n.statusLabel.SetText(fmt.Sprintf("Estado: %d líneas repetidas eliminadas", len(current)))
+	})
+
+	help := widget.NewLabel("Se conserva la primera línea de cada código y se quitan las siguientes. Ctrl+Z lo deshace.")
+	help.Wrapping = fyne.TextWrapWord
+	d = dialog.NewCustom("🔁 Códigos repetidos", "Cerrar",
+		container.NewBorder(nil, container.NewVBox(help, removeButton), nil, nil, list), window)
+	d.Resize(fyne.NewSize(520, 360))
+	d.Show()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	text := "***LISTA REPOSICIÓN***\n" +
+		"......9999 REPOSICION 15:04 MGAVINO\n" +
+		"......9999 REPOSICION 15:04 JRIOS\n" +
+		"***ZETTACOM***\n" +
+		"......0154 LGARCIA 15:04 MGAVINO\n" +
+		"......0154 LGARCIA 15:04 JRIOS\n" +
+		"......0083 JVILCATOMA 15:04 MGAVINO\n" +
+		"***OTRA***\n" +
+		"......0083 JVILCATOMA 15:04 JRIOS"
+
+	want := []duplicateLine{{Linea: 5, Primera: 4, Seccion: "ZETTACOM", Codigo: "0154"}}
+	dups := findDuplicates(text)
+	if !reflect.DeepEqual(dups, want) {
+		t.Fatalf("findDuplicates = %+v", dups)
+	}
+	if got := dups[0].String(); got != "Línea 6: 0154 ya está en la línea 5 (ZETTACOM)" {
+		t.Errorf("String = %q", got)
+	}
+
+	cleaned := removeDuplicates(text, dups)
+	if len(findDuplicates(cleaned)) != 0 {
+		t.Error("después de quitar los repetidos no deberían quedar")
+	}
+	if countNote(cleaned).Lineas != countNote(text).Lineas-1 {
+		t.Errorf("solo debería quitarse una línea: %q", cleaned)
+	}
+	if duplicatesSummary(cleaned) != "" || duplicatesSummary(text) == "" {
+		t.Error("el aviso debería aparecer solo con repetidos")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// Horas y fechas del texto, que no son códigos aunque tengan dígitos
+	noteTimeRegex = regexp.MustCompile(`\b\d{1,2}:\d{2}(:\d{2})?\b`)
+	noteDateRegex = regexp.MustCompile(`\b\d{1,2}[/\-.]\d{1,2}[/\-.]\d{2,4}\b`)
+)
+
+// Dígitos mínimos para considerar una palabra como código (0154, ZET00154)
+const minCodeDigits = 3
+
+// extractCodes saca de las líneas los códigos a copiar, sin repetir y en orden de
+// aparición: "REPOSICION 15:30 JRIOS 0154" da 0154
+func extractCodes(text string) []string {
+	var codes []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		line = noteTimeRegex.ReplaceAllString(line, " ")
+		line = noteDateRegex.ReplaceAllString(line, " ")
+
+		words := strings.FieldsFunc(line, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+		})
+		for _, w := range words {
+			w = strings.Trim(w, "-")
+			digits := 0
+			for _, r := range w {
+				if unicode.IsDigit(r) {
+					digits++
+				}
+			}
+			if digits < minCodeDigits || seen[w] {
+				continue
+			}
+			seen[w] = true
+			codes = append(codes, w)
+		}
+	}
+	return codes
+}
+
+// noteLines devuelve el texto seleccionado o, si no hay selección, la línea del cursor
+func (n *NotePad) noteLines() string {
+	if selected := n.multiLine.SelectedText(); selected != "" {
+		return selected
+	}
+	lines := strings.Split(n.multiLine.Text, "\n")
+	if row := n.multiLine.CursorRow; row >= 0 && row < len(lines) {
+		return lines[row]
+	}
+	return ""
+}
+
+// sendToAutocopiador envía los códigos de las líneas seleccionadas a la lista de series
+func (n *NotePad) sendToAutocopiador() {
+	codes := extractCodes(n.noteLines())
+	if len(codes) == 0 {
+		n.statusLabel.SetText("Estado: No se encontraron códigos en la selección")
+		return
+	}
+	if n.onSendSeries != nil {
+		n.onSendSeries(codes)
+	}
+	n.statusLabel.SetText(fmt.Sprintf("Estado: %d códigos enviados al Autocopiador", len(codes)))
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "línea con hora", text: "REPOSICION 15:30 JRIOS 0154", want: []string{"0154"}},
+		{
+			name: "varias líneas sin repetir",
+			text: "0154 revisar\nZET00154, 0083 - 12/05/2025\n0154 otra vez",
+			want: []string{"0154", "ZET00154", "0083"},
+		},
+		{name: "números cortos y palabras", text: "caja 12 de 3 items", want: nil},
+		{name: "código con guion", text: "(AB-1234)", want: []string{"AB-1234"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractCodes(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractCodes(%q) = %q, se esperaba %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	userStatsDays     = 7   // Días del archivo diario que se resumen
+	userStatsBarWidth = 160 // Ancho de la barra del usuario con más líneas
+)
+
+// userCount son las líneas de reposición que registró un usuario
+type userCount struct {
+	Usuario string
+	Lineas  int
+}
+
+// dayCount resume las líneas de una nota diaria
+type dayCount struct {
+	Dia      string // Nombre de la nota, como 2025-05-27
+	Total    int
+	Usuarios []userCount
+}
+
+// countByUser cuenta las líneas por quién las registró, de mayor a menor. Las líneas de
+// la plantilla con el código 9999 no cuentan.
+func countByUser(rows []reposicionRow) []userCount {
+	totals := map[string]int{}
+	for _, r := range rows {
+		if strings.Trim(r.Codigo, ".") == placeholderCode {
+			continue
+		}
+		totals[strings.ToUpper(r.Registrado)]++
+	}
+	counts := make([]userCount, 0, len(totals))
+	for user, lines := range totals {
+		counts = append(counts, userCount{Usuario: user, Lineas: lines})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Lineas != counts[j].Lineas {
+			return counts[i].Lineas > counts[j].Lineas
+		}
+		return counts[i].Usuario < counts[j].Usuario
+	})
+	return counts
+}
+
+// countDay resume el texto de la nota diaria day
+func countDay(day, text string) dayCount {
+	users := countByUser(parseReposicionTable(text))
+	total := 0
+	for _, u := range users {
+		total += u.Lineas
+	}
+	return dayCount{Dia: day, Total: total, Usuarios: users}
+}
+
+// formatUserCounts muestra los conteos en una línea: "JRIOS 5 · MGAVINO 3"
+func formatUserCounts(counts []userCount) string {
+	parts := make([]string, len(counts))
+	for i, c := range counts {
+		parts[i] = fmt.Sprintf("%s %d", c.Usuario, c.Lineas)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// daySummary muestra un día por línea, el más reciente primero
+func daySummary(days []dayCount) string {
+	if len(days) == 0 {
+		return "Todavía no hay notas diarias."
+	}
+	lines := make([]string, len(days))
+	for i, d := range days {
+		lines[i] = fmt.Sprintf("%s: %d líneas", dayLabel(d.Dia), d.Total)
+		if len(d.Usuarios) > 0 {
+			lines[i] += " (" + formatUserCounts(d.Usuarios) + ")"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// loadDayStats lee las últimas notas diarias; la abierta se cuenta desde el editor en
+// refreshUserStats para verla al día mientras se escribe
+func (n *NotePad) loadDayStats() {
+	n.dayStats = nil
+	for i, day := range n.days {
+		if i == userStatsDays || n.locked {
+			break
+		}
+		data, err := readNoteFile(notePath(day), n.key)
+		if err != nil {
+			log.Printf("Error leyendo la nota del %s para las estadísticas: %v", dayLabel(day), err)
+			continue
+		}
+		n.dayStats = append(n.dayStats, countDay(day, stripSaveHeader(string(data))))
+	}
+	n.refreshUserStats()
+}
+
+// refreshUserStats redibuja las barras por usuario de la nota abierta y el resumen por día
+func (n *NotePad) refreshUserStats() {
+	if n.userBars == nil {
+		return
+	}
+	counts := countByUser(parseReposicionTable(n.multiLine.Text))
+	rows := make([]fyne.CanvasObject, 0, len(counts)+1)
+	if len(counts) == 0 {
+		rows = append(rows, widget.NewLabel("La nota no tiene líneas de reposición."))
+	}
+	for _, c := range counts {
+		bar := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+		bar.SetMinSize(fyne.NewSize(float32(c.Lineas)/float32(counts[0].Lineas)*userStatsBarWidth, 14))
+		name := widget.NewLabel(c.Usuario)
+		name.TextStyle = fyne.TextStyle{Monospace: true}
+		rows = append(rows, container.NewHBox(name, container.NewCenter(bar), widget.NewLabel(fmt.Sprintf("%d", c.Lineas))))
+	}
+	n.userBars.Objects = rows
+	n.userBars.Refresh()
+
+	if !n.daily {
+		n.statsLabel.SetText("Activa el archivo diario para ver la carga de cada día.")
+		return
+	}
+	days := make([]dayCount, len(n.dayStats))
+	copy(days, n.dayStats)
+	live := countDay(n.current, n.multiLine.Text)
+	replaced := false
+	for i := range days {
+		if days[i].Dia == n.current {
+			days[i], replaced = live, true
+		}
+	}
+	if !replaced && n.current == n.today && live.Total > 0 {
+		days = append([]dayCount{live}, days...)
+	}
+	n.statsLabel.SetText(daySummary(days))
+}
+
+// createUserStatsCard arma la tarjeta con la carga de trabajo por usuario y por día
+func (n *NotePad) createUserStatsCard() *widget.Card {
+	n.userBars = container.NewVBox()
+	n.statsLabel = widget.NewLabel("")
+	n.loadDayStats()
+
+	return widget.NewCard("👥 Carga por usuario", "",
+		widget.NewAccordion(
+			widget.NewAccordionItem("Líneas por usuario en esta nota", n.userBars),
+			widget.NewAccordionItem(fmt.Sprintf("Últimos %d días", userStatsDays), n.statsLabel),
+		),
+	)
+}
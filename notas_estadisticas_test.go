@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+const statsNote = `***********LISTA REPOSICIÓN*********
+......9999 REPOSICION 15:04 MGAVINO
+......0154 LGARCIA 15:04 jrios
+......0083 JVILCATOMA 15:10 MGAVINO
+# ......0017 NCRISOSTOMO 15:04 JRIOS
+**************ZETTACOM**********
+......0017 NCRISOSTOMO 16:00 JRIOS
+[x] ......0020 LGARCIA 16:05 BTAIPE`
+
+func TestCountByUser(t *testing.T) {
+	got := countByUser(parseReposicionTable(statsNote))
+	want := []userCount{{"JRIOS", 2}, {"BTAIPE", 1}, {"MGAVINO", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countByUser = %+v, quería %+v", got, want)
+	}
+}
+
+func TestDaySummary(t *testing.T) {
+	days := []dayCount{countDay("2026-10-16", statsNote), countDay("2026-10-15", "")}
+	want := "16/10/2026: 4 líneas (JRIOS 2 · BTAIPE 1 · MGAVINO 1)\n15/10/2026: 0 líneas"
+	if got := daySummary(days); got != want {
+		t.Errorf("daySummary = %q, quería %q", got, want)
+	}
+	if got := daySummary(nil); got == "" {
+		t.Error("sin días debería explicar que no hay notas diarias")
+	}
+}
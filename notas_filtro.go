@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// filteredLine es una línea que contiene el texto del filtro; Linea cuenta desde 0
+type filteredLine struct {
+	Linea int
+	Texto string
+}
+
+// filterLines devuelve las líneas que contienen query, sin distinguir mayúsculas
+func filterLines(text, query string) []filteredLine {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var lines []filteredLine
+	for i, line := range strings.Split(text, "\n") {
+		if query != "" && strings.Contains(strings.ToLower(line), query) {
+			lines = append(lines, filteredLine{Linea: i, Texto: line})
+		}
+	}
+	return lines
+}
+
+// updateFilter vuelve a filtrar si hay un filtro escrito; sin filtro se ve el editor
+func (n *NotePad) updateFilter() {
+	if n.filterEntry == nil {
+		return
+	}
+	if strings.TrimSpace(n.filterEntry.Text) == "" {
+		n.filterBox.Hide()
+		n.unfiltered.Show()
+		return
+	}
+	n.filterRows = filterLines(n.multiLine.Text, n.filterEntry.Text)
+	n.filterLabel.SetText(fmt.Sprintf("%d líneas con %q. Elige una para ir a ella en el editor.", len(n.filterRows), strings.TrimSpace(n.filterEntry.Text)))
+	n.filterList.Refresh()
+	n.unfiltered.Hide()
+	n.filterBox.Show()
+}
+
+// createFilterBar arma la barra de filtro y la lista que ocupa el lugar del editor mientras
+// hay un filtro. Filtrar no cambia el texto de la nota.
+func (n *NotePad) createFilterBar(editor fyne.CanvasObject, window fyne.Window) (fyne.CanvasObject, fyne.CanvasObject) {
+	n.unfiltered = editor
+	n.filterList = widget.NewList(
+		func() int {
+			return len(n.filterRows)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			row := n.filterRows[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%4d   %s", row.Linea+1, row.Texto))
+		},
+	)
+	n.filterList.OnSelected = func(id widget.ListItemID) {
+		row := n.filterRows[id].Linea
+		n.filterList.UnselectAll()
+		n.filterEntry.SetText("")
+		n.goToRow(row, window)
+	}
+	n.filterLabel = widget.NewLabel("")
+	n.filterBox = container.NewBorder(n.filterLabel, nil, nil, nil, n.filterList)
+	n.filterBox.Hide()
+
+	n.filterEntry = widget.NewEntry()
+	n.filterEntry.SetPlaceHolder("Mostrar solo las líneas con... (por ejemplo JRIOS)")
+	n.filterEntry.OnChanged = func(string) { n.updateFilter() }
+	clearButton := widget.NewButton("✖ Quitar filtro", func() {
+		n.filterEntry.SetText("")
+	})
+
+	bar := container.NewBorder(nil, nil, widget.NewLabel("🔽 Filtrar"), clearButton, n.filterEntry)
+	return bar, container.NewStack(editor, n.filterBox)
+}
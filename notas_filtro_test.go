@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterLines(t *testing.T) {
+	text := "***ZETTACOM***\n......0154 LGARCIA 15:04 JRIOS\n......0083 JVILCATOMA 15:04 MGAVINO\nllamar a jrios"
+
+	want := []filteredLine{
+		{Linea: 1, Texto: "......0154 LGARCIA 15:04 JRIOS"},
+		{Linea: 3, Texto: "llamar a jrios"},
+	}
+	if got := filterLines(text, " JRIOS "); !reflect.DeepEqual(got, want) {
+		t.Errorf("filterLines = %+v", got)
+	}
+	if got := filterLines(text, ""); got != nil {
+		t.Errorf("sin filtro no debería devolver líneas: %+v", got)
+	}
+	if got := filterLines(text, "ZZZ"); len(got) != 0 {
+		t.Errorf("sin coincidencias = %+v", got)
+	}
+}
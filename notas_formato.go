@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os/user"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	formatRuleFile = "formato_notas.json" // Junto al programa: el usuario es el de cada equipo
+	maxFormatDots  = 20
+	maxFormatWidth = 10
+)
+
+// bareCodeRegex reconoce una línea escrita a la rápida: código, técnico y opcionalmente
+// quién la registra, como "154 LGARCIA" o "154 lgarcia mgavino". Los puntos del principio
+// se aceptan porque la línea puede venir de la que agrega la lista al pulsar Enter.
+var bareCodeRegex = regexp.MustCompile(`^[\s.]*(\d+)\s+(\p{L}[\p{L}\d]*)(?:\s+(\p{L}[\p{L}\d]*))?\s*$`)
+
+// codeLineRegex reconoce una línea de la lista: puntos, código y algo más después
+var codeLineRegex = regexp.MustCompile(`^(\.+)(\d+)\s+\S`)
+
+// Qué agrega Enter al final de una línea de código
+const (
+	continueNext  = "Código siguiente"
+	continueBlank = "Código en blanco"
+	continueOff   = "No continuar la lista"
+)
+
+var continueModes = []string{continueNext, continueBlank, continueOff}
+
+// NoteFormatRule dice cómo completar las líneas de código al pulsar Enter
+type NoteFormatRule struct {
+	Activo  bool
+	Puntos  int    // Puntos antes del código
+	Digitos int    // El código se completa con ceros a la izquierda hasta este largo
+	Usuario string // Quién registra si la línea no lo dice
+	// Continuar es uno de continueModes; vacío no continúa la lista
+	Continuar string
+}
+
+func defaultFormatRule() NoteFormatRule {
+	return NoteFormatRule{Activo: true, Puntos: 6, Digitos: 4, Usuario: sessionInitials(), Continuar: continueNext}
+}
+
+// sessionInitials usa el usuario de Windows en mayúsculas, sin el dominio
+func sessionInitials() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	name := u.Username
+	if i := strings.LastIndex(name, `\`); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.ToUpper(name)
+}
+
+func (r NoteFormatRule) validate() error {
+	if r.Puntos < 0 || r.Puntos > maxFormatDots {
+		return fmt.Errorf("los puntos deben estar entre 0 y %d", maxFormatDots)
+	}
+	if r.Digitos < 1 || r.Digitos > maxFormatWidth {
+		return fmt.Errorf("los dígitos del código deben estar entre 1 y %d", maxFormatWidth)
+	}
+	if strings.ContainsAny(strings.TrimSpace(r.Usuario), " \t") {
+		return fmt.Errorf("el usuario no puede llevar espacios")
+	}
+	return nil
+}
+
+// formatCodeLine convierte "154 LGARCIA" en "......0154 LGARCIA 15:04 JRIOS". Las líneas que
+// no tienen esa forma, o que no dicen quién registra cuando la regla no tiene usuario,
+// quedan igual.
+func formatCodeLine(line string, rule NoteFormatRule, now time.Time) (string, bool) {
+	m := bareCodeRegex.FindStringSubmatch(line)
+	if m == nil {
+		return line, false
+	}
+	registered := m[3]
+	if registered == "" {
+		registered = strings.TrimSpace(rule.Usuario)
+	}
+	if registered == "" {
+		return line, false
+	}
+	code := m[1]
+	if len(code) < rule.Digitos {
+		code = strings.Repeat("0", rule.Digitos-len(code)) + code
+	}
+	return fmt.Sprintf("%s%s %s %s %s", strings.Repeat(".", rule.Puntos), code,
+		strings.ToUpper(m[2]), formatTokens(insertTimeFormat, now), strings.ToUpper(registered)), true
+}
+
+// continueCodeLine arma la línea que sigue a prev al pulsar Enter, como una lista numerada:
+// los mismos puntos y el código siguiente con el mismo ancho, o solo los puntos. Si prev es
+// prompt, la última línea que agregó la lista, y quedó sin llenar, end indica que se borra y
+// la lista termina, igual que un Enter en un punto vacío de otros editores. Así una línea de
+// puntos que separa secciones nunca se borra.
+func continueCodeLine(prev, prompt, mode string) (next string, end, ok bool) {
+	if mode != continueNext && mode != continueBlank {
+		return "", false, false
+	}
+	if prompt != "" && strings.TrimSpace(prev) == strings.TrimSpace(prompt) {
+		return "", true, true
+	}
+	m := codeLineRegex.FindStringSubmatch(prev)
+	if m == nil {
+		return "", false, false
+	}
+	if mode == continueBlank {
+		return m[1], false, true
+	}
+	code, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", false, false
+	}
+	return fmt.Sprintf("%s%0*d ", m[1], len(m[2]), code+1), false, true
+}
+
+func loadFormatRule() NoteFormatRule {
+	rule := defaultFormatRule()
+	data, err := ioutil.ReadFile(formatRuleFile)
+	if err != nil {
+		return rule
+	}
+	if err := json.Unmarshal(data, &rule); err != nil {
+		log.Printf("Error leyendo %s: %v", formatRuleFile, err)
+		return defaultFormatRule()
+	}
+	if err := rule.validate(); err != nil {
+		log.Printf("Regla de formato inválida, se usa la predeterminada: %v", err)
+		return defaultFormatRule()
+	}
+	return rule
+}
+
+func saveFormatRule(rule NoteFormatRule) error {
+	data, err := json.MarshalIndent(rule, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(formatRuleFile, data, 0644)
+}
+
+// formatTypedLine se llama desde OnChanged: si lo escrito fue un Enter, completa la línea
+// que se acaba de terminar y, si el Enter fue al final de una línea de código, empieza la
+// siguiente de la lista. Es un paso aparte del historial para deshacerlo con Ctrl+Z sin
+// perder lo escrito.
+func (n *NotePad) formatTypedLine(before, content string) {
+	if n.pendingKind != "" || len(content) != len(before)+1 {
+		return
+	}
+	row := n.multiLine.CursorRow - 1
+	lines := strings.Split(content, "\n")
+	if row < 0 || row+1 >= len(lines) || n.multiLine.CursorColumn != 0 || strings.Count(content, "\n") != strings.Count(before, "\n")+1 {
+		return
+	}
+	status := ""
+	if n.formatRule.Activo {
+		if formatted, ok := formatCodeLine(lines[row], n.formatRule, time.Now()); ok {
+			lines[row] = formatted
+			status = fmt.Sprintf("Estado: 🪄 Línea %d completada: %s", row+1, formatted)
+		}
+	}
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	if lines[row+1] == "" {
+		next, end, ok := continueCodeLine(lines[row], n.listPrompt, n.formatRule.Continuar)
+		n.listPrompt = next
+		if ok && end {
+			lines[row] = ""
+			lines = append(lines[:row+1], lines[row+2:]...)
+			cursorRow = row
+			status = "Estado: Lista terminada"
+		} else if ok {
+			lines[row+1] = next
+			cursorCol = utf8.RuneCountInString(next)
+		}
+	}
+	edited := strings.Join(lines, "\n")
+	if edited == content {
+		return
+	}
+
+	n.applyEdit(historyFormat, edited)
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+	if status != "" {
+		n.statusLabel.SetText(status)
+	}
+}
+
+// showFormatRule abre el diálogo de la regla de formato de las líneas de código
+func (n *NotePad) showFormatRule(window fyne.Window) {
+	activeCheck := widget.NewCheck("Completar las líneas de código al pulsar Enter", nil)
+	activeCheck.SetChecked(n.formatRule.Activo)
+	dotsInput := widget.NewEntry()
+	dotsInput.SetText(strconv.Itoa(n.formatRule.Puntos))
+	digitsInput := widget.NewEntry()
+	digitsInput.SetText(strconv.Itoa(n.formatRule.Digitos))
+	userInput := widget.NewEntry()
+	userInput.SetText(n.formatRule.Usuario)
+	userInput.SetPlaceHolder("JRIOS")
+	continueSelect := widget.NewSelect(continueModes, nil)
+	continueSelect.SetSelected(continueOff)
+	if slices.Contains(continueModes, n.formatRule.Continuar) {
+		continueSelect.SetSelected(n.formatRule.Continuar)
+	}
+
+	dialog.ShowForm("🪄 Formato de líneas", "Guardar", "Cancelar",
+		[]*widget.FormItem{
+			widget.NewFormItem("", activeCheck),
+			widget.NewFormItem("Puntos antes del código", dotsInput),
+			widget.NewFormItem("Dígitos del código", digitsInput),
+			widget.NewFormItem("Registrado por", userInput),
+			widget.NewFormItem("Enter al final de una línea", continueSelect),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			dots, err1 := strconv.Atoi(strings.TrimSpace(dotsInput.Text))
+			digits, err2 := strconv.Atoi(strings.TrimSpace(digitsInput.Text))
+			if err1 != nil || err2 != nil {
+				dialog.ShowError(fmt.Errorf("los puntos y los dígitos deben ser números enteros"), window)
+				return
+			}
+			rule := NoteFormatRule{
+				Activo:    activeCheck.Checked,
+				Puntos:    dots,
+				Digitos:   digits,
+				Usuario:   strings.ToUpper(strings.TrimSpace(userInput.Text)),
+				Continuar: continueSelect.Selected,
+			}
+			if err := rule.validate(); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := saveFormatRule(rule); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.formatRule = rule
+			example, _ := formatCodeLine("154 LGARCIA", rule, time.Now())
+			if rule.Activo {
+				n.statusLabel.SetText(fmt.Sprintf("Estado: 🪄 \"154 LGARCIA\" se completará como %q", example))
+			} else {
+				n.statusLabel.SetText("Estado: Formato automático de líneas desactivado")
+			}
+		}, window)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatCodeLine(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 5, 0, 0, time.Local)
+	rule := NoteFormatRule{Activo: true, Puntos: 6, Digitos: 4, Usuario: "JRIOS"}
+	tests := []struct {
+		line, want string
+		ok         bool
+	}{
+		{"154 LGARCIA", "......0154 LGARCIA 09:05 JRIOS", true},
+		{"  17 ncrisostomo  ", "......0017 NCRISOSTOMO 09:05 JRIOS", true},
+		{"154 LGARCIA mgavino", "......0154 LGARCIA 09:05 MGAVINO", true},
+		{"123456 LGARCIA", "......123456 LGARCIA 09:05 JRIOS", true},
+		{"......0154 LGARCIA 15:04 MGAVINO", "......0154 LGARCIA 15:04 MGAVINO", false},
+		{"# 154 LGARCIA", "# 154 LGARCIA", false},
+		{"154", "154", false},
+		{"154 LGARCIA 15:04", "154 LGARCIA 15:04", false},
+	}
+	for _, tt := range tests {
+		got, ok := formatCodeLine(tt.line, rule, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("formatCodeLine(%q) = %q, %v; quería %q, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	rule.Usuario = ""
+	if _, ok := formatCodeLine("154 LGARCIA", rule, now); ok {
+		t.Error("sin usuario en la regla ni en la línea no debería completarse")
+	}
+	rule.Puntos, rule.Digitos = 0, 3
+	if got, _ := formatCodeLine("5 LGARCIA BTAIPE", rule, now); got != "005 LGARCIA 09:05 BTAIPE" {
+		t.Errorf("con 0 puntos y 3 dígitos = %q", got)
+	}
+}
+
+func TestFormatRuleValidate(t *testing.T) {
+	if err := (NoteFormatRule{Puntos: 6, Digitos: 4, Usuario: "JRIOS"}).validate(); err != nil {
+		t.Errorf("una regla válida no debería fallar: %v", err)
+	}
+	for _, rule := range []NoteFormatRule{
+		{Puntos: -1, Digitos: 4},
+		{Puntos: 6, Digitos: 0},
+		{Puntos: 6, Digitos: 4, Usuario: "J RIOS"},
+	} {
+		if rule.validate() == nil {
+			t.Errorf("la regla %+v debería ser inválida", rule)
+		}
+	}
+}
+
+func TestContinueCodeLine(t *testing.T) {
+	tests := []struct {
+		prev, prompt, mode, next string
+		end, ok                  bool
+	}{
+		{"......0154 LGARCIA 15:04 JRIOS", "", continueNext, "......0155 ", false, true},
+		{"...0099 LGARCIA 15:04 JRIOS", "", continueNext, "...0100 ", false, true},
+		{"......9 LGARCIA", "", continueNext, "......10 ", false, true},
+		{"......0154 LGARCIA 15:04 JRIOS", "", continueBlank, "......", false, true},
+		{"......0155 ", "......0155 ", continueNext, "", true, true},
+		{"......0155", "......0155 ", continueNext, "", true, true},
+		{"......", "......", continueBlank, "", true, true},
+		{"......................", "", continueNext, "", false, false},
+		{"......0154 LGARCIA 15:04 JRIOS", "", continueOff, "", false, false},
+		{"......0154 LGARCIA 15:04 JRIOS", "", "", "", false, false},
+		{"***LISTA REPOSICIÓN***", "", continueNext, "", false, false},
+		{"154 LGARCIA", "", continueNext, "", false, false},
+	}
+	for _, tt := range tests {
+		next, end, ok := continueCodeLine(tt.prev, tt.prompt, tt.mode)
+		if next != tt.next || end != tt.end || ok != tt.ok {
+			t.Errorf("continueCodeLine(%q, %q, %q) = %q, %v, %v; quería %q, %v, %v",
+				tt.prev, tt.prompt, tt.mode, next, end, ok, tt.next, tt.end, tt.ok)
+		}
+	}
+
+	// Lo que se escribe en la línea que agregó la lista se completa como siempre
+	rule := NoteFormatRule{Activo: true, Puntos: 6, Digitos: 4, Usuario: "JRIOS"}
+	now := time.Date(2026, 10, 16, 9, 5, 0, 0, time.Local)
+	for _, line := range []string{"......0155 lgarcia", "......155 LGARCIA"} {
+		if got, _ := formatCodeLine(line, rule, now); got != "......0155 LGARCIA 09:05 JRIOS" {
+			t.Errorf("formatCodeLine(%q) = %q", line, got)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	fontSettingsFile = "fuente_notas.json" // Junto al programa: depende del monitor de cada equipo
+	minFontSize      = 10
+	maxFontSize      = 32
+)
+
+// NoteFontSettings es la letra del editor
+type NoteFontSettings struct {
+	Monoespaciada bool
+	Tamano        float32
+}
+
+func defaultFontSettings() NoteFontSettings {
+	return NoteFontSettings{Tamano: theme.DefaultTheme().Size(theme.SizeNameText)}
+}
+
+func (s NoteFontSettings) validate() error {
+	if s.Tamano < minFontSize || s.Tamano > maxFontSize {
+		return fmt.Errorf("el tamaño de letra debe estar entre %d y %d", minFontSize, maxFontSize)
+	}
+	return nil
+}
+
+func (s NoteFontSettings) textStyle() fyne.TextStyle {
+	return fyne.TextStyle{Monospace: s.Monoespaciada}
+}
+
+func loadFontSettings() NoteFontSettings {
+	settings := defaultFontSettings()
+	data, err := ioutil.ReadFile(fontSettingsFile)
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", fontSettingsFile, err)
+		return defaultFontSettings()
+	}
+	if err := settings.validate(); err != nil {
+		log.Printf("Letra del editor inválida, se usa la predeterminada: %v", err)
+		return defaultFontSettings()
+	}
+	return settings
+}
+
+func saveFontSettings(settings NoteFontSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fontSettingsFile, data, 0644)
+}
+
+// applyFont cambia la letra del editor y del margen, que deben coincidir para que cada
+// número quede a la altura de su línea
+func (n *NotePad) applyFont(settings NoteFontSettings) {
+	n.editorFont = settings
+	n.editorTheme.textSize = settings.Tamano
+	n.multiLine.TextStyle = settings.textStyle()
+	n.gutter.TextStyle = settings.textStyle()
+	n.themeBox.Refresh()
+	n.multiLine.Refresh()
+	n.gutter.Refresh()
+}
+
+// showFontSettings cambia la letra del editor; los cambios se ven mientras se ajustan y se
+// descartan con Cancelar
+func (n *NotePad) showFontSettings(window fyne.Window) {
+	previous := n.editorFont
+	current := previous
+
+	monoCheck := widget.NewCheck("Letra monoespaciada (columnas alineadas)", func(on bool) {
+		current.Monoespaciada = on
+		n.applyFont(current)
+	})
+	monoCheck.SetChecked(current.Monoespaciada)
+
+	sizeLabel := widget.NewLabel("")
+	sizeSlider := widget.NewSlider(minFontSize, maxFontSize)
+	sizeSlider.Step = 1
+	sizeSlider.SetValue(float64(current.Tamano))
+	sizeLabel.SetText(fmt.Sprintf("%.0f", current.Tamano))
+	sizeSlider.OnChanged = func(value float64) {
+		current.Tamano = float32(value)
+		sizeLabel.SetText(fmt.Sprintf("%.0f", value))
+		n.applyFont(current)
+	}
+
+	content := container.NewVBox(
+		monoCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Tamaño"), sizeLabel, sizeSlider),
+	)
+	d := dialog.NewCustomConfirm("🔤 Letra del editor", "Guardar", "Cancelar", content, func(ok bool) {
+		if !ok {
+			n.applyFont(previous)
+			return
+		}
+		if err := saveFontSettings(current); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.statusLabel.SetText(fmt.Sprintf("Estado: Letra del editor de tamaño %.0f", current.Tamano))
+	}, window)
+	d.Resize(fyne.NewSize(420, 200))
+	d.Show()
+}
@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestFontSettingsValidate(t *testing.T) {
+	for _, size := range []float32{minFontSize, 18, maxFontSize} {
+		if err := (NoteFontSettings{Tamano: size}).validate(); err != nil {
+			t.Errorf("tamaño %v debería ser válido: %v", size, err)
+		}
+	}
+	for _, size := range []float32{0, minFontSize - 1, maxFontSize + 1} {
+		if err := (NoteFontSettings{Tamano: size}).validate(); err == nil {
+			t.Errorf("tamaño %v debería dar error", size)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const saveSettingsFile = "guardado.json" // Dentro de la carpeta de notas
+
+// NoteSaveSettings controla el guardado automático. En unidades de red conviene guardar
+// menos seguido o desactivarlo y usar "Guardar Ahora".
+type NoteSaveSettings struct {
+	Activo    bool
+	Intervalo int // Segundos entre guardados
+	Pausa     int // Segundos sin escribir antes de guardar o actualizar la hora
+}
+
+func defaultSaveSettings() NoteSaveSettings {
+	return NoteSaveSettings{
+		Activo:    true,
+		Intervalo: int(autoSaveInterval / time.Second),
+		Pausa:     int(editPause / time.Second),
+	}
+}
+
+func (s NoteSaveSettings) validate() error {
+	if s.Intervalo < 1 || s.Intervalo > 3600 {
+		return fmt.Errorf("el intervalo de guardado debe estar entre 1 y 3600 segundos")
+	}
+	if s.Pausa < 0 || s.Pausa > 300 {
+		return fmt.Errorf("la pausa de edición debe estar entre 0 y 300 segundos")
+	}
+	return nil
+}
+
+func (s NoteSaveSettings) interval() time.Duration {
+	return time.Duration(s.Intervalo) * time.Second
+}
+
+func (s NoteSaveSettings) pause() time.Duration {
+	return time.Duration(s.Pausa) * time.Second
+}
+
+// autoSaveSettings comparte los ajustes entre la interfaz y los goroutines del bloc
+type autoSaveSettings struct {
+	mu       sync.Mutex
+	settings NoteSaveSettings
+}
+
+func (a *autoSaveSettings) get() NoteSaveSettings {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.settings
+}
+
+func (a *autoSaveSettings) set(s NoteSaveSettings) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.settings = s
+}
+
+func loadSaveSettings() NoteSaveSettings {
+	settings := defaultSaveSettings()
+	data, err := ioutil.ReadFile(filepath.Join(notesDir, saveSettingsFile))
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", saveSettingsFile, err)
+		return defaultSaveSettings()
+	}
+	if err := settings.validate(); err != nil {
+		log.Printf("Ajustes de guardado inválidos, se usan los predeterminados: %v", err)
+		return defaultSaveSettings()
+	}
+	return settings
+}
+
+func saveSaveSettings(settings NoteSaveSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(notesDir, saveSettingsFile), data, 0644)
+}
+
+// showSaveSettings abre el diálogo de ajustes del guardado automático
+func (n *NotePad) showSaveSettings(window fyne.Window) {
+	current := n.autoSave.get()
+
+	activeCheck := widget.NewCheck("Guardar automáticamente", nil)
+	activeCheck.SetChecked(current.Activo)
+	intervalInput := widget.NewEntry()
+	intervalInput.SetText(strconv.Itoa(current.Intervalo))
+	pauseInput := widget.NewEntry()
+	pauseInput.SetText(strconv.Itoa(current.Pausa))
+
+	dialog.ShowForm("💾 Guardado automático", "Guardar", "Cancelar",
+		[]*widget.FormItem{
+			widget.NewFormItem("", activeCheck),
+			widget.NewFormItem("Guardar cada (seg)", intervalInput),
+			widget.NewFormItem("Pausa de edición (seg)", pauseInput),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			interval, err1 := strconv.Atoi(strings.TrimSpace(intervalInput.Text))
+			pause, err2 := strconv.Atoi(strings.TrimSpace(pauseInput.Text))
+			if err1 != nil || err2 != nil {
+				dialog.ShowError(fmt.Errorf("el intervalo y la pausa deben ser números enteros de segundos"), window)
+				return
+			}
+			settings := NoteSaveSettings{Activo: activeCheck.Checked, Intervalo: interval, Pausa: pause}
+			if err := settings.validate(); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := saveSaveSettings(settings); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.autoSave.set(settings)
+			if settings.Activo {
+				n.statusLabel.SetText(fmt.Sprintf("Estado: Guardado automático cada %d seg", settings.Intervalo))
+			} else {
+				n.statusLabel.SetText("Estado: Guardado automático desactivado, usa 💾 Guardar Ahora")
+			}
+		}, window)
+}
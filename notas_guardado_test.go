@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNoteSaveSettings(t *testing.T) {
+	defaults := defaultSaveSettings()
+	if !defaults.Activo || defaults.interval() != autoSaveInterval || defaults.pause() != editPause {
+		t.Errorf("los predeterminados deberían mantener 5 y 2 segundos: %+v", defaults)
+	}
+	if err := defaults.validate(); err != nil {
+		t.Errorf("los predeterminados deberían ser válidos: %v", err)
+	}
+
+	tests := []struct {
+		settings NoteSaveSettings
+		valid    bool
+	}{
+		{NoteSaveSettings{Intervalo: 60, Pausa: 0}, true},
+		{NoteSaveSettings{Activo: true, Intervalo: 3600, Pausa: 300}, true},
+		{NoteSaveSettings{Intervalo: 0, Pausa: 2}, false},
+		{NoteSaveSettings{Intervalo: 5, Pausa: -1}, false},
+		{NoteSaveSettings{Intervalo: 5, Pausa: 301}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.settings.validate(); (err == nil) != tt.valid {
+			t.Errorf("validate(%+v) = %v", tt.settings, err)
+		}
+	}
+
+	var shared autoSaveSettings
+	shared.set(NoteSaveSettings{Intervalo: 30, Pausa: 10})
+	if got := shared.get(); got.interval() != 30*time.Second || got.pause() != 10*time.Second {
+		t.Errorf("get() = %+v", got)
+	}
+}
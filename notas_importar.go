@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// cp1252 son los caracteres que el Bloc de notas de Windows guarda entre 0x80 y 0x9F en
+// "ANSI"; en Latin-1 puro esos bytes son de control y nunca aparecen en un texto
+var cp1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// decodeLegacyText pasa a UTF-8 un .txt viejo: UTF-8 con o sin BOM queda igual, UTF-16 con
+// BOM (el "Unicode" del Bloc de notas) se convierte y lo demás se lee como Latin-1 con los
+// caracteres de Windows. Los saltos de línea quedan como los del editor. converted indica
+// si el archivo no estaba en UTF-8.
+func decodeLegacyText(data []byte) (text string, converted bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		text = string(data[3:])
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		bigEndian := data[0] == 0xFE
+		units := make([]uint16, 0, len(data)/2)
+		for i := 2; i+1 < len(data); i += 2 {
+			if bigEndian {
+				units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+			} else {
+				units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+			}
+		}
+		text, converted = string(utf16.Decode(units)), true
+	case utf8.Valid(data):
+		text = string(data)
+	default:
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			if r, ok := cp1252[b]; ok {
+				runes[i] = r
+			} else {
+				runes[i] = rune(b)
+			}
+		}
+		text, converted = string(runes), true
+	}
+	return strings.ReplaceAll(text, "\r\n", "\n"), converted
+}
+
+// importName elige el nombre de la nota para el archivo file: el mismo sin .txt, recortado
+// y sin los caracteres que no admiten las notas, con (2), (3)... si ya hay una nota así
+func importName(file string, taken func(string) bool) string {
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	base = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, base)
+	base = strings.TrimSpace(strings.TrimLeft(base, "."))
+	if base == "" {
+		base = "Importada"
+	}
+	if runes := []rune(base); len(runes) > noteNameMaxLen-4 {
+		base = strings.TrimSpace(string(runes[:noteNameMaxLen-4]))
+	}
+	name := base
+	for i := 2; taken(name); i++ {
+		name = fmt.Sprintf("%s (%d)", base, i)
+	}
+	return name
+}
+
+// importResult resume una importación: las notas creadas, cuántas se pasaron a UTF-8 y los
+// archivos que no se pudieron leer o escribir
+type importResult struct {
+	Notas       []string
+	Convertidas int
+	Errores     []string
+}
+
+// importNotes copia los .txt de dir a la carpeta de notas como notas nuevas, en UTF-8 y
+// cifradas si hay clave. Conservan la fecha de modificación del original para que el
+// historial de turnos siga en orden. existing son las notas que ya hay.
+func importNotes(dir string, existing []string, key []byte) (importResult, error) {
+	var result importResult
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return result, err
+	}
+	if len(files) == 0 {
+		return result, fmt.Errorf("la carpeta %s no tiene archivos .txt", dir)
+	}
+	taken := map[string]bool{}
+	for _, name := range existing {
+		taken[strings.ToLower(name)] = true
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			result.Errores = append(result.Errores, fmt.Sprintf("%s: %v", filepath.Base(file), err))
+			continue
+		}
+		text, converted := decodeLegacyText(data)
+		name := importName(file, func(name string) bool {
+			_, err := os.Stat(notePath(name))
+			return taken[strings.ToLower(name)] || err == nil
+		})
+		if err := writeNoteFile(notePath(name), []byte(text), key); err != nil {
+			result.Errores = append(result.Errores, fmt.Sprintf("%s: %v", filepath.Base(file), err))
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			os.Chtimes(notePath(name), info.ModTime(), info.ModTime())
+		}
+		taken[strings.ToLower(name)] = true
+		result.Notas = append(result.Notas, name)
+		if converted {
+			result.Convertidas++
+		}
+	}
+	return result, nil
+}
+
+// showImport elige una carpeta con notas viejas y las importa sin trabar la ventana
+func (n *NotePad) showImport(window fyne.Window) {
+	if n.locked {
+		dialog.ShowInformation("📥 Importar notas", "Desbloquea las notas antes de importar.", window)
+		return
+	}
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if uri == nil {
+			return
+		}
+		dir := uri.Path()
+		if samePath(dir, notesDir) {
+			dialog.ShowError(fmt.Errorf("esa es la carpeta de las notas; elige la carpeta de los archivos viejos"), window)
+			return
+		}
+		n.statusLabel.SetText(fmt.Sprintf("Estado: 📥 Importando las notas de %s...", dir))
+		existing := append([]string{}, n.notes...)
+		existing = append(existing, n.days...)
+		n.saveMu.Lock()
+		key := n.key
+		n.saveMu.Unlock()
+		go func() {
+			result, err := importNotes(dir, existing, key)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, window)
+					n.statusLabel.SetText("Estado: ⚠️ No se importó ninguna nota")
+					return
+				}
+				n.refreshNotes()
+				n.selectNoteInList()
+				n.statusLabel.SetText(fmt.Sprintf("Estado: 📥 %d notas importadas", len(result.Notas)))
+				n.showImportResult(result, window)
+			})
+		}()
+	}, window)
+}
+
+// showImportResult lista lo importado y los archivos que fallaron
+func (n *NotePad) showImportResult(result importResult, window fyne.Window) {
+	summary := fmt.Sprintf("Se importaron %d notas", len(result.Notas))
+	if result.Convertidas > 0 {
+		summary += fmt.Sprintf(", %d convertidas de Latin-1 o UTF-16 a UTF-8", result.Convertidas)
+	}
+	lines := []string{summary + "."}
+	if len(result.Notas) > 0 {
+		lines = append(lines, "", strings.Join(result.Notas, "\n"))
+	}
+	if len(result.Errores) > 0 {
+		lines = append(lines, "", "No se pudieron importar:", strings.Join(result.Errores, "\n"))
+	}
+	label := widget.NewLabel(strings.Join(lines, "\n"))
+	label.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("📥 Importar notas", "Cerrar", container.NewScroll(label), window)
+	d.Resize(fyne.NewSize(520, 400))
+	d.Show()
+}
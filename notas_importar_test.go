@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeLegacyText(t *testing.T) {
+	tests := []struct {
+		data      []byte
+		want      string
+		converted bool
+	}{
+		{[]byte("REPOSICIÓN ñ\r\n"), "REPOSICIÓN ñ\n", false},
+		{append([]byte{0xEF, 0xBB, 0xBF}, "Año"...), "Año", false},
+		{[]byte("REPOSICI\xd3N a\xf1o \x93ok\x94 \x80"), "REPOSICIÓN año “ok” €", true},
+		{[]byte{0xFF, 0xFE, 'A', 0, 0xF1, 0, '\r', 0, '\n', 0}, "Añ\n", true},
+		{[]byte{0xFE, 0xFF, 0, 'A', 0, 0xF1}, "Añ", true},
+	}
+	for _, tt := range tests {
+		got, converted := decodeLegacyText(tt.data)
+		if got != tt.want || converted != tt.converted {
+			t.Errorf("decodeLegacyText(%q) = %q, %v; quería %q, %v", tt.data, got, converted, tt.want, tt.converted)
+		}
+	}
+}
+
+func TestImportName(t *testing.T) {
+	taken := map[string]bool{"turno": true, "turno (2)": true}
+	isTaken := func(name string) bool { return taken[name] }
+	tests := map[string]string{
+		"C:/viejos/turno.txt":                           "turno (3)",
+		"C:/viejos/entrega.TXT":                         "entrega",
+		"C:/viejos/.oculto.txt":                         "oculto",
+		"C:/viejos/lista: enero?.txt":                   "lista_ enero_",
+		"C:/viejos/.txt":                                "Importada",
+		"C:/viejos/2024-03-15.txt":                      "2024-03-15",
+		"C:/viejos/" + strings.Repeat("x", 70) + ".txt": strings.Repeat("x", noteNameMaxLen-4),
+	}
+	for file, want := range tests {
+		if got := importName(file, isTaken); got != want {
+			t.Errorf("importName(%q) = %q, quería %q", file, got, want)
+		}
+	}
+}
+
+func TestImportNotes(t *testing.T) {
+	src := t.TempDir()
+	old := notesDir
+	notesDir = t.TempDir()
+	defer func() { notesDir = old }()
+
+	ioutil.WriteFile(filepath.Join(src, "General.txt"), []byte("turno viejo\r\n"), 0644)
+	ioutil.WriteFile(filepath.Join(src, "entrega.txt"), []byte("Recepci\xf3n"), 0644)
+	ioutil.WriteFile(filepath.Join(src, "leeme.md"), []byte("no"), 0644)
+	modTime := time.Date(2024, 3, 15, 18, 0, 0, 0, time.Local)
+	os.Chtimes(filepath.Join(src, "entrega.txt"), modTime, modTime)
+
+	result, err := importNotes(src, []string{"general"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Notas, []string{"General (2)", "entrega"}) || result.Convertidas != 1 || len(result.Errores) != 0 {
+		t.Fatalf("importNotes = %+v", result)
+	}
+	data, _ := readNoteFile(notePath("entrega"), nil)
+	if string(data) != "Recepción" {
+		t.Errorf("entrega = %q", data)
+	}
+	if info, err := os.Stat(notePath("entrega")); err != nil || !info.ModTime().Equal(modTime) {
+		t.Errorf("la nota importada debería conservar la fecha del original: %v", err)
+	}
+
+	if _, err := importNotes(t.TempDir(), nil, nil); err == nil {
+		t.Error("una carpeta sin .txt debería dar error")
+	}
+}
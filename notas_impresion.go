@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	printMinWidth = 40 // Ancho mínimo de la línea bajo el encabezado
+	printMaxWidth = 80 // Columnas de una hoja A4 en letra monoespaciada
+)
+
+// printableNote arma el texto que se imprime: un encabezado con la nota, la fecha y quién la
+// imprimió, y debajo las líneas de la nota. Los comentarios con # son instrucciones del
+// bloc y no van al tablero.
+func printableNote(title, text, user string, now time.Time) string {
+	var lines []string
+	width := printMinWidth
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		lines = append(lines, line)
+		if w := utf8.RuneCountInString(line); w > width {
+			width = w
+		}
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if width > printMaxWidth {
+		width = printMaxWidth
+	}
+
+	printed := "Impresa el " + now.Format("02/01/2006 15:04")
+	if user != "" {
+		printed += " por " + user
+	}
+	header := []string{"NOTA: " + title, printed, strings.Repeat("=", width), ""}
+	return strings.Join(append(header, lines...), "\n") + "\n"
+}
+
+// parsePrinters lee una impresora por línea, como las listan lpstat -e y Get-Printer
+func parsePrinters(output string) []string {
+	var printers []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			printers = append(printers, line)
+		}
+	}
+	return printers
+}
+
+// parseDefaultPrinter lee la predeterminada de "lpstat -d", que según el idioma dice
+// "system default destination: HP" o "destino predeterminado del sistema: HP". Sin
+// predeterminada no hay dos puntos.
+func parseDefaultPrinter(output string) string {
+	output = strings.TrimSpace(output)
+	if i := strings.LastIndex(output, ": "); i >= 0 {
+		return strings.TrimSpace(output[i+2:])
+	}
+	return ""
+}
+
+// psQuote encierra s entre comillas simples de PowerShell
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// listPrinters devuelve las impresoras del sistema y la predeterminada
+func listPrinters() ([]string, string, error) {
+	var listCmd, defaultCmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		listCmd = exec.Command("powershell", "-NoProfile", "-Command", "Get-Printer | Select-Object -ExpandProperty Name")
+		defaultCmd = exec.Command("powershell", "-NoProfile", "-Command",
+			"Get-CimInstance Win32_Printer -Filter 'Default=True' | Select-Object -ExpandProperty Name")
+	} else {
+		listCmd = exec.Command("lpstat", "-e")
+		defaultCmd = exec.Command("lpstat", "-d")
+	}
+	hideConsoleWindow(listCmd)
+	hideConsoleWindow(defaultCmd)
+
+	out, err := listCmd.Output()
+	if err != nil {
+		return nil, "", fmt.Errorf("no se pudo obtener la lista de impresoras: %v", err)
+	}
+	printers := parsePrinters(string(out))
+	def := ""
+	if out, err := defaultCmd.Output(); err == nil && runtime.GOOS == "windows" {
+		def = strings.TrimSpace(string(out))
+	} else if err == nil {
+		def = parseDefaultPrinter(string(out))
+	}
+	return printers, def, nil
+}
+
+// printText manda text a printer. En Windows lo imprime PowerShell con Out-Printer y en
+// Linux y macOS CUPS con lp; los dos usan letra monoespaciada para el texto plano.
+func printText(printer, title, text string) error {
+	f, err := ioutil.TempFile("", "nota-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", fmt.Sprintf(
+			"Get-Content -Encoding UTF8 -LiteralPath %s | Out-Printer -Name %s", psQuote(f.Name()), psQuote(printer)))
+	} else {
+		cmd = exec.Command("lp", "-d", printer, "-t", title, "-o", "cpi=12", "-o", "lpi=7", f.Name())
+	}
+	hideConsoleWindow(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("la impresora %q no aceptó el trabajo: %v %s", printer, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// showPrint muestra cómo quedará la hoja y la manda a la impresora elegida. La lista de
+// impresoras se pide al sistema sin trabar la ventana.
+func (n *NotePad) showPrint(window fyne.Window) {
+	if n.current == "" || n.locked {
+		return
+	}
+	title := n.mailNoteName()
+	text := printableNote(title, n.noteText(), sessionInitials(), time.Now())
+
+	preview := widget.NewLabel(text)
+	preview.TextStyle = fyne.TextStyle{Monospace: true}
+	printerSelect := widget.NewSelect(nil, nil)
+	printerSelect.PlaceHolder = "Buscando impresoras..."
+
+	content := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabel("Impresora"), nil, printerSelect), nil, nil, nil,
+		container.NewScroll(preview),
+	)
+	d := dialog.NewCustomConfirm("🖨️ Imprimir nota", "Imprimir", "Cancelar", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		printer := printerSelect.Selected
+		if printer == "" {
+			dialog.ShowError(fmt.Errorf("elige una impresora"), window)
+			return
+		}
+		n.printer = printer
+		n.statusLabel.SetText(fmt.Sprintf("Estado: 🖨️ Enviando la nota a %s...", printer))
+		go func() {
+			err := printText(printer, title, text)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, window)
+					n.statusLabel.SetText("Estado: ⚠️ No se pudo imprimir la nota")
+					return
+				}
+				n.statusLabel.SetText(fmt.Sprintf("Estado: 🖨️ Nota %q enviada a %s", title, printer))
+			})
+		}()
+	}, window)
+	d.Resize(fyne.NewSize(700, 520))
+	d.Show()
+
+	go func() {
+		printers, def, err := listPrinters()
+		fyne.Do(func() {
+			if err != nil || len(printers) == 0 {
+				printerSelect.PlaceHolder = "No hay impresoras instaladas"
+				printerSelect.Refresh()
+				return
+			}
+			printerSelect.SetOptions(printers)
+			// La última usada en esta sesión; si no, la predeterminada del sistema
+			for _, name := range []string{n.printer, def, printers[0]} {
+				if name != "" && slices.Contains(printers, name) {
+					printerSelect.SetSelected(name)
+					break
+				}
+			}
+		})
+	}()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintableNote(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 30, 0, 0, time.Local)
+	text := "***LISTA***  \r\n......0154 LGARCIA 15:04 JRIOS\n# Las horas se actualizan\n\n"
+	want := "NOTA: 2026-10-16\n" +
+		"Impresa el 16/10/2026 18:30 por JRIOS\n" +
+		strings.Repeat("=", printMinWidth) + "\n\n" +
+		"***LISTA***\n......0154 LGARCIA 15:04 JRIOS\n"
+	if got := printableNote("2026-10-16", text, "JRIOS", now); got != want {
+		t.Errorf("printableNote = %q, quería %q", got, want)
+	}
+
+	long := strings.Repeat("x", 200)
+	got := printableNote("larga", long, "", now)
+	if !strings.Contains(got, "Impresa el 16/10/2026 18:30\n"+strings.Repeat("=", printMaxWidth)+"\n") {
+		t.Errorf("el encabezado debería medir %d columnas y no llevar usuario: %q", printMaxWidth, got[:120])
+	}
+}
+
+func TestParsePrinters(t *testing.T) {
+	got := parsePrinters("HP_LaserJet\r\n\n  Epson L3150  \n")
+	want := []string{"HP_LaserJet", "Epson L3150"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePrinters = %q, quería %q", got, want)
+	}
+}
+
+func TestParseDefaultPrinter(t *testing.T) {
+	tests := map[string]string{
+		"system default destination: HP_LaserJet\n":         "HP_LaserJet",
+		"destino predeterminado del sistema: Almacen_Epson": "Almacen_Epson",
+		"no system default destination\n":                   "",
+		"":                                                  "",
+	}
+	for output, want := range tests {
+		if got := parseDefaultPrinter(output); got != want {
+			t.Errorf("parseDefaultPrinter(%q) = %q, quería %q", output, got, want)
+		}
+	}
+}
+
+func TestPSQuote(t *testing.T) {
+	if got := psQuote(`C:\Temp\nota de O'Brien.txt`); got != `'C:\Temp\nota de O''Brien.txt'` {
+		t.Errorf("psQuote = %s", got)
+	}
+}
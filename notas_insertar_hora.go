@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Formatos que se insertan con Ctrl+T (hora) y Ctrl+Shift+T (fecha y hora); usan las
+// mismas marcas que los patrones de actualización automática
+const (
+	insertTimeFormat  = "HH:mm"
+	insertStampFormat = "dd/MM/yyyy HH:mm"
+)
+
+// insertText escribe text en la posición del cursor, reemplazando la selección si la hay,
+// igual que si se tecleara
+func insertText(e *widget.Entry, text string) {
+	for _, r := range text {
+		e.TypedRune(r)
+	}
+}
+
+// insertTimestamp inserta la hora actual con format en el cursor del editor
+func (n *NotePad) insertTimestamp(format string) {
+	insertText(&n.multiLine.Entry, formatTokens(format, time.Now()))
+}
+
+// createTimestampButtons registra los atajos en el editor (no en la ventana, para no
+// escribir en la nota desde otras pestañas) y devuelve los botones de la barra
+func (n *NotePad) createTimestampButtons() (*widget.Button, *widget.Button) {
+	insertTime := func() { n.insertTimestamp(insertTimeFormat) }
+	insertStamp := func() { n.insertTimestamp(insertStampFormat) }
+
+	n.multiLine.shortcuts[noteShortcut{key: fyne.KeyT}] = insertTime
+	n.multiLine.shortcuts[noteShortcut{key: fyne.KeyT, shift: true}] = insertStamp
+
+	return widget.NewButton("🕐 Hora", insertTime), widget.NewButton("📅 Fecha y hora", insertStamp)
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	snapshotsDir         = "historial"      // Dentro de la carpeta de notas, una subcarpeta por nota
+	snapshotSettingsFile = "historial.json" // Frecuencia y días que se conservan
+	defaultSnapshotDays  = 30
+	maxSnapshotDays      = 365
+	snapshotNowOption    = "Ahora (editor)"
+	snapshotListLayout   = "02/01 15:04:05"
+)
+
+// snapshotSettings controla el archivo de instantáneas. A diferencia de las versiones, que
+// guardan los últimos cambios, el archivo guarda el turno entero: una copia por hora o una
+// por guardado, y se borran por antigüedad.
+type snapshotSettings struct {
+	CadaGuardado bool // false: como mucho una por hora
+	Dias         int
+}
+
+func defaultSnapshotSettings() snapshotSettings {
+	return snapshotSettings{Dias: defaultSnapshotDays}
+}
+
+func (s snapshotSettings) validate() error {
+	if s.Dias < 1 || s.Dias > maxSnapshotDays {
+		return fmt.Errorf("los días del historial deben estar entre 1 y %d", maxSnapshotDays)
+	}
+	return nil
+}
+
+func noteSnapshotsDir(name string) string {
+	return filepath.Join(notesDir, snapshotsDir, name)
+}
+
+// snapshotDue indica si toca otra instantánea: con everySave siempre, si no solo al pasar a
+// otra hora del reloj respecto de latest
+func snapshotDue(latest, when time.Time, everySave bool) bool {
+	return everySave || latest.IsZero() || latest.Format("2006010215") != when.Format("2006010215")
+}
+
+// writeSnapshot guarda content en el archivo dir si toca según snapshotDue; igual que las
+// versiones, no repite un contenido idéntico al de la última
+func writeSnapshot(dir, content string, when time.Time, everySave bool, key []byte) (bool, error) {
+	snapshots, err := listVersions(dir)
+	if err != nil {
+		return false, err
+	}
+	if len(snapshots) > 0 && !snapshotDue(snapshots[0].Fecha, when, everySave) {
+		return false, nil
+	}
+	return writeVersion(dir, content, when, true, key)
+}
+
+// pruneSnapshots borra las instantáneas de más de days días
+func pruneSnapshots(dir string, days int, now time.Time) error {
+	snapshots, err := listVersions(dir)
+	if err != nil {
+		return err
+	}
+	limit := now.AddDate(0, 0, -days)
+	for _, s := range snapshots {
+		if s.Fecha.Before(limit) {
+			if err := os.Remove(s.Path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// diffStats cuenta las líneas agregadas y quitadas de un diff
+func diffStats(diff []diffLine) (added, removed int) {
+	for _, line := range diff {
+		switch line.Tipo {
+		case diffAdded:
+			added++
+		case diffRemoved:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+func loadSnapshotSettings() snapshotSettings {
+	settings := defaultSnapshotSettings()
+	data, err := ioutil.ReadFile(filepath.Join(notesDir, snapshotSettingsFile))
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", snapshotSettingsFile, err)
+		return defaultSnapshotSettings()
+	}
+	if err := settings.validate(); err != nil {
+		log.Printf("Historial inválido, se usa el predeterminado: %v", err)
+		return defaultSnapshotSettings()
+	}
+	return settings
+}
+
+func saveSnapshotSettings(settings snapshotSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(notesDir, snapshotSettingsFile), data, 0644)
+}
+
+// archiveSnapshot se llama al guardar la nota y guarda la instantánea si toca
+func (n *NotePad) archiveSnapshot(content string) {
+	if n.current == "" || n.external || content == "" {
+		return
+	}
+	now := time.Now()
+	dir := noteSnapshotsDir(n.current)
+	written, err := writeSnapshot(dir, content, now, n.archive.CadaGuardado, n.key)
+	if err == nil && written {
+		err = pruneSnapshots(dir, n.archive.Dias, now)
+	}
+	if err != nil {
+		log.Printf("Error guardando el historial de %q: %v", n.current, err)
+	}
+}
+
+// showSnapshots abre la línea de tiempo del historial de la nota: cada instantánea con las
+// líneas que cambiaron desde la anterior, y la comparación entre dos momentos cualesquiera
+func (n *NotePad) showSnapshots(window fyne.Window) {
+	if n.external {
+		dialog.ShowInformation("🗂️ Historial", "Los archivos abiertos con 📂 Abrir no guardan historial; solo las notas de la carpeta.", window)
+		return
+	}
+	snapshots, err := listVersions(noteSnapshotsDir(n.current))
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	texts := map[int]string{}
+	read := func(id int) (string, error) {
+		if id == len(snapshots) {
+			return n.noteText(), nil
+		}
+		if text, ok := texts[id]; ok {
+			return text, nil
+		}
+		data, err := readNoteFile(snapshots[id].Path, n.key)
+		if err != nil {
+			return "", err
+		}
+		texts[id] = string(data)
+		return texts[id], nil
+	}
+
+	// Las opciones siguen el orden de snapshots; la última de "Hasta" es el editor
+	options := make([]string, len(snapshots))
+	for i, s := range snapshots {
+		options[i] = s.Fecha.Format("02/01/2006 15:04:05")
+	}
+	fromSelect := widget.NewSelect(options, nil)
+	toSelect := widget.NewSelect(append(append([]string{}, options...), snapshotNowOption), nil)
+	summary := widget.NewLabel("")
+	diffScroll := container.NewScroll(widget.NewLabel("Elige un momento de la lista o dos en Desde y Hasta para ver qué cambió."))
+
+	compare := func() {
+		from, to := fromSelect.SelectedIndex(), toSelect.SelectedIndex()
+		if from < 0 || to < 0 {
+			return
+		}
+		before, err := read(from)
+		if err == nil {
+			var after string
+			if after, err = read(to); err == nil {
+				diff := diffLines(before, after)
+				added, removed := diffStats(diff)
+				summary.SetText(fmt.Sprintf("%d líneas agregadas y %d quitadas", added, removed))
+				diffScroll.Content = diffView(diff)
+				diffScroll.Refresh()
+				return
+			}
+		}
+		dialog.ShowError(err, window)
+	}
+	fromSelect.OnChanged = func(string) { compare() }
+	toSelect.OnChanged = func(string) { compare() }
+
+	// Cambios de cada instantánea respecto de la anterior; se calculan al mostrarse
+	changes := map[int]string{}
+	list := widget.NewList(
+		func() int {
+			return len(snapshots)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if _, ok := changes[id]; !ok {
+				changes[id] = ""
+				if id+1 < len(snapshots) {
+					before, err1 := read(id + 1)
+					after, err2 := read(id)
+					if err1 == nil && err2 == nil {
+						added, removed := diffStats(diffLines(before, after))
+						changes[id] = fmt.Sprintf("  +%d −%d", added, removed)
+					}
+				}
+			}
+			obj.(*widget.Label).SetText(snapshots[id].Fecha.Format(snapshotListLayout) + changes[id])
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		from := id
+		if id+1 < len(snapshots) {
+			from = id + 1
+		}
+		fromSelect.SetSelectedIndex(from)
+		toSelect.SetSelectedIndex(id)
+	}
+
+	everySaveCheck := widget.NewCheck("Una copia por guardado (si no, una por hora)", nil)
+	everySaveCheck.SetChecked(n.archive.CadaGuardado)
+	daysInput := widget.NewEntry()
+	daysInput.SetText(strconv.Itoa(n.archive.Dias))
+	applyButton := widget.NewButton("Aplicar", func() {
+		days, err := strconv.Atoi(strings.TrimSpace(daysInput.Text))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("los días deben ser un número"), window)
+			return
+		}
+		settings := snapshotSettings{CadaGuardado: everySaveCheck.Checked, Dias: days}
+		if err := settings.validate(); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if err := saveSnapshotSettings(settings); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.archive = settings
+		if err := pruneSnapshots(noteSnapshotsDir(n.current), days, time.Now()); err != nil {
+			dialog.ShowError(err, window)
+		}
+	})
+
+	listScroll := container.NewScroll(list)
+	listScroll.SetMinSize(fyne.NewSize(190, 300))
+	selectors := container.NewGridWithColumns(2,
+		container.NewBorder(nil, nil, widget.NewLabel("Desde"), nil, fromSelect),
+		container.NewBorder(nil, nil, widget.NewLabel("Hasta"), nil, toSelect),
+	)
+	content := container.NewBorder(
+		container.NewVBox(selectors, summary),
+		container.NewBorder(nil, nil, everySaveCheck,
+			container.NewHBox(widget.NewLabel("Conservar"), container.NewGridWrap(fyne.NewSize(60, daysInput.MinSize().Height), daysInput), widget.NewLabel("días"), applyButton)),
+		listScroll,
+		nil,
+		diffScroll,
+	)
+	if len(snapshots) == 0 {
+		diffScroll.Content = widget.NewLabel("Todavía no hay historial de esta nota. Se guarda una copia al guardar, como mucho una por hora salvo que elijas una por guardado.")
+	} else {
+		// Al abrir se ve lo que cambió desde la primera copia de hoy, o desde la última si no hay de hoy
+		first := 0
+		today := time.Now().Format("2006-01-02")
+		for i, s := range snapshots {
+			if s.Fecha.Format("2006-01-02") == today {
+				first = i
+			}
+		}
+		fromSelect.SetSelectedIndex(first)
+		toSelect.SetSelected(snapshotNowOption)
+	}
+
+	d := dialog.NewCustom(fmt.Sprintf("🗂️ Historial de %q", n.current), "Cerrar", content, window)
+	d.Resize(fyne.NewSize(860, 540))
+	d.Show()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotDue(t *testing.T) {
+	at := time.Date(2026, 10, 16, 14, 5, 0, 0, time.Local)
+	tests := []struct {
+		latest    time.Time
+		everySave bool
+		want      bool
+	}{
+		{time.Time{}, false, true},
+		{at.Add(-4 * time.Minute), false, false},
+		{at.Add(-6 * time.Minute), false, true}, // 13:59, otra hora del reloj
+		{at.Add(-time.Minute), true, true},
+		{at.AddDate(0, 0, -1), false, true},
+	}
+	for _, tt := range tests {
+		if got := snapshotDue(tt.latest, at, tt.everySave); got != tt.want {
+			t.Errorf("snapshotDue(%v, %v, %v) = %v, quería %v", tt.latest, at, tt.everySave, got, tt.want)
+		}
+	}
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "General")
+	start := time.Date(2026, 10, 16, 9, 10, 0, 0, time.Local)
+
+	if written, err := writeSnapshot(dir, "uno", start, false, nil); err != nil || !written {
+		t.Fatalf("la primera copia debería guardarse: %v, %v", written, err)
+	}
+	if written, _ := writeSnapshot(dir, "dos", start.Add(30*time.Minute), false, nil); written {
+		t.Error("por hora no debería guardarse otra copia en la misma hora")
+	}
+	if written, _ := writeSnapshot(dir, "dos", start.Add(30*time.Minute), true, nil); !written {
+		t.Error("con una copia por guardado debería guardarse")
+	}
+	if written, _ := writeSnapshot(dir, "dos", start.Add(time.Hour), false, nil); written {
+		t.Error("un contenido igual al de la última copia no debería guardarse")
+	}
+	if written, _ := writeSnapshot(dir, "tres", start.Add(time.Hour), false, nil); !written {
+		t.Error("en otra hora con contenido distinto debería guardarse")
+	}
+	if snapshots, _ := listVersions(dir); len(snapshots) != 3 {
+		t.Errorf("deberían quedar 3 copias: %v", snapshots)
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	for i, days := range []int{40, 31, 29, 0} {
+		if _, err := writeVersion(dir, string(rune('a'+i)), now.AddDate(0, 0, -days), true, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pruneSnapshots(dir, 30, now); err != nil {
+		t.Fatal(err)
+	}
+	snapshots, _ := listVersions(dir)
+	if len(snapshots) != 2 || !snapshots[1].Fecha.Equal(now.AddDate(0, 0, -29)) {
+		t.Errorf("deberían quedar las de los últimos 30 días: %v", snapshots)
+	}
+}
+
+func TestDiffStats(t *testing.T) {
+	added, removed := diffStats(diffLines("a\nb\nc", "a\nc\nd\ne"))
+	if added != 2 || removed != 1 {
+		t.Errorf("diffStats = +%d -%d, quería +2 -1", added, removed)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// lineCount cuenta las líneas que muestra el editor; un texto vacío tiene una
+func lineCount(text string) int {
+	return strings.Count(text, "\n") + 1
+}
+
+// lineNumbers arma el texto del margen: un número por línea
+func lineNumbers(count int) string {
+	var b strings.Builder
+	for i := 1; i <= count; i++ {
+		if i > 1 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(strconv.Itoa(i))
+	}
+	return b.String()
+}
+
+// parseLineNumber convierte lo escrito en "ir a línea" (contando desde 1) en la fila del
+// editor (contando desde 0)
+func parseLineNumber(input string, total int) (int, error) {
+	line, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		return 0, fmt.Errorf("escribe un número de línea")
+	}
+	if line < 1 || line > total {
+		return 0, fmt.Errorf("la nota tiene %d líneas", total)
+	}
+	return line - 1, nil
+}
+
+// createEditorScroll pone el editor junto al margen con los números de línea. El editor
+// no se desplaza por su cuenta sino dentro del mismo scroll que el margen, así los números
+// siempre quedan a la altura de su línea.
+func (n *NotePad) createEditorScroll() *container.Scroll {
+	n.multiLine.Scroll = container.ScrollNone
+	n.gutter = widget.NewLabelWithStyle("1", fyne.TextAlignTrailing, fyne.TextStyle{})
+	n.gutterLines = 1
+	n.updateGutter()
+
+	// El texto del editor empieza después de su borde; el margen se baja lo mismo
+	gutterBox := container.New(layout.NewCustomPaddedLayout(theme.InputBorderSize(), 0, 0, 0), n.gutter)
+	n.editorTheme = &noteTheme{}
+	n.themeBox = container.NewThemeOverride(container.NewBorder(nil, nil, gutterBox, nil, n.multiLine), n.editorTheme)
+	n.applyFont(loadFontSettings())
+	n.editorBox = container.NewScroll(n.themeBox)
+	n.multiLine.OnCursorChanged = n.showCursor
+	return n.editorBox
+}
+
+// updateGutter ajusta los números del margen cuando cambia la cantidad de líneas
+func (n *NotePad) updateGutter() {
+	if n.gutter == nil {
+		return
+	}
+	if count := lineCount(n.multiLine.Text); count != n.gutterLines {
+		n.gutterLines = count
+		n.gutter.SetText(lineNumbers(count))
+	}
+}
+
+// showCursor desplaza el scroll del editor para que el cursor quede a la vista
+func (n *NotePad) showCursor() {
+	if n.editorBox == nil {
+		return
+	}
+	textSize := n.editorTheme.textSize
+	lineHeight := fyne.MeasureText("0", textSize, n.multiLine.TextStyle).Height
+	top := theme.InputBorderSize() + theme.InnerPadding() + float32(n.multiLine.CursorRow)*lineHeight
+	left := n.gutter.Size().Width + theme.InnerPadding()
+	lines := strings.Split(n.multiLine.Text, "\n")
+	if row := n.multiLine.CursorRow; row < len(lines) {
+		runes := []rune(lines[row])
+		if col := n.multiLine.CursorColumn; col <= len(runes) {
+			left += fyne.MeasureText(string(runes[:col]), textSize, n.multiLine.TextStyle).Width
+		}
+	}
+
+	view := n.editorBox.Size()
+	offset := n.editorBox.Offset
+	if top < offset.Y {
+		offset.Y = top
+	} else if bottom := top + lineHeight + theme.InnerPadding(); bottom > offset.Y+view.Height {
+		offset.Y = bottom - view.Height
+	}
+	if left < offset.X+n.gutter.Size().Width {
+		offset.X = fyne.Max(0, left-n.gutter.Size().Width-theme.InnerPadding())
+	} else if right := left + theme.InnerPadding(); right > offset.X+view.Width {
+		offset.X = right - view.Width
+	}
+	if offset != n.editorBox.Offset {
+		n.editorBox.ScrollToOffset(offset)
+	}
+}
+
+// goToRow lleva el cursor al principio de la fila row del editor y la deja a la vista
+func (n *NotePad) goToRow(row int, window fyne.Window) {
+	selectRange(&n.multiLine.Entry, row, 0, 0)
+	window.Canvas().Focus(n.multiLine)
+	n.showCursor()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Línea %d", row+1))
+}
+
+// showGoToLine pregunta a qué línea ir y deja el cursor al principio de ella
+func (n *NotePad) showGoToLine(window fyne.Window) {
+	total := lineCount(n.multiLine.Text)
+	lineInput := widget.NewEntry()
+	lineInput.SetPlaceHolder(fmt.Sprintf("1 - %d", total))
+
+	var d *dialog.FormDialog
+	d = dialog.NewForm("↪️ Ir a línea", "Ir", "Cancelar",
+		[]*widget.FormItem{widget.NewFormItem("Línea", lineInput)},
+		func(ok bool) {
+			if !ok {
+				window.Canvas().Focus(n.multiLine)
+				return
+			}
+			row, err := parseLineNumber(lineInput.Text, total)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.goToRow(row, window)
+		}, window)
+	lineInput.OnSubmitted = func(string) { d.Submit() }
+	d.Show()
+	window.Canvas().Focus(lineInput)
+}
+
+// createGoToLine registra Ctrl+G y devuelve el botón de la barra
+func (n *NotePad) createGoToLine(window fyne.Window) *widget.Button {
+	open := func() { n.showGoToLine(window) }
+	n.addNoteShortcut(window, noteShortcut{key: fyne.KeyG}, open)
+	return widget.NewButton("↪️ Ir a línea", open)
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestLineNumbers(t *testing.T) {
+	if got := lineCount(""); got != 1 {
+		t.Errorf("lineCount vacío = %d", got)
+	}
+	if got := lineCount("LISTA\n0154\n"); got != 3 {
+		t.Errorf("lineCount = %d", got)
+	}
+	if got := lineNumbers(3); got != "1\n2\n3" {
+		t.Errorf("lineNumbers = %q", got)
+	}
+}
+
+func TestParseLineNumber(t *testing.T) {
+	if row, err := parseLineNumber(" 37 ", 40); err != nil || row != 36 {
+		t.Errorf("parseLineNumber = %d, %v", row, err)
+	}
+	for _, input := range []string{"", "abc", "0", "41", "-2"} {
+		if _, err := parseLineNumber(input, 40); err == nil {
+			t.Errorf("%q debería dar error", input)
+		}
+	}
+}
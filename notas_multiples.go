@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	defaultNoteName = "General" // Nota inicial; recibe el contenido del bloc único anterior
+	noteNameMaxLen  = 60
+)
+
+// validNoteName limpia el nombre de una nota y comprueba que sirva como nombre de archivo
+func validNoteName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("el nombre de la nota no puede estar vacío")
+	}
+	if len([]rune(name)) > noteNameMaxLen {
+		return "", fmt.Errorf("el nombre de la nota no puede superar %d caracteres", noteNameMaxLen)
+	}
+	if strings.ContainsAny(name, `<>:"/\|?*`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf(`el nombre no puede empezar con punto ni contener < > : " / \ | ? *`)
+	}
+	return name, nil
+}
+
+func notePath(name string) string {
+	return filepath.Join(notesDir, name+".txt")
+}
+
+// listNotes devuelve los nombres de las notas de dir en orden alfabético
+func listNotes(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(f), ".txt"))
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	return names, nil
+}
+
+// migrateLegacyNote copia el bloc único a la nota inicial la primera vez que se usan
+// varias notas. El archivo original se conserva como respaldo.
+func migrateLegacyNote(dir, legacy string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if names, err := listNotes(dir); err != nil || len(names) > 0 {
+		return err
+	}
+	data, err := ioutil.ReadFile(legacy)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, defaultNoteName+".txt"), data, 0644)
+}
+
+// loadNotes prepara la lista de notas y elige la primera
+func (n *NotePad) loadNotes() {
+	if err := migrateLegacyNote(notesDir, legacyNoteFile); err != nil {
+		log.Printf("Error migrando %s: %v", legacyNoteFile, err)
+	}
+	n.refreshNotes()
+	n.current = n.notes[0]
+	n.external = false
+	if n.daily {
+		n.today = dailyNoteName(time.Now())
+		n.current = n.today
+	}
+}
+
+// refreshNotes relee los nombres desde la carpeta; siempre queda al menos la nota inicial.
+// Con el archivo diario las notas de cada día van en la lista de días y no en esta.
+func (n *NotePad) refreshNotes() {
+	names, err := listNotes(notesDir)
+	if err != nil {
+		log.Printf("Error listando notas: %v", err)
+	}
+	regular, days := splitDailyNotes(names)
+	n.days = days
+	if n.daily {
+		names = regular
+	}
+	if len(names) == 0 {
+		names = []string{defaultNoteName}
+	}
+	n.notes = names
+	if n.notesList != nil {
+		n.notesList.Refresh()
+	}
+	n.refreshDays()
+}
+
+// selectNoteInList marca la nota actual en la lista sin volver a cargarla
+func (n *NotePad) selectNoteInList() {
+	for i, name := range n.notes {
+		if name == n.current {
+			n.notesList.Select(i)
+			return
+		}
+	}
+}
+
+// switchNote guarda la nota abierta y carga otra
+func (n *NotePad) switchNote(name string) {
+	if name == n.current {
+		return
+	}
+	n.saveContent()
+	n.current = name
+	n.external = false
+	n.loadContent()
+	n.refreshDays()
+	n.rememberRecent()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Nota %q abierta", name))
+}
+
+func (n *NotePad) noteExists(name string) bool {
+	for _, existing := range n.notes {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// askNoteName pide un nombre de nota válido y que no exista todavía; extra agrega más
+// campos al diálogo, que se leen en onName
+func (n *NotePad) askNoteName(title, initial string, window fyne.Window, onName func(string), extra ...*widget.FormItem) {
+	entry := widget.NewEntry()
+	entry.SetText(initial)
+	dialog.ShowForm(title, "Aceptar", "Cancelar",
+		append([]*widget.FormItem{widget.NewFormItem("Nombre", entry)}, extra...),
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			name, err := validNoteName(entry.Text)
+			if err == nil && n.noteExists(name) && !strings.EqualFold(name, initial) {
+				err = fmt.Errorf("ya existe una nota llamada %q", name)
+			}
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			onName(name)
+		}, window)
+}
+
+func (n *NotePad) createNotesSidebar(window fyne.Window) fyne.CanvasObject {
+	n.notesList = widget.NewList(
+		func() int {
+			return len(n.notes)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(n.notes[id])
+		},
+	)
+	n.notesList.OnSelected = func(id widget.ListItemID) {
+		n.switchNote(n.notes[id])
+	}
+
+	newButton := widget.NewButton("➕", func() {
+		templateSelect := newTemplateSelect()
+		n.askNoteName("Nueva nota", "", window, func(name string) {
+			if err := ioutil.WriteFile(notePath(name), nil, 0644); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			n.refreshNotes()
+			n.switchNote(name)
+			n.selectNoteInList()
+			if templateSelect.Selected != noTemplate {
+				n.insertCompanyTemplate(templateSelect.Selected)
+			}
+		}, widget.NewFormItem("Plantilla", templateSelect))
+	})
+
+	renameButton := widget.NewButton("✏️", func() {
+		if n.external {
+			dialog.ShowInformation("Renombrar nota", "Solo se pueden renombrar las notas de la lista; elige una primero.", window)
+			return
+		}
+		n.askNoteName("Renombrar nota", n.current, window, func(name string) {
+			if name == n.current {
+				return
+			}
+			n.saveContent()
+			if err := os.Rename(notePath(n.current), notePath(name)); err != nil && !os.IsNotExist(err) {
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := os.Rename(noteVersionsDir(n.current), noteVersionsDir(name)); err != nil && !os.IsNotExist(err) {
+				log.Printf("Error renombrando versiones de %q: %v", n.current, err)
+			}
+			if err := os.Rename(noteSnapshotsDir(n.current), noteSnapshotsDir(name)); err != nil && !os.IsNotExist(err) {
+				log.Printf("Error renombrando el historial de %q: %v", n.current, err)
+			}
+			n.current = name
+			n.refreshNotes()
+			n.selectNoteInList()
+			n.statusLabel.SetText(fmt.Sprintf("Estado: Nota renombrada a %q", name))
+		})
+	})
+
+	deleteButton := widget.NewButton("🗑️", func() {
+		if n.external {
+			dialog.ShowInformation("Eliminar nota", "Solo se pueden eliminar las notas de la lista; elige una primero.", window)
+			return
+		}
+		name := n.current
+		dialog.ShowConfirm("Eliminar nota", fmt.Sprintf("¿Eliminar la nota %q? No se puede deshacer.", name), func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if err := os.Remove(notePath(name)); err != nil && !os.IsNotExist(err) {
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := os.RemoveAll(noteVersionsDir(name)); err != nil {
+				log.Printf("Error eliminando versiones de %q: %v", name, err)
+			}
+			if err := os.RemoveAll(noteSnapshotsDir(name)); err != nil {
+				log.Printf("Error eliminando el historial de %q: %v", name, err)
+			}
+			n.refreshNotes()
+			// La nota eliminada no debe volver a guardarse al cambiar
+			n.current = ""
+			n.switchNote(n.notes[0])
+			n.selectNoteInList()
+		}, window)
+	})
+
+	listScroll := container.NewScroll(n.notesList)
+	listScroll.SetMinSize(fyne.NewSize(160, 300))
+
+	top := container.NewVBox(n.createDailyBox(window), n.createRecentSelect(window))
+	return container.NewBorder(top, container.NewGridWithColumns(3, newButton, renameButton, deleteButton), nil, nil, listScroll)
+}
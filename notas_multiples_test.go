@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidNoteName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"  REPOSICIÓN ", "REPOSICIÓN", false},
+		{"Recordatorios personales", "Recordatorios personales", false},
+		{"", "", true},
+		{"   ", "", true},
+		{"a/b", "", true},
+		{`C:\notas`, "", true},
+		{".oculta", "", true},
+		{"¿qué?", "", true},
+		{strings.Repeat("n", noteNameMaxLen+1), "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := validNoteName(tt.name)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("validNoteName(%q) = %q, %v", tt.name, got, err)
+		}
+	}
+}
+
+func TestListNotes(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"zettacom.txt", "General.txt", "REPOSICIÓN.txt", "respaldo.bak"} {
+		if err := os.WriteFile(filepath.Join(dir, f), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := listNotes(dir)
+	want := []string{"General", "REPOSICIÓN", "zettacom"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("listNotes() = %v, %v; se esperaba %v", got, err, want)
+	}
+}
+
+func TestMigrateLegacyNote(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "notas")
+	legacy := filepath.Join(base, "bloc_notas.txt")
+	if err := os.WriteFile(legacy, []byte("# Guardado: hoy\n......0154 LGARCIA"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrateLegacyNote(dir, legacy); err != nil {
+		t.Fatalf("migrateLegacyNote() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, defaultNoteName+".txt"))
+	if err != nil || !strings.Contains(string(data), "0154 LGARCIA") {
+		t.Fatalf("la nota inicial no recibió el bloc anterior: %q, %v", data, err)
+	}
+
+	// Con notas ya creadas no se vuelve a copiar
+	if err := os.WriteFile(legacy, []byte("otro contenido"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateLegacyNote(dir, legacy); err != nil {
+		t.Fatalf("migrateLegacyNote() error = %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, defaultNoteName+".txt"))
+	if strings.Contains(string(data), "otro contenido") {
+		t.Error("la migración no debería repetirse")
+	}
+}
+
+func TestMigrateLegacyNoteWithoutLegacyFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "notas")
+	if err := migrateLegacyNote(dir, filepath.Join(dir, "no_existe.txt")); err != nil {
+		t.Fatalf("migrateLegacyNote() error = %v", err)
+	}
+	if names, _ := listNotes(dir); len(names) != 0 {
+		t.Errorf("no debería crear notas: %v", names)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// Criterios de "Ordenar sección"
+const (
+	sortByCode = "Por código"
+	sortByTech = "Por técnico"
+	sortByUser = "Por quién registró"
+	sortByText = "Alfabético"
+)
+
+var sortKeys = []string{sortByCode, sortByTech, sortByUser, sortByText}
+
+// isFixedLine indica las líneas que no se mueven al ordenar: vacías, títulos entre
+// asteriscos, separadores y comentarios
+func isFixedLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#") ||
+		starTitleRegex.MatchString(trimmed) || separatorRegex.MatchString(trimmed)
+}
+
+// lineSortKey es el valor por el que se ordena line. Las líneas que no son de reposición
+// se ordenan por su primer código o por su texto.
+func lineSortKey(line, key string) string {
+	text := strings.TrimLeft(strings.TrimSpace(line), "!. \t")
+	m := reposicionLineRegex.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+	switch {
+	case key == sortByCode && m != nil:
+		return m[2]
+	case key == sortByCode:
+		if codes := extractCodes(line); len(codes) > 0 {
+			return codes[0]
+		}
+	case key == sortByTech && m != nil:
+		return m[3]
+	case key == sortByUser && m != nil:
+		return m[5]
+	}
+	return text
+}
+
+// lessSortKey compara sin distinguir mayúsculas; dos códigos numéricos se comparan como
+// números para que 83 vaya antes que 0154
+func lessSortKey(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil && x != y {
+		return x < y
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// sortLines ordena las líneas por key sin sacarlas de su sección: los títulos, comentarios
+// y líneas vacías quedan en su lugar y cada tramo entre ellos se ordena por separado
+func sortLines(lines []string, key string) []string {
+	sorted := append([]string(nil), lines...)
+	for start := 0; start < len(sorted); {
+		if starTitleRegex.MatchString(strings.TrimSpace(sorted[start])) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(sorted) && !starTitleRegex.MatchString(strings.TrimSpace(sorted[end])) {
+			end++
+		}
+
+		var slots []int
+		var movable []string
+		for i := start; i < end; i++ {
+			if !isFixedLine(sorted[i]) {
+				slots = append(slots, i)
+				movable = append(movable, sorted[i])
+			}
+		}
+		sort.SliceStable(movable, func(i, j int) bool {
+			return lessSortKey(lineSortKey(movable[i], key), lineSortKey(movable[j], key))
+		})
+		for i, slot := range slots {
+			sorted[slot] = movable[i]
+		}
+		start = end
+	}
+	return sorted
+}
+
+// sectionRange devuelve las líneas bajo el título entre asteriscos donde está row, hasta
+// el siguiente título
+func sectionRange(lines []string, row int) (int, int) {
+	first := 0
+	for i := row; i >= 0 && i < len(lines); i-- {
+		if starTitleRegex.MatchString(strings.TrimSpace(lines[i])) {
+			first = i + 1
+			break
+		}
+	}
+	last := len(lines) - 1
+	for i := first; i < len(lines); i++ {
+		if starTitleRegex.MatchString(strings.TrimSpace(lines[i])) {
+			last = i - 1
+			break
+		}
+	}
+	return first, last
+}
+
+// selectionRows devuelve la primera y la última línea de la selección. El cursor queda en
+// uno de los extremos; si el texto anterior al cursor termina con lo seleccionado, está al
+// final.
+func selectionRows(text string, cursor int, selected string) (int, int) {
+	row, _ := offsetToRowCol(text, cursor)
+	span := strings.Count(selected, "\n")
+	if cursor >= len(selected) && text[cursor-len(selected):cursor] == selected {
+		return row - span, row
+	}
+	return row, row + span
+}
+
+// sortSection ordena las líneas seleccionadas o, sin selección, la sección del cursor
+func (n *NotePad) sortSection(key string) {
+	text := n.multiLine.Text
+	lines := strings.Split(text, "\n")
+	var first, last int
+	if selected := n.multiLine.SelectedText(); selected != "" {
+		cursor := rowColToOffset(text, n.multiLine.CursorRow, n.multiLine.CursorColumn)
+		first, last = selectionRows(text, cursor, selected)
+	} else {
+		first, last = sectionRange(lines, n.multiLine.CursorRow)
+	}
+	if first > last {
+		n.statusLabel.SetText("Estado: La sección está vacía")
+		return
+	}
+
+	sorted := sortLines(lines[first:last+1], key)
+	if strings.Join(sorted, "\n") == strings.Join(lines[first:last+1], "\n") {
+		n.statusLabel.SetText("Estado: La sección ya estaba ordenada")
+		return
+	}
+	result := append(append(append([]string(nil), lines[:first]...), sorted...), lines[last+1:]...)
+
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	n.applyEdit(historySort, strings.Join(result, "\n"))
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+	n.multiLine.Refresh()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Líneas %d a %d ordenadas (%s)", first+1, last+1, strings.ToLower(key)))
+}
+
+// createSortSelect arma la lista de "Ordenar sección"; elegir un criterio ordena en el acto
+func (n *NotePad) createSortSelect() *widget.Select {
+	var sortSelect *widget.Select
+	sortSelect = widget.NewSelect(sortKeys, func(key string) {
+		if key == "" {
+			return
+		}
+		n.sortSection(key)
+		sortSelect.ClearSelected()
+	})
+	sortSelect.PlaceHolder = "↕️ Ordenar sección"
+	return sortSelect
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSortLines(t *testing.T) {
+	lines := strings.Split(`***ZETTACOM***
+......0154 LGARCIA 15:04 MGAVINO
+......0083 JVILCATOMA 15:04 JRIOS
+# comentario
+......0017 NCRISOSTOMO 15:04 BTAIPE
+***OTRA***
+......0002 ZGOMEZ 15:04 AJUAREZ
+......0001 APEREZ 15:04 JRIOS`, "\n")
+
+	want := []string{
+		"***ZETTACOM***",
+		"......0017 NCRISOSTOMO 15:04 BTAIPE",
+		"......0083 JVILCATOMA 15:04 JRIOS",
+		"# comentario",
+		"......0154 LGARCIA 15:04 MGAVINO",
+		"***OTRA***",
+		"......0001 APEREZ 15:04 JRIOS",
+		"......0002 ZGOMEZ 15:04 AJUAREZ",
+	}
+	if got := sortLines(lines, sortByCode); !reflect.DeepEqual(got, want) {
+		t.Errorf("por código = %q", got)
+	}
+
+	byUser := sortLines(lines[1:5], sortByUser)
+	if byUser[0] != "......0017 NCRISOSTOMO 15:04 BTAIPE" || byUser[2] != "# comentario" || byUser[3] != "......0154 LGARCIA 15:04 MGAVINO" {
+		t.Errorf("por quién registró = %q", byUser)
+	}
+	byTech := sortLines(lines[6:], sortByTech)
+	if byTech[0] != "......0001 APEREZ 15:04 JRIOS" {
+		t.Errorf("por técnico = %q", byTech)
+	}
+}
+
+func TestLessSortKey(t *testing.T) {
+	if !lessSortKey("83", "0154") || lessSortKey("0154", "83") {
+		t.Error("los códigos numéricos deberían compararse como números")
+	}
+	if !lessSortKey("apérez", "Lgarcia") {
+		t.Error("no debería distinguir mayúsculas")
+	}
+}
+
+func TestSectionRange(t *testing.T) {
+	lines := []string{"***A***", "uno", "dos", "***B***", "tres", ""}
+	if first, last := sectionRange(lines, 2); first != 1 || last != 2 {
+		t.Errorf("sección A = %d, %d", first, last)
+	}
+	if first, last := sectionRange(lines, 3); first != 4 || last != 5 {
+		t.Errorf("desde el título B = %d, %d", first, last)
+	}
+	if first, last := sectionRange([]string{"uno", "dos"}, 1); first != 0 || last != 1 {
+		t.Errorf("sin títulos = %d, %d", first, last)
+	}
+}
+
+func TestSelectionRows(t *testing.T) {
+	text := "a\nbb\ncc\ndd"
+	if first, last := selectionRows(text, 7, "b\ncc"); first != 1 || last != 2 {
+		t.Errorf("cursor al final = %d, %d", first, last)
+	}
+	if first, last := selectionRows(text, 3, "b\ncc"); first != 1 || last != 2 {
+		t.Errorf("cursor al principio = %d, %d", first, last)
+	}
+}
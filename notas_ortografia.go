@@ -0,0 +1,487 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	spellDir          = "diccionario"              // Junto al programa: es_ES.dic y es_ES.aff de hunspell
+	spellPersonalFile = "diccionario_personal.txt" // Palabras agregadas con "Agregar al diccionario"
+	spellMaxSuggest   = 6
+	spellAlphabet     = "abcdefghijklmnñopqrstuvwxyzáéíóúü"
+)
+
+// spellWordRegex separa las palabras a revisar; las que tienen dígitos son códigos
+var spellWordRegex = regexp.MustCompile(`[\p{L}\d]+`)
+
+// affixRule es una regla PFX o SFX del .aff: quitar Strip y poner Add cuando la raíz
+// cumple la condición
+type affixRule struct {
+	flag  string
+	strip string
+	add   string
+	cross bool
+	cond  *regexp.Regexp
+}
+
+// spellDict es un diccionario de hunspell (.dic con las raíces y sus marcas, .aff con las
+// reglas de prefijos y sufijos) más las palabras propias del equipo
+type spellDict struct {
+	words    map[string][]string // Raíz y sus marcas
+	prefixes []affixRule
+	suffixes []affixRule
+	flagMode string
+	personal map[string]bool
+	cache    map[string]bool
+}
+
+func newSpellDict() *spellDict {
+	return &spellDict{words: map[string][]string{}, personal: map[string]bool{}, cache: map[string]bool{}}
+}
+
+// latin1 pasa a UTF-8 los diccionarios guardados en ISO-8859-1
+func latin1(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+// parseFlags separa las marcas de una raíz según FLAG del .aff
+func parseFlags(s, mode string) []string {
+	var flags []string
+	switch mode {
+	case "long":
+		runes := []rune(s)
+		for i := 0; i+1 < len(runes); i += 2 {
+			flags = append(flags, string(runes[i:i+2]))
+		}
+	case "num":
+		for _, f := range strings.Split(s, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				flags = append(flags, f)
+			}
+		}
+	default:
+		for _, r := range s {
+			flags = append(flags, string(r))
+		}
+	}
+	return flags
+}
+
+// readAff lee FLAG y las reglas PFX y SFX; lo demás del .aff no se usa
+func (d *spellDict) readAff(r io.Reader) error {
+	type header struct {
+		cross   bool
+		pending int
+	}
+	headers := map[string]*header{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(latin1(scanner.Text()))
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if fields[0] == "FLAG" {
+			d.flagMode = fields[1]
+			continue
+		}
+		if (fields[0] != "PFX" && fields[0] != "SFX") || len(fields) < 4 {
+			continue
+		}
+		key := fields[0] + fields[1]
+		h := headers[key]
+		if h == nil || h.pending == 0 {
+			count, err := strconv.Atoi(fields[3])
+			if err != nil {
+				return fmt.Errorf("encabezado %s %s inválido en el .aff", fields[0], fields[1])
+			}
+			headers[key] = &header{cross: fields[2] == "Y", pending: count}
+			continue
+		}
+		h.pending--
+
+		rule := affixRule{flag: fields[1], cross: h.cross}
+		if fields[2] != "0" {
+			rule.strip = fields[2]
+		}
+		if add, _, _ := strings.Cut(fields[3], "/"); add != "0" {
+			rule.add = add
+		}
+		cond := "."
+		if len(fields) > 4 {
+			cond = fields[4]
+		}
+		var err error
+		if fields[0] == "SFX" {
+			rule.cond, err = regexp.Compile("(" + cond + ")$")
+		} else {
+			rule.cond, err = regexp.Compile("^(" + cond + ")")
+		}
+		if err != nil {
+			continue // Condición que no se puede traducir: se pierde solo esa regla
+		}
+		if fields[0] == "SFX" {
+			d.suffixes = append(d.suffixes, rule)
+		} else {
+			d.prefixes = append(d.prefixes, rule)
+		}
+	}
+	return scanner.Err()
+}
+
+// readDic lee las raíces; la primera línea es la cantidad y se salta
+func (d *spellDict) readDic(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(latin1(scanner.Text()))
+		if first {
+			first = false
+			if _, err := strconv.Atoi(line); err == nil {
+				continue
+			}
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		word, flags, _ := strings.Cut(fields[0], "/")
+		d.words[word] = append(d.words[word], parseFlags(flags, d.flagMode)...)
+	}
+	return scanner.Err()
+}
+
+func (d *spellDict) hasFlag(word, flag string) bool {
+	for _, f := range d.words[word] {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// withSuffix indica si word sale de una raíz con un sufijo; extra es otra marca que la raíz
+// también debe tener (la del prefijo ya quitado)
+func (d *spellDict) withSuffix(word, extra string, needCross bool) bool {
+	for _, r := range d.suffixes {
+		if (needCross && !r.cross) || !strings.HasSuffix(word, r.add) || len(word) == len(r.add) {
+			continue
+		}
+		stem := word[:len(word)-len(r.add)] + r.strip
+		if r.cond.MatchString(stem) && d.hasFlag(stem, r.flag) && (extra == "" || d.hasFlag(stem, extra)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *spellDict) checkForm(word string) bool {
+	if _, ok := d.words[word]; ok || d.withSuffix(word, "", false) {
+		return true
+	}
+	for _, r := range d.prefixes {
+		if !strings.HasPrefix(word, r.add) || len(word) == len(r.add) {
+			continue
+		}
+		stem := r.strip + word[len(r.add):]
+		if !r.cond.MatchString(stem) {
+			continue
+		}
+		if d.hasFlag(stem, r.flag) || (r.cross && d.withSuffix(stem, r.flag, true)) {
+			return true
+		}
+	}
+	return false
+}
+
+// known indica si la palabra está bien escrita, tal cual o en minúsculas
+func (d *spellDict) known(word string) bool {
+	lower := strings.ToLower(word)
+	if d.personal[lower] {
+		return true
+	}
+	if ok, cached := d.cache[word]; cached {
+		return ok
+	}
+	ok := d.checkForm(word) || (lower != word && d.checkForm(lower))
+	d.cache[word] = ok
+	return ok
+}
+
+// add agrega una palabra propia, como un apellido o el nombre de un cliente
+func (d *spellDict) add(word string) {
+	d.personal[strings.ToLower(word)] = true
+}
+
+// misspelled indica si hay que marcar la palabra. No se revisan las de una letra, las
+// que tienen dígitos ni las que van en mayúsculas, que son códigos y usuarios como JRIOS.
+func (d *spellDict) misspelled(word string) bool {
+	if utf8.RuneCountInString(word) < 2 || word == strings.ToUpper(word) || strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+		return false
+	}
+	return !d.known(word)
+}
+
+// suggest propone las palabras del diccionario a un cambio de distancia: una letra de más,
+// de menos, cambiada (como una tilde que falta) o dos letras invertidas
+func (d *spellDict) suggest(word string) []string {
+	runes := []rune(strings.ToLower(word))
+	alphabet := []rune(spellAlphabet)
+	seen := map[string]bool{}
+	var suggestions []string
+	try := func(candidate []rune) {
+		s := string(candidate)
+		if seen[s] || len(suggestions) >= spellMaxSuggest {
+			return
+		}
+		seen[s] = true
+		if d.known(s) {
+			suggestions = append(suggestions, s)
+		}
+	}
+	edit := func(parts ...[]rune) []rune {
+		var out []rune
+		for _, p := range parts {
+			out = append(out, p...)
+		}
+		return out
+	}
+	for i := range runes {
+		for _, r := range alphabet {
+			if r != runes[i] {
+				try(edit(runes[:i], []rune{r}, runes[i+1:]))
+			}
+		}
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		try(edit(runes[:i], []rune{runes[i+1], runes[i]}, runes[i+2:]))
+	}
+	for i := range runes {
+		try(edit(runes[:i], runes[i+1:]))
+	}
+	for i := 0; i <= len(runes); i++ {
+		for _, r := range alphabet {
+			try(edit(runes[:i], []rune{r}, runes[i:]))
+		}
+	}
+
+	// Se respeta la mayúscula inicial de la palabra escrita
+	if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) {
+		for i, s := range suggestions {
+			r, size := utf8.DecodeRuneInString(s)
+			suggestions[i] = string(unicode.ToUpper(r)) + s[size:]
+		}
+	}
+	return suggestions
+}
+
+// misspelling es una palabra mal escrita de la nota; Linea cuenta desde 0 y Columna es la
+// posición en bytes dentro de la línea
+type misspelling struct {
+	Linea   int
+	Columna int
+	Palabra string
+}
+
+// findMisspellings busca las palabras mal escritas fuera de los títulos entre asteriscos
+func findMisspellings(text string, misspelled func(string) bool) []misspelling {
+	var found []misspelling
+	for i, line := range strings.Split(text, "\n") {
+		if starTitleRegex.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		for _, m := range spellWordRegex.FindAllStringIndex(line, -1) {
+			if word := line[m[0]:m[1]]; misspelled(word) {
+				found = append(found, misspelling{Linea: i, Columna: m[0], Palabra: word})
+			}
+		}
+	}
+	return found
+}
+
+// replaceMisspelling cambia la palabra por replacement si todavía está en su lugar
+func replaceMisspelling(text string, m misspelling, replacement string) (string, bool) {
+	lines := strings.Split(text, "\n")
+	if m.Linea >= len(lines) {
+		return text, false
+	}
+	line := lines[m.Linea]
+	if m.Columna+len(m.Palabra) > len(line) || line[m.Columna:m.Columna+len(m.Palabra)] != m.Palabra {
+		return text, false
+	}
+	lines[m.Linea] = line[:m.Columna] + replacement + line[m.Columna+len(m.Palabra):]
+	return strings.Join(lines, "\n"), true
+}
+
+// loadSpellDict busca un par .dic/.aff en la carpeta diccionario y suma las palabras
+// propias. Sin diccionario devuelve nil y no se revisa la ortografía.
+func loadSpellDict() (*spellDict, error) {
+	dics, err := filepath.Glob(filepath.Join(spellDir, "*.dic"))
+	if err != nil || len(dics) == 0 {
+		return nil, err
+	}
+	sort.Strings(dics)
+	d := newSpellDict()
+	aff, err := os.Open(strings.TrimSuffix(dics[0], ".dic") + ".aff")
+	if err == nil {
+		err = d.readAff(aff)
+		aff.Close()
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	dic, err := os.Open(dics[0])
+	if err != nil {
+		return nil, err
+	}
+	defer dic.Close()
+	if err := d.readDic(dic); err != nil {
+		return nil, err
+	}
+
+	if data, err := ioutil.ReadFile(spellPersonalFile); err == nil {
+		for _, word := range strings.Fields(string(data)) {
+			d.add(word)
+		}
+	}
+	return d, nil
+}
+
+// addPersonalWord agrega la palabra al diccionario propio de este equipo
+func addPersonalWord(word string) error {
+	f, err := os.OpenFile(spellPersonalFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, strings.ToLower(word))
+	return err
+}
+
+// startSpellCheck carga el diccionario sin frenar la apertura del programa
+func (n *NotePad) startSpellCheck() {
+	d, err := loadSpellDict()
+	if err != nil {
+		log.Printf("Error cargando el diccionario: %v", err)
+		return
+	}
+	if d == nil {
+		return
+	}
+	fyne.Do(func() {
+		n.spell = d
+		n.updatePreview()
+	})
+}
+
+// spellChecker es la función que marca las palabras en la vista previa; nil sin diccionario
+func (n *NotePad) spellChecker() func(string) bool {
+	if n.spell == nil {
+		return nil
+	}
+	return n.spell.misspelled
+}
+
+// showSpelling lista las palabras mal escritas de la nota con sus sugerencias
+func (n *NotePad) showSpelling(window fyne.Window) {
+	if n.spell == nil {
+		dialog.ShowInformation("📖 Ortografía", fmt.Sprintf("No hay diccionario. Copia es_ES.dic y es_ES.aff "+
+			"(los de LibreOffice u OpenOffice) en la carpeta %q junto al programa y vuelve a abrirlo.", spellDir), window)
+		return
+	}
+
+	var found []misspelling
+	selected := -1
+	list := widget.NewList(
+		func() int {
+			return len(found)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(fmt.Sprintf("%4d   %s", found[id].Linea+1, found[id].Palabra))
+		},
+	)
+	summary := widget.NewLabel("")
+	suggestions := widget.NewSelect(nil, nil)
+	suggestions.PlaceHolder = "Sugerencias..."
+	replaceButton := widget.NewButton("✔️ Reemplazar", nil)
+	addButton := widget.NewButton("➕ Agregar al diccionario", nil)
+
+	refresh := func() {
+		found = findMisspellings(n.multiLine.Text, n.spell.misspelled)
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		suggestions.Options = nil
+		suggestions.ClearSelected()
+		summary.SetText(fmt.Sprintf("%d palabras para revisar. Las que van en mayúsculas y los códigos no se revisan.", len(found)))
+		n.updatePreview()
+	}
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		suggestions.Options = n.spell.suggest(found[id].Palabra)
+		suggestions.ClearSelected()
+		if len(suggestions.Options) > 0 {
+			suggestions.SetSelected(suggestions.Options[0])
+		}
+		suggestions.Refresh()
+		n.goToRow(found[id].Linea, window)
+	}
+	replaceButton.OnTapped = func() {
+		if selected < 0 || suggestions.Selected == "" {
+			return
+		}
+		text, ok := replaceMisspelling(n.multiLine.Text, found[selected], suggestions.Selected)
+		if ok {
+			cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+			n.applyEdit(historyReplace, text)
+			n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+			n.multiLine.Refresh()
+		}
+		refresh()
+	}
+	addButton.OnTapped = func() {
+		if selected < 0 {
+			return
+		}
+		word := found[selected].Palabra
+		if err := addPersonalWord(word); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		n.spell.add(word)
+		refresh()
+	}
+	refresh()
+
+	actions := container.NewVBox(suggestions, replaceButton, addButton)
+	content := container.NewBorder(summary, nil, nil, actions, list)
+	d := dialog.NewCustom("📖 Ortografía", "Cerrar", content, window)
+	d.Resize(fyne.NewSize(600, 420))
+	d.Show()
+}
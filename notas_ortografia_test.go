@@ -0,0 +1,101 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testAff = `SET UTF-8
+# Plural y prefijo de prueba
+SFX S Y 2
+SFX S 0 s [aeiouáéíóú]
+SFX S 0 es [^aeiouáéíóú]
+SFX G N 1
+SFX G o a o
+PFX R Y 1
+PFX R 0 re .
+`
+
+const testDic = `6
+dirección/S
+equipo/SR
+caja/S
+revisado/G
+de
+la
+`
+
+func testSpellDict(t *testing.T) *spellDict {
+	d := newSpellDict()
+	if err := d.readAff(strings.NewReader(testAff)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.readDic(strings.NewReader(testDic)); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestSpellDictKnown(t *testing.T) {
+	d := testSpellDict(t)
+	for _, word := range []string{"dirección", "direcciónes", "equipos", "reequipo", "reequipos", "cajas", "revisada", "Caja", "de"} {
+		if !d.known(word) {
+			t.Errorf("%q debería estar en el diccionario", word)
+		}
+	}
+	for _, word := range []string{"direccion", "cajaes", "revisadas", "recaja", "ekipo"} {
+		if d.known(word) {
+			t.Errorf("%q no debería estar en el diccionario", word)
+		}
+	}
+
+	for _, word := range []string{"JRIOS", "a", "ZET00154", "LGARCIA"} {
+		if d.misspelled(word) {
+			t.Errorf("%q no debería revisarse", word)
+		}
+	}
+	d.add("Quintana")
+	if d.misspelled("quintana") || !d.misspelled("ekipo") {
+		t.Error("las palabras propias deberían aceptarse sin importar mayúsculas")
+	}
+}
+
+func TestSpellSuggest(t *testing.T) {
+	d := testSpellDict(t)
+	if got := d.suggest("direccion"); !reflect.DeepEqual(got, []string{"dirección"}) {
+		t.Errorf("suggest(direccion) = %q", got)
+	}
+	if got := d.suggest("Eqiupo"); len(got) == 0 || got[0] != "Equipo" {
+		t.Errorf("la sugerencia debería conservar la mayúscula: %q", got)
+	}
+}
+
+func TestFindAndReplaceMisspellings(t *testing.T) {
+	d := testSpellDict(t)
+	text := "***LISTA ekipo***\nla caja de ekipo 0154 JRIOS\nla direccion"
+	found := findMisspellings(text, d.misspelled)
+	want := []misspelling{{Linea: 1, Columna: 11, Palabra: "ekipo"}, {Linea: 2, Columna: 3, Palabra: "direccion"}}
+	if !reflect.DeepEqual(found, want) {
+		t.Fatalf("findMisspellings = %+v", found)
+	}
+	got, ok := replaceMisspelling(text, found[1], "dirección")
+	if !ok || got != "***LISTA ekipo***\nla caja de ekipo 0154 JRIOS\nla dirección" {
+		t.Errorf("replaceMisspelling = %q, %v", got, ok)
+	}
+	if _, ok := replaceMisspelling("otro texto", found[1], "dirección"); ok {
+		t.Error("si la palabra ya no está en su lugar no debería reemplazarse")
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	if got := parseFlags("AbCd", "long"); !reflect.DeepEqual(got, []string{"Ab", "Cd"}) {
+		t.Errorf("long = %q", got)
+	}
+	if got := parseFlags("12,3", "num"); !reflect.DeepEqual(got, []string{"12", "3"}) {
+		t.Errorf("num = %q", got)
+	}
+	if got := parseFlags("SRñ", ""); !reflect.DeepEqual(got, []string{"S", "R", "ñ"}) {
+		t.Errorf("por defecto = %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// restoreCleared devuelve lo limpiado a la nota; lo escrito después de limpiar queda debajo,
+// separado por una línea vacía, para que deshacer la limpieza no borre nada
+func restoreCleared(current, cleared string) string {
+	if strings.TrimSpace(current) == "" {
+		return cleared
+	}
+	return strings.TrimRight(cleared, "\n") + "\n\n" + current
+}
+
+// clearContent vacía la nota después de confirmar. Lo que había va a la papelera de la
+// sesión, para recuperarlo con "Deshacer limpieza" aunque se cambie de nota, y también al
+// historial de la nota como una instantánea más.
+func (n *NotePad) clearContent(window fyne.Window) {
+	dialog.ShowConfirm("Confirmar", fmt.Sprintf("¿Estás seguro de que quieres limpiar todo el contenido de la nota %q?", n.mailNoteName()), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		text := n.noteText()
+		if strings.TrimSpace(text) != "" {
+			path, _ := n.currentFile()
+			n.trash[path] = append(n.trash[path], text)
+			if !n.external {
+				if _, err := writeVersion(noteSnapshotsDir(n.current), text, time.Now(), true, n.key); err != nil {
+					log.Printf("Error guardando en el historial lo limpiado de %q: %v", n.current, err)
+				}
+			}
+		}
+		n.applyEdit(historyClear, "")
+		n.statusLabel.SetText("Estado: Contenido limpiado (♻️ Deshacer limpieza lo recupera)")
+	}, window)
+}
+
+// undoClear recupera lo último que se limpió de la nota abierta
+func (n *NotePad) undoClear() {
+	path, _ := n.currentFile()
+	cleared := n.trash[path]
+	if len(cleared) == 0 {
+		n.statusLabel.SetText("Estado: No hay limpiezas de esta nota para deshacer")
+		return
+	}
+	n.trash[path] = cleared[:len(cleared)-1]
+	n.applyEdit(historyRestore, restoreCleared(n.multiLine.Text, cleared[len(cleared)-1]))
+	n.statusLabel.SetText("Estado: ♻️ Contenido limpiado recuperado")
+}
+
+// createClearButtons devuelve los botones de limpiar y de deshacer la limpieza
+func (n *NotePad) createClearButtons(window fyne.Window) (*widget.Button, *widget.Button) {
+	n.trash = map[string][]string{}
+	return widget.NewButton("🗑️ Limpiar", func() { n.clearContent(window) }),
+		widget.NewButton("♻️ Deshacer limpieza", n.undoClear)
+}
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestRestoreCleared(t *testing.T) {
+	cleared := "***LISTA***\n......0154 LGARCIA 15:04 JRIOS\n"
+	tests := []struct {
+		current, want string
+	}{
+		{"", cleared},
+		{" \n", cleared},
+		{"......0201 MGAVINO 16:10 JRIOS", "***LISTA***\n......0154 LGARCIA 15:04 JRIOS\n\n......0201 MGAVINO 16:10 JRIOS"},
+	}
+	for _, tt := range tests {
+		if got := restoreCleared(tt.current, cleared); got != tt.want {
+			t.Errorf("restoreCleared(%q) = %q, quería %q", tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Patrones que se actualizan solos en el bloc de notas
+const timePatternsFile = "patrones_notas.json"
+
+// TimePattern reemplaza cada coincidencia de Patron por Formato aplicado a la hora actual.
+// Con Seccion solo actúa bajo los títulos entre asteriscos que coinciden con esa expresión,
+// sin distinguir mayúsculas; vacía vale para toda la nota.
+type TimePattern struct {
+	Nombre  string
+	Patron  string
+	Formato string
+	Activo  bool
+	Seccion string `json:",omitempty"`
+}
+
+// defaultTimePatterns actualiza horas y fechas. Los marcadores {FECHA} y {HORA} se
+// cambian por la fecha u hora la primera vez y desde ahí los mantienen al día los otros
+// dos patrones, así un encabezado diario siempre muestra el día de hoy.
+func defaultTimePatterns() []TimePattern {
+	return []TimePattern{
+		{Nombre: "Hora", Patron: `\b\d{1,2}:\d{2}\b`, Formato: "HH:mm", Activo: true},
+		{Nombre: "Fecha", Patron: `\b\d{1,2}/\d{1,2}/\d{4}\b`, Formato: "dd/MM/yyyy", Activo: true},
+		{Nombre: "Marcador de fecha", Patron: `\{FECHA\}`, Formato: "dd/MM/yyyy", Activo: true},
+		{Nombre: "Marcador de hora", Patron: `\{HORA\}`, Formato: "HH:mm", Activo: true},
+	}
+}
+
+// Marcas de formatTokens, las más largas primero para que "yyyy" no se lea como "yy"
+var timeTokens = []struct {
+	token  string
+	layout string
+}{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"MM", "01"},
+	{"dd", "02"},
+	{"HH", "15"},
+	{"mm", "04"},
+	{"ss", "05"},
+}
+
+// formatTokens arma el reemplazo: HH, mm, ss, dd, MM, yy y yyyy se cambian por la hora y
+// fecha de now; el resto del texto queda igual
+func formatTokens(format string, now time.Time) string {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		matched := false
+		for _, t := range timeTokens {
+			if strings.HasPrefix(format[i:], t.token) {
+				b.WriteString(now.Format(t.layout))
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// compiledPattern es un TimePattern activo listo para aplicar
+type compiledPattern struct {
+	re      *regexp.Regexp
+	format  string
+	section *regexp.Regexp // nil si se aplica en toda la nota
+}
+
+// appliesTo indica si el patrón actúa bajo el título title; las líneas antes del primer
+// título tienen título vacío
+func (p compiledPattern) appliesTo(title string) bool {
+	return p.section == nil || p.section.MatchString(title)
+}
+
+// compileTimePatterns valida todos los patrones y devuelve los activos
+func compileTimePatterns(patterns []TimePattern) ([]compiledPattern, error) {
+	var compiled []compiledPattern
+	for i, p := range patterns {
+		name := strings.TrimSpace(p.Nombre)
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if strings.TrimSpace(p.Patron) == "" {
+			return nil, fmt.Errorf("patrón %s: la expresión no puede estar vacía", name)
+		}
+		re, err := regexp.Compile(p.Patron)
+		if err != nil {
+			return nil, fmt.Errorf("patrón %s: expresión inválida: %v", name, err)
+		}
+		if p.Formato == "" {
+			return nil, fmt.Errorf("patrón %s: el formato no puede estar vacío", name)
+		}
+		var section *regexp.Regexp
+		if strings.TrimSpace(p.Seccion) != "" {
+			if section, err = regexp.Compile("(?i)" + strings.TrimSpace(p.Seccion)); err != nil {
+				return nil, fmt.Errorf("patrón %s: sección inválida: %v", name, err)
+			}
+		}
+		if p.Activo {
+			compiled = append(compiled, compiledPattern{re: re, format: p.Formato, section: section})
+		}
+	}
+	return compiled, nil
+}
+
+// Marcas para congelar horas que no deben actualizarse, como la hora en que salió un envío:
+// una línea que empieza con "!" queda entera como está, y lo escrito entre acentos graves
+// (`15:30`) también. La hora de "@recordar 16:30" tampoco se toca.
+const frozenLinePrefix = "!"
+
+var frozenSpanRegex = regexp.MustCompile("`[^`]*`|(?i)@recordar\\s+\\d{1,2}:\\d{2}")
+
+func isFrozenLine(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), frozenLinePrefix)
+}
+
+// replaceOutsideFrozen aplica replace solo a las partes de la línea fuera de `...`
+func replaceOutsideFrozen(line string, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range frozenSpanRegex.FindAllStringIndex(line, -1) {
+		b.WriteString(replace(line[last:span[0]]))
+		b.WriteString(line[span[0]:span[1]])
+		last = span[1]
+	}
+	b.WriteString(replace(line[last:]))
+	return b.String()
+}
+
+// applyTimePatterns actualiza en content todas las coincidencias de los patrones activos,
+// salvo en las líneas y partes congeladas. Cada título entre asteriscos cambia los patrones
+// que actúan desde esa línea hasta el siguiente título.
+func applyTimePatterns(content string, patterns []compiledPattern, now time.Time) string {
+	if len(patterns) == 0 {
+		return content
+	}
+	var active []compiledPattern
+	selectPatterns := func(title string) {
+		active = active[:0]
+		for _, p := range patterns {
+			if p.appliesTo(title) {
+				active = append(active, p)
+			}
+		}
+	}
+	replace := func(text string) string {
+		for _, p := range active {
+			text = p.re.ReplaceAllLiteralString(text, formatTokens(p.format, now))
+		}
+		return text
+	}
+
+	selectPatterns("")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if m := starTitleRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			selectPatterns(m[1])
+		}
+		if len(active) > 0 && !isFrozenLine(line) {
+			lines[i] = replaceOutsideFrozen(line, replace)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// loadTimePatterns lee los patrones guardados; si no hay o son inválidos usa los de siempre
+func loadTimePatterns() []TimePattern {
+	data, err := ioutil.ReadFile(timePatternsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Error leyendo %s: %v", timePatternsFile, err)
+		}
+		return defaultTimePatterns()
+	}
+	var patterns []TimePattern
+	if err := json.Unmarshal(data, &patterns); err != nil {
+		log.Printf("Error leyendo %s: %v", timePatternsFile, err)
+		return defaultTimePatterns()
+	}
+	if _, err := compileTimePatterns(patterns); err != nil {
+		log.Printf("Patrones inválidos en %s, se usan los predeterminados: %v", timePatternsFile, err)
+		return defaultTimePatterns()
+	}
+	return patterns
+}
+
+func saveTimePatterns(patterns []TimePattern) error {
+	data, err := json.MarshalIndent(patterns, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(timePatternsFile, data, 0644)
+}
+
+// setTimePatterns cambia los patrones que usa la actualización automática
+func (n *NotePad) setTimePatterns(patterns []TimePattern) error {
+	compiled, err := compileTimePatterns(patterns)
+	if err != nil {
+		return err
+	}
+	n.timePatterns = patterns
+	n.autoPatterns = compiled
+	return nil
+}
+
+// patternRow son los campos de un patrón en el diálogo
+type patternRow struct {
+	active  *widget.Check
+	name    *widget.Entry
+	pattern *widget.Entry
+	format  *widget.Entry
+	section *widget.Entry
+}
+
+func (r *patternRow) value() TimePattern {
+	return TimePattern{
+		Nombre:  strings.TrimSpace(r.name.Text),
+		Patron:  r.pattern.Text,
+		Formato: r.format.Text,
+		Activo:  r.active.Checked,
+		Seccion: strings.TrimSpace(r.section.Text),
+	}
+}
+
+// showTimePatterns abre el administrador de patrones de actualización automática
+func (n *NotePad) showTimePatterns(window fyne.Window) {
+	var rows []*patternRow
+	rowsBox := container.NewVBox()
+
+	var rebuild func()
+	addRow := func(p TimePattern) {
+		row := &patternRow{
+			active:  widget.NewCheck("", nil),
+			name:    widget.NewEntry(),
+			pattern: widget.NewEntry(),
+			format:  widget.NewEntry(),
+			section: widget.NewEntry(),
+		}
+		row.active.SetChecked(p.Activo)
+		row.name.SetText(p.Nombre)
+		row.name.SetPlaceHolder("Nombre")
+		row.pattern.SetText(p.Patron)
+		row.pattern.SetPlaceHolder(`Expresión, ej. \b\d{1,2}:\d{2}\b`)
+		row.format.SetText(p.Formato)
+		row.format.SetPlaceHolder("Formato, ej. HH:mm")
+		row.section.SetText(p.Seccion)
+		row.section.SetPlaceHolder("Secciones: todas")
+		rows = append(rows, row)
+	}
+	rebuild = func() {
+		rowsBox.Objects = nil
+		for i, row := range rows {
+			i := i
+			removeButton := widget.NewButton("🗑️", func() {
+				rows = append(rows[:i], rows[i+1:]...)
+				rebuild()
+			})
+			rowsBox.Add(container.NewBorder(nil, nil,
+				container.NewHBox(row.active, container.NewGridWrap(fyne.NewSize(120, row.name.MinSize().Height), row.name)),
+				container.NewHBox(
+					container.NewGridWrap(fyne.NewSize(130, row.format.MinSize().Height), row.format),
+					container.NewGridWrap(fyne.NewSize(160, row.section.MinSize().Height), row.section),
+					removeButton),
+				row.pattern))
+		}
+		rowsBox.Refresh()
+	}
+	for _, p := range n.timePatterns {
+		addRow(p)
+	}
+	rebuild()
+
+	addButton := widget.NewButton("➕ Agregar patrón", func() {
+		addRow(TimePattern{Formato: "HH:mm", Activo: true})
+		rebuild()
+	})
+	resetButton := widget.NewButton("↩️ Predeterminados", func() {
+		rows = nil
+		for _, p := range defaultTimePatterns() {
+			addRow(p)
+		}
+		rebuild()
+	})
+
+	help := widget.NewLabel("Cada patrón activo reemplaza sus coincidencias por el formato con la hora actual.\n" +
+		"En el formato: HH hora, mm minutos, ss segundos, dd día, MM mes, yyyy año (yy con dos cifras).\n" +
+		"El resto del formato se copia igual, por ejemplo \"Turno HH:mm\".\n" +
+		"En Secciones, una expresión como REPOSICI limita el patrón a los títulos entre asteriscos que la contienen.\n" +
+		"Las líneas que empiezan con ! y lo escrito entre acentos graves (`15:30`) no se actualizan.")
+	help.Wrapping = fyne.TextWrapWord
+
+	rowsScroll := container.NewVScroll(rowsBox)
+	rowsScroll.SetMinSize(fyne.NewSize(780, 220))
+
+	var d dialog.Dialog
+	d = dialog.NewCustomConfirm("⚙️ Patrones de actualización automática", "Guardar", "Cancelar",
+		container.NewBorder(help, container.NewHBox(addButton, resetButton), nil, nil, rowsScroll),
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			patterns := make([]TimePattern, 0, len(rows))
+			for _, row := range rows {
+				patterns = append(patterns, row.value())
+			}
+			err := n.setTimePatterns(patterns)
+			if err == nil {
+				err = saveTimePatterns(patterns)
+			}
+			if err != nil {
+				d.Show()
+				dialog.ShowError(err, window)
+				return
+			}
+			n.statusLabel.SetText(fmt.Sprintf("Estado: %d patrones activos", len(n.autoPatterns)))
+		}, window)
+	d.Resize(fyne.NewSize(860, 420))
+	d.Show()
+}
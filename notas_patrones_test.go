@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTokens(t *testing.T) {
+	now := time.Date(2026, 3, 7, 9, 5, 42, 0, time.Local)
+	tests := []struct {
+		format, want string
+	}{
+		{"HH:mm", "09:05"},
+		{"HH:mm:ss", "09:05:42"},
+		{"dd/MM/yyyy", "07/03/2026"},
+		{"dd-MM-yy", "07-03-26"},
+		{"Turno HH:mm", "Turno 09:05"},
+		{"sin marcas", "sin marcas"},
+		{"añoyyyy", "año2026"},
+	}
+	for _, tt := range tests {
+		if got := formatTokens(tt.format, now); got != tt.want {
+			t.Errorf("formatTokens(%q) = %q, se esperaba %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestCompileTimePatterns(t *testing.T) {
+	patterns := defaultTimePatterns()
+	patterns[1].Activo = false
+	compiled, err := compileTimePatterns(patterns)
+	if err != nil || len(compiled) != len(patterns)-1 {
+		t.Fatalf("los patrones inactivos no deberían compilarse: %v, %v", compiled, err)
+	}
+
+	invalid := [][]TimePattern{
+		{{Nombre: "Roto", Patron: "(", Formato: "HH:mm"}},
+		{{Nombre: "Vacío", Patron: "  ", Formato: "HH:mm"}},
+		{{Nombre: "Sin formato", Patron: `\d+`}},
+	}
+	for _, patterns := range invalid {
+		if _, err := compileTimePatterns(patterns); err == nil {
+			t.Errorf("%v debería dar error aunque esté inactivo", patterns)
+		}
+	}
+}
+
+func TestApplyTimePatterns(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 7, 0, 0, time.Local)
+	patterns := append(defaultTimePatterns(), TimePattern{
+		Nombre: "Turno", Patron: `TURNO-\d{4}`, Formato: "TURNO-HHmm", Activo: true,
+	})
+	compiled, err := compileTimePatterns(patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "REPOSICION 9:30 JRIOS\nFecha 01/01/2025 TURNO-0800"
+	want := "REPOSICION 14:07 JRIOS\nFecha 16/10/2026 TURNO-1407"
+	if got := applyTimePatterns(content, compiled, now); got != want {
+		t.Errorf("applyTimePatterns = %q, se esperaba %q", got, want)
+	}
+	content = "*** REPOSICIÓN {FECHA} ***\nDesde {HORA}, antes 7/5/2025"
+	want = "*** REPOSICIÓN 16/10/2026 ***\nDesde 14:07, antes 16/10/2026"
+	if got := applyTimePatterns(content, compiled, now); got != want {
+		t.Errorf("marcadores = %q, se esperaba %q", got, want)
+	}
+	if got := applyTimePatterns(content, nil, now); got != content {
+		t.Errorf("sin patrones activos el texto no debería cambiar: %q", got)
+	}
+}
+
+func TestApplyTimePatternsFrozen(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 7, 0, 0, time.Local)
+	compiled, _ := compileTimePatterns(defaultTimePatterns())
+
+	content := "!......0154 SALIO 08:15 JRIOS\n" +
+		"  ! 09:00 también congelada\n" +
+		"......0154 `08:15` LLEGO 10:30\n" +
+		"......0083 REPOSICION 11:00"
+	want := "!......0154 SALIO 08:15 JRIOS\n" +
+		"  ! 09:00 también congelada\n" +
+		"......0154 `08:15` LLEGO 14:07\n" +
+		"......0083 REPOSICION 14:07"
+	if got := applyTimePatterns(content, compiled, now); got != want {
+		t.Errorf("applyTimePatterns =\n%s\nse esperaba\n%s", got, want)
+	}
+
+	// La hora de un recordatorio tampoco se actualiza
+	if got := applyTimePatterns("Recoger 10:30 @Recordar 16:30", compiled, now); got != "Recoger 14:07 @Recordar 16:30" {
+		t.Errorf("recordatorio = %q", got)
+	}
+
+	// Un acento grave sin cerrar no congela nada
+	if got := applyTimePatterns("`10:30 y 11:00", compiled, now); got != "`14:07 y 14:07" {
+		t.Errorf("acento sin cerrar = %q", got)
+	}
+}
+
+func TestApplyTimePatternsSection(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 7, 0, 0, time.Local)
+	patterns := defaultTimePatterns()
+	patterns[0].Seccion = "reposici"
+	compiled, err := compileTimePatterns(patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "Inicio 9:00 del 01/01/2025\n" +
+		"***********LISTA REPOSICIÓN*********\n" +
+		"......9999 REPOSICION 15:04 MGAVINO\n" +
+		"\n" +
+		"**************ZETTACOM**********\n" +
+		"......0154 LGARCIA 15:04 MGAVINO 01/01/2025"
+	want := "Inicio 9:00 del 16/10/2026\n" +
+		"***********LISTA REPOSICIÓN*********\n" +
+		"......9999 REPOSICION 14:07 MGAVINO\n" +
+		"\n" +
+		"**************ZETTACOM**********\n" +
+		"......0154 LGARCIA 15:04 MGAVINO 16/10/2026"
+	if got := applyTimePatterns(content, compiled, now); got != want {
+		t.Errorf("applyTimePatterns = %q, se esperaba %q", got, want)
+	}
+
+	patterns[0].Seccion = "("
+	if _, err := compileTimePatterns(patterns); err == nil {
+		t.Error("una sección inválida debería dar error")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+const noTemplate = "Nota vacía"
+
+// companyNames devuelve las empresas de empresasData en orden alfabético
+func companyNames() []string {
+	names := make([]string, 0, len(empresasData))
+	for name := range empresasData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// companyTemplate arma el bloque de una empresa para la nota del turno: el título entre
+// asteriscos con el nombre corto, sus datos, la fecha del turno entre acentos graves para
+// que no se actualice, quién es el responsable, la línea 9999 de ejemplo y los campos de
+// siempre. ok es false si la empresa no está en empresasData.
+func companyTemplate(company, user string, now time.Time) (string, bool) {
+	data, ok := empresasData[company]
+	if !ok {
+		return "", false
+	}
+	if user == "" {
+		user = "USUARIO"
+	}
+	lines := []string{
+		strings.Repeat("*", 14) + company + strings.Repeat("*", 10),
+		fmt.Sprintf("# %s - %s - %s", data.Nombre, data.Direccion, data.Telefono),
+		fmt.Sprintf("Turno: `%s` Responsable: %s", now.Format("02/01/2006"), user),
+		fmt.Sprintf("......9999 REPOSICION %s %s", formatTokens(insertTimeFormat, now), user),
+		"Pendientes:",
+		"Observaciones:",
+	}
+	return strings.Join(lines, "\n") + "\n", true
+}
+
+// templateUser es quién figura como responsable: el usuario de 🪄 Formato o el de Windows
+func (n *NotePad) templateUser() string {
+	if user := strings.TrimSpace(n.formatRule.Usuario); user != "" {
+		return user
+	}
+	return sessionInitials()
+}
+
+// insertCompanyTemplate agrega la plantilla de la empresa antes de la línea del cursor,
+// separada por una línea vacía si la nota ya tiene texto ahí
+func (n *NotePad) insertCompanyTemplate(company string) {
+	template, ok := companyTemplate(company, n.templateUser(), time.Now())
+	if !ok {
+		return
+	}
+	text := n.multiLine.Text
+	lines := strings.Split(text, "\n")
+	row := min(max(n.multiLine.CursorRow, 0), len(lines)-1)
+	if strings.TrimSpace(lines[row]) != "" {
+		template += "\n"
+	}
+	offset := rowColToOffset(text, row, 0)
+
+	n.applyEdit(historyHeader, text[:offset]+template+text[offset:])
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = row, 0
+	n.multiLine.Refresh()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Plantilla de %s agregada", company))
+}
+
+// createTemplateSelect devuelve la lista de la barra que agrega la plantilla de una empresa
+func (n *NotePad) createTemplateSelect() *widget.Select {
+	var templateSelect *widget.Select
+	templateSelect = widget.NewSelect(companyNames(), func(company string) {
+		if company == "" {
+			return
+		}
+		n.insertCompanyTemplate(company)
+		templateSelect.ClearSelected()
+	})
+	templateSelect.PlaceHolder = "🏢 Plantilla de empresa"
+	return templateSelect
+}
+
+// newTemplateSelect es la lista del diálogo ➕ para empezar la nota nueva con una plantilla
+func newTemplateSelect() *widget.Select {
+	templateSelect := widget.NewSelect(append([]string{noTemplate}, companyNames()...), nil)
+	templateSelect.SetSelected(noTemplate)
+	return templateSelect
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompanyNames(t *testing.T) {
+	if got := companyNames(); !reflect.DeepEqual(got, []string{"COMSITEC", "ZETTACOM"}) {
+		t.Errorf("companyNames = %q", got)
+	}
+}
+
+func TestCompanyTemplate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 7, 30, 0, 0, time.Local)
+	want := "**************ZETTACOM**********\n" +
+		"# ZETTACOM S.A.C - Av. Giraldez 242, Huancayo, Junín - +51 964 789 123\n" +
+		"Turno: `16/10/2026` Responsable: JRIOS\n" +
+		"......9999 REPOSICION 07:30 JRIOS\n" +
+		"Pendientes:\n" +
+		"Observaciones:\n"
+	if got, ok := companyTemplate("ZETTACOM", "JRIOS", now); !ok || got != want {
+		t.Errorf("companyTemplate = %q, %v; quería %q", got, ok, want)
+	}
+	if got, _ := companyTemplate("COMSITEC", "", now); !strings.HasPrefix(got, "**************COMSITEC**********\n") || !strings.Contains(got, "Responsable: USUARIO\n") {
+		t.Errorf("sin usuario la plantilla de COMSITEC = %q", got)
+	}
+	if _, ok := companyTemplate("OTRA", "JRIOS", now); ok {
+		t.Error("una empresa que no existe no debería tener plantilla")
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// Línea que queda en el editor en lugar de una sección plegada: "⋯ [plegado 3] 12 líneas"
+var foldLineRegex = regexp.MustCompile(`^⋯ \[plegado (\d+)\]`)
+
+const unfoldAllOption = "Desplegar todas"
+
+// noteSection es un título entre asteriscos de la nota
+type noteSection struct {
+	Titulo  string
+	Fila    int
+	Plegado int // Número del pliegue que tiene debajo; 0 si está desplegada
+	Lineas  int // Líneas ocultas si está plegada
+}
+
+// foldLine es la línea que reemplaza a las count líneas de un pliegue
+func foldLine(id, count int) string {
+	return fmt.Sprintf("⋯ [plegado %d] %d líneas", id, count)
+}
+
+// foldID devuelve el número del pliegue si line es una línea de sección plegada
+func foldID(line string) (int, bool) {
+	m := foldLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return 0, false
+	}
+	id, err := strconv.Atoi(m[1])
+	return id, err == nil
+}
+
+// noteSections lista los títulos de la nota e indica cuáles están plegados
+func noteSections(text string, folds map[int]string) []noteSection {
+	lines := strings.Split(text, "\n")
+	var sections []noteSection
+	for i, line := range lines {
+		m := starTitleRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		section := noteSection{Titulo: m[1], Fila: i}
+		if i+1 < len(lines) {
+			if id, ok := foldID(lines[i+1]); ok {
+				if body, found := folds[id]; found {
+					section.Plegado = id
+					section.Lineas = strings.Count(body, "\n") + 1
+				}
+			}
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// foldSection reemplaza por una sola línea lo que hay bajo el título de la fila row, hasta
+// el siguiente título. Las líneas vacías del final quedan a la vista para que la sección
+// siga separada de la próxima. Devuelve el texto nuevo y lo ocultado.
+func foldSection(text string, row, id int) (string, string, bool) {
+	lines := strings.Split(text, "\n")
+	if row < 0 || row >= len(lines) || !starTitleRegex.MatchString(strings.TrimSpace(lines[row])) {
+		return text, "", false
+	}
+	last := row
+	for last+1 < len(lines) && !starTitleRegex.MatchString(strings.TrimSpace(lines[last+1])) {
+		last++
+	}
+	for last > row && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+	if last == row {
+		return text, "", false
+	}
+	body := lines[row+1 : last+1]
+	folded := append(append(append([]string{}, lines[:row+1]...), foldLine(id, len(body))), lines[last+1:]...)
+	return strings.Join(folded, "\n"), strings.Join(body, "\n"), true
+}
+
+// unfoldSections vuelve a poner lo ocultado en lugar de las líneas de pliegue de folds;
+// las demás líneas quedan igual
+func unfoldSections(text string, folds map[int]string) string {
+	if len(folds) == 0 {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if id, ok := foldID(line); ok {
+			if body, found := folds[id]; found {
+				lines[i] = body
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// noteText es la nota completa, con las secciones plegadas desplegadas. Es lo que se
+// guarda, se compara y se recupera.
+func (n *NotePad) noteText() string {
+	n.saveMu.Lock()
+	defer n.saveMu.Unlock()
+	return unfoldSections(n.multiLine.Text, n.folds)
+}
+
+// toggleFold pliega o despliega section sin mover el cursor
+func (n *NotePad) toggleFold(section noteSection) {
+	n.saveMu.Lock()
+	text, ok := n.multiLine.Text, true
+	if section.Plegado != 0 {
+		text = unfoldSections(text, map[int]string{section.Plegado: n.folds[section.Plegado]})
+	} else {
+		var body string
+		text, body, ok = foldSection(text, section.Fila, n.foldNext+1)
+		if ok {
+			n.foldNext++
+			if n.folds == nil {
+				n.folds = map[int]string{}
+			}
+			n.folds[n.foldNext] = body
+		}
+	}
+	n.saveMu.Unlock()
+	if !ok {
+		n.statusLabel.SetText("Estado: La sección está vacía")
+		return
+	}
+
+	cursorRow, cursorCol := n.multiLine.CursorRow, n.multiLine.CursorColumn
+	n.applyEdit(historyFold, text)
+	n.multiLine.CursorRow, n.multiLine.CursorColumn = cursorRow, cursorCol
+	n.multiLine.Refresh()
+	if section.Plegado != 0 {
+		n.statusLabel.SetText(fmt.Sprintf("Estado: Sección %q desplegada", section.Titulo))
+	} else {
+		n.statusLabel.SetText(fmt.Sprintf("Estado: Sección %q plegada", section.Titulo))
+	}
+}
+
+// unfoldAll despliega todas las secciones
+func (n *NotePad) unfoldAll() {
+	text := n.noteText()
+	if text == n.multiLine.Text {
+		return
+	}
+	n.applyEdit(historyFold, text)
+	n.statusLabel.SetText("Estado: Secciones desplegadas")
+}
+
+// clearFolds olvida lo ocultado al cargar otra nota; la nota cargada viene completa
+func (n *NotePad) clearFolds() {
+	n.saveMu.Lock()
+	n.folds = nil
+	n.saveMu.Unlock()
+}
+
+// foldOptions arma las opciones de la lista: ▼ para las secciones a la vista y ▶ para las
+// plegadas
+func foldOptions(sections []noteSection) []string {
+	options := make([]string, 0, len(sections)+1)
+	folded := false
+	for _, s := range sections {
+		if s.Plegado != 0 {
+			options = append(options, fmt.Sprintf("▶ %s (%d líneas)", s.Titulo, s.Lineas))
+			folded = true
+		} else {
+			options = append(options, "▼ "+s.Titulo)
+		}
+	}
+	if folded {
+		options = append(options, unfoldAllOption)
+	}
+	return options
+}
+
+// updateFolds se llama desde OnChanged para que la lista muestre los títulos de la nota
+func (n *NotePad) updateFolds() {
+	if n.foldSelect == nil {
+		return
+	}
+	n.saveMu.Lock()
+	sections := noteSections(n.multiLine.Text, n.folds)
+	n.saveMu.Unlock()
+	options := foldOptions(sections)
+	if strings.Join(options, "\n") != strings.Join(n.foldSelect.Options, "\n") {
+		n.foldSelect.SetOptions(options)
+	}
+}
+
+// createFoldSelect arma la lista de "Plegar sección"; elegir un título lo pliega o lo
+// despliega en el acto
+func (n *NotePad) createFoldSelect() *widget.Select {
+	n.foldSelect = widget.NewSelect(nil, func(option string) {
+		if option == "" {
+			return
+		}
+		defer n.foldSelect.ClearSelected()
+		if option == unfoldAllOption {
+			n.unfoldAll()
+			return
+		}
+		n.saveMu.Lock()
+		sections := noteSections(n.multiLine.Text, n.folds)
+		n.saveMu.Unlock()
+		for i, o := range foldOptions(sections) {
+			if o == option && i < len(sections) {
+				n.toggleFold(sections[i])
+				return
+			}
+		}
+	})
+	n.foldSelect.PlaceHolder = "📁 Plegar sección"
+	n.updateFolds()
+	return n.foldSelect
+}
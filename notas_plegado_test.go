@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+const foldSample = "***LISTA***\na\nb\n\n**************ZETTACOM**********\nz1\nz2\nz3\n\n# fin"
+
+func TestFoldSection(t *testing.T) {
+	got, body, ok := foldSection(foldSample, 4, 7)
+	want := "***LISTA***\na\nb\n\n**************ZETTACOM**********\n" + foldLine(7, 5)
+	if !ok || got != want || body != "z1\nz2\nz3\n\n# fin" {
+		t.Errorf("foldSection de la última sección = %q, %q, %v", got, body, ok)
+	}
+
+	got, body, ok = foldSection(foldSample, 0, 1)
+	want = "***LISTA***\n" + foldLine(1, 2) + "\n\n**************ZETTACOM**********\nz1\nz2\nz3\n\n# fin"
+	if !ok || got != want || body != "a\nb" {
+		t.Errorf("foldSection de la primera sección = %q, %q, %v", got, body, ok)
+	}
+
+	if _, _, ok := foldSection(foldSample, 1, 1); ok {
+		t.Error("una fila que no es título no debería plegarse")
+	}
+	if _, _, ok := foldSection("***VACÍA***\n\n***OTRA***\nx", 0, 1); ok {
+		t.Error("una sección sin líneas no debería plegarse")
+	}
+}
+
+func TestUnfoldSections(t *testing.T) {
+	folded, body, _ := foldSection(foldSample, 0, 3)
+	if got := unfoldSections(folded, map[int]string{3: body}); got != foldSample {
+		t.Errorf("unfoldSections = %q, quería %q", got, foldSample)
+	}
+	if got := unfoldSections(folded, nil); got != folded {
+		t.Errorf("sin pliegues el texto no debería cambiar: %q", got)
+	}
+	if got := unfoldSections(folded, map[int]string{9: "x"}); got != folded {
+		t.Errorf("un pliegue de otra nota no debería tocar el texto: %q", got)
+	}
+}
+
+func TestNoteSections(t *testing.T) {
+	folded, body, _ := foldSection(foldSample, 4, 2)
+	got := noteSections(folded, map[int]string{2: body})
+	want := []noteSection{
+		{Titulo: "LISTA", Fila: 0},
+		{Titulo: "ZETTACOM", Fila: 4, Plegado: 2, Lineas: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("noteSections = %+v, quería %+v", got, want)
+	}
+	options := foldOptions(got)
+	wantOptions := []string{"▼ LISTA", "▶ ZETTACOM (5 líneas)", unfoldAllOption}
+	if !reflect.DeepEqual(options, wantOptions) {
+		t.Errorf("foldOptions = %q, quería %q", options, wantOptions)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	recentFile = "recientes_notas.json" // Junto al programa: cada equipo tiene sus recientes
+	recentMax  = 10
+)
+
+func loadRecent() []string {
+	var recent []string
+	data, err := ioutil.ReadFile(recentFile)
+	if err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &recent); err != nil {
+		log.Printf("Error leyendo %s: %v", recentFile, err)
+		return nil
+	}
+	return recent
+}
+
+func saveRecent(recent []string) error {
+	data, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(recentFile, data, 0644)
+}
+
+// pushRecent pone path primero en la lista sin repetirlo y deja como mucho max rutas
+func pushRecent(recent []string, path string, max int) []string {
+	list := []string{path}
+	for _, p := range recent {
+		if !samePath(p, path) && len(list) < max {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// samePath compara rutas sin distinguir mayúsculas, como en Windows
+func samePath(a, b string) bool {
+	return strings.EqualFold(filepath.Clean(a), filepath.Clean(b))
+}
+
+// recentNoteName devuelve el nombre de la nota si path está en la carpeta de notas dir
+func recentNoteName(path, dir string) (string, bool) {
+	if !samePath(filepath.Dir(path), dir) || !strings.EqualFold(filepath.Ext(path), ".txt") {
+		return "", false
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), true
+}
+
+// recentLabel es el texto de path en la lista: las notas por su nombre (los días como
+// fecha) y los demás archivos con su carpeta
+func recentLabel(path, dir string) string {
+	if name, ok := recentNoteName(path, dir); ok {
+		if isDailyNote(name) {
+			return "📅 " + dayLabel(name)
+		}
+		return "📝 " + name
+	}
+	return fmt.Sprintf("📄 %s (%s)", filepath.Base(path), filepath.Dir(path))
+}
+
+// rememberRecent agrega lo abierto en el editor a los recientes
+func (n *NotePad) rememberRecent() {
+	if n.current == "" {
+		return
+	}
+	path, _ := n.currentFile()
+	n.recent = pushRecent(n.recent, path, recentMax)
+	if err := saveRecent(n.recent); err != nil {
+		log.Printf("Error guardando %s: %v", recentFile, err)
+	}
+	n.refreshRecent()
+}
+
+func (n *NotePad) refreshRecent() {
+	if n.recentSelect == nil {
+		return
+	}
+	labels := make([]string, len(n.recent))
+	for i, path := range n.recent {
+		labels[i] = recentLabel(path, notesDir)
+	}
+	n.recentSelect.Options = labels
+	n.recentSelect.Selected = ""
+	n.recentSelect.Refresh()
+}
+
+// openRecent vuelve a abrir path. Si ya no existe se quita de la lista.
+func (n *NotePad) openRecent(path string, window fyne.Window) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		n.recent = removeRecent(n.recent, path)
+		if err := saveRecent(n.recent); err != nil {
+			log.Printf("Error guardando %s: %v", recentFile, err)
+		}
+		n.refreshRecent()
+		dialog.ShowError(fmt.Errorf("%s ya no existe; se quitó de los recientes", path), window)
+		return
+	}
+	n.openPath(path)
+}
+
+func removeRecent(recent []string, path string) []string {
+	var list []string
+	for _, p := range recent {
+		if !samePath(p, path) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// createRecentSelect arma la lista "🕑 Recientes" para la barra de notas
+func (n *NotePad) createRecentSelect(window fyne.Window) *widget.Select {
+	n.recentSelect = widget.NewSelect(nil, nil)
+	n.recentSelect.PlaceHolder = "🕑 Recientes..."
+	n.recentSelect.OnChanged = func(label string) {
+		index := -1
+		for i, option := range n.recentSelect.Options {
+			if option == label {
+				index = i
+				break
+			}
+		}
+		if index < 0 || index >= len(n.recent) {
+			return
+		}
+		n.openRecent(n.recent[index], window)
+		n.refreshRecent()
+	}
+	n.refreshRecent()
+	return n.recentSelect
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPushRecent(t *testing.T) {
+	recent := []string{"a", "b", "c"}
+	if got := pushRecent(recent, "c", 10); !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Errorf("una ruta repetida debería pasar al principio: %q", got)
+	}
+	if got := pushRecent(recent, "B", 10); !reflect.DeepEqual(got, []string{"B", "a", "c"}) {
+		t.Errorf("las rutas no distinguen mayúsculas: %q", got)
+	}
+	if got := pushRecent(recent, "d", 2); !reflect.DeepEqual(got, []string{"d", "a"}) {
+		t.Errorf("la lista no debería pasar de max: %q", got)
+	}
+	if got := removeRecent(recent, "b"); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("removeRecent = %q", got)
+	}
+}
+
+func TestRecentLabel(t *testing.T) {
+	dir := filepath.Join("compartido", "notas")
+	other := filepath.Join("compartido", "turnos")
+	tests := []struct{ path, want string }{
+		{filepath.Join(dir, "General.txt"), "📝 General"},
+		{filepath.Join(dir, "2025-05-27.txt"), "📅 27/05/2025"},
+		{filepath.Join(other, "entrega.txt"), fmt.Sprintf("📄 entrega.txt (%s)", other)},
+	}
+	for _, tt := range tests {
+		if got := recentLabel(tt.path, dir); got != tt.want {
+			t.Errorf("recentLabel(%q) = %q, quería %q", tt.path, got, tt.want)
+		}
+	}
+	if name, ok := recentNoteName(filepath.Join(dir, "General.txt"), dir); !ok || name != "General" {
+		t.Errorf("recentNoteName = %q, %v", name, ok)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// reminderRegex reconoce "@recordar 16:30"; esa hora no se actualiza sola
+var reminderRegex = regexp.MustCompile(`(?i)@recordar\s+(\d{1,2}):(\d{2})`)
+
+// Cada cuánto se revisan los recordatorios de la nota abierta
+const reminderCheckInterval = 10 * time.Second
+
+// noteReminder es un aviso pendiente de la nota; Linea cuenta desde 0
+type noteReminder struct {
+	Linea int
+	Hora  time.Time
+	Texto string
+}
+
+// findReminders busca los recordatorios de hoy: los "@recordar HH:MM" y las horas de las
+// líneas congeladas con !, que son las únicas que no se cambian por la hora actual
+func findReminders(text string, day time.Time) []noteReminder {
+	var reminders []noteReminder
+	for i, line := range strings.Split(text, "\n") {
+		if m := reminderRegex.FindStringSubmatch(line); m != nil {
+			if at, ok := reminderTime(day, m[1], m[2]); ok {
+				message := strings.TrimSpace(reminderRegex.ReplaceAllString(line, ""))
+				reminders = append(reminders, noteReminder{Linea: i, Hora: at, Texto: message})
+			}
+			continue
+		}
+		if !isFrozenLine(line) {
+			continue
+		}
+		if match := noteTimeRegex.FindString(line); match != "" {
+			parts := strings.Split(match, ":")
+			if at, ok := reminderTime(day, parts[0], parts[1]); ok {
+				message := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), frozenLinePrefix))
+				reminders = append(reminders, noteReminder{Linea: i, Hora: at, Texto: message})
+			}
+		}
+	}
+	return reminders
+}
+
+// reminderTime arma la hora del recordatorio en el día de day
+func reminderTime(day time.Time, hour, minute string) (time.Time, bool) {
+	h, err1 := strconv.Atoi(hour)
+	m, err2 := strconv.Atoi(minute)
+	if err1 != nil || err2 != nil || h > 23 || m > 59 {
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location()), true
+}
+
+// dueReminders devuelve los recordatorios cuya hora llegó después de from y hasta to
+func dueReminders(text string, from, to time.Time) []noteReminder {
+	var due []noteReminder
+	for _, r := range findReminders(text, to) {
+		if r.Hora.After(from) && !r.Hora.After(to) {
+			due = append(due, r)
+		}
+	}
+	return due
+}
+
+// nextReminder es el aviso que se agrega a los conteos con el próximo recordatorio
+func nextReminder(text string, now time.Time) string {
+	var next *noteReminder
+	reminders := findReminders(text, now)
+	for i, r := range reminders {
+		if r.Hora.After(now) && (next == nil || r.Hora.Before(next.Hora)) {
+			next = &reminders[i]
+		}
+	}
+	if next == nil {
+		return ""
+	}
+	return fmt.Sprintf(" · ⏰ %s", next.Hora.Format("15:04"))
+}
+
+// startReminders revisa la nota abierta y avisa con una notificación del sistema cuando
+// llega la hora de un recordatorio. Los que ya pasaron al abrir el programa no avisan.
+func (n *NotePad) startReminders() {
+	last := time.Now()
+	ticker := time.NewTicker(reminderCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		fyne.Do(func() {
+			now := time.Now()
+			due := dueReminders(n.multiLine.Text, last, now)
+			last = now
+			for _, r := range due {
+				n.notifyReminder(r)
+			}
+			if len(due) > 0 {
+				n.updateCounts()
+			}
+		})
+	}
+}
+
+func (n *NotePad) notifyReminder(r noteReminder) {
+	message := r.Texto
+	if message == "" {
+		message = fmt.Sprintf("Recordatorio de la línea %d", r.Linea+1)
+	}
+	playNotificationSound()
+	fyne.CurrentApp().SendNotification(fyne.NewNotification(
+		fmt.Sprintf("⏰ Recordatorio %s", r.Hora.Format("15:04")), message))
+	n.statusLabel.SetText(fmt.Sprintf("Estado: ⏰ %s (línea %d)", message, r.Linea+1))
+}
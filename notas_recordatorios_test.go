@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindReminders(t *testing.T) {
+	day := time.Date(2026, 10, 16, 14, 0, 0, 0, time.Local)
+	text := "Recoger equipo ZETTACOM @recordar 16:30\n" +
+		"......0154 LGARCIA 15:04 MGAVINO\n" +
+		"!Llamar a proveedor 17:15\n" +
+		"@recordar 25:00 inválida"
+
+	reminders := findReminders(text, day)
+	if len(reminders) != 2 {
+		t.Fatalf("findReminders = %+v", reminders)
+	}
+	if r := reminders[0]; r.Linea != 0 || r.Texto != "Recoger equipo ZETTACOM" || !r.Hora.Equal(day.Add(150*time.Minute)) {
+		t.Errorf("primer recordatorio = %+v", r)
+	}
+	if r := reminders[1]; r.Linea != 2 || r.Texto != "Llamar a proveedor 17:15" || r.Hora.Format("15:04") != "17:15" {
+		t.Errorf("línea congelada = %+v", r)
+	}
+}
+
+func TestDueReminders(t *testing.T) {
+	text := "a @recordar 16:30\nb @recordar 16:31"
+	from := time.Date(2026, 10, 16, 16, 29, 55, 0, time.Local)
+
+	if due := dueReminders(text, from, from.Add(10*time.Second)); len(due) != 1 || due[0].Texto != "a" {
+		t.Errorf("dueReminders = %+v", due)
+	}
+	if due := dueReminders(text, from.Add(10*time.Second), from.Add(20*time.Second)); len(due) != 0 {
+		t.Errorf("un recordatorio no debería avisar dos veces: %+v", due)
+	}
+	if got := nextReminder(text, from); got != " · ⏰ 16:30" {
+		t.Errorf("nextReminder = %q", got)
+	}
+	if got := nextReminder(text, from.Add(time.Hour)); got != "" {
+		t.Errorf("sin recordatorios pendientes = %q", got)
+	}
+}
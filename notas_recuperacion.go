@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	recoveryFile  = "notas.recovery" // Junto al programa: lo que quedó sin guardar en este equipo
+	recoveryDelay = time.Second      // Se escribe al terminar cada ráfaga de teclas
+)
+
+// noteRecovery es la copia de lo que había en el editor, guardado o no. Si el programa se
+// cerró bien se borra; si al abrir todavía existe y no coincide con el archivo, se ofrece
+// restaurarla.
+type noteRecovery struct {
+	Ruta    string
+	Externo bool // Ruta es un .txt abierto con 📂 Abrir
+	Fecha   time.Time
+	Texto   string
+}
+
+// writeRecovery guarda la copia en path, cifrada si hay clave como las notas
+func writeRecovery(path string, rec noteRecovery, key []byte) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return writeNoteFile(path, data, key)
+}
+
+// readRecovery lee la copia de path; ok es false si no hay ninguna
+func readRecovery(path string, key []byte) (rec noteRecovery, ok bool, err error) {
+	data, err := readNoteFile(path, key)
+	if os.IsNotExist(err) {
+		return rec, false, nil
+	}
+	if err != nil {
+		return rec, false, err
+	}
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return rec, false, fmt.Errorf("copia de recuperación dañada: %v", err)
+	}
+	return rec, true, nil
+}
+
+// scheduleRecovery se llama desde OnChanged y escribe la copia un momento después de la
+// última tecla. No hace nada hasta revisar la copia que dejó la sesión anterior, para no
+// pisarla.
+func (n *NotePad) scheduleRecovery() {
+	if !n.recovered || n.pendingKind == historySkip {
+		return
+	}
+	if n.recoverTimer != nil {
+		n.recoverTimer.Reset(recoveryDelay)
+		return
+	}
+	n.recoverTimer = time.AfterFunc(recoveryDelay, func() {
+		fyne.Do(n.writeRecoveryNow)
+	})
+}
+
+func (n *NotePad) writeRecoveryNow() {
+	n.saveMu.Lock()
+	path, key := n.currentFile()
+	skip := n.current == "" || n.locked
+	n.saveMu.Unlock()
+	if skip {
+		return
+	}
+	rec := noteRecovery{Ruta: path, Externo: n.external, Fecha: time.Now(), Texto: n.noteText()}
+	if err := writeRecovery(recoveryFile, rec, key); err != nil {
+		log.Printf("Error escribiendo %s: %v", recoveryFile, err)
+	}
+}
+
+// discardRecovery borra la copia cuando lo del editor ya está en el archivo
+func discardRecovery() {
+	if err := os.Remove(recoveryFile); err != nil && !os.IsNotExist(err) {
+		log.Printf("Error borrando %s: %v", recoveryFile, err)
+	}
+}
+
+// stopRecovery se llama al cerrar bien el programa, después de guardar. Si las notas
+// siguen bloqueadas se conserva la copia de la sesión anterior, que todavía no se revisó.
+func (n *NotePad) stopRecovery() {
+	if n.recoverTimer != nil {
+		n.recoverTimer.Stop()
+	}
+	if n.recovered {
+		discardRecovery()
+	}
+}
+
+// checkRecovery se llama al abrir, con las notas ya desbloqueadas: si la sesión anterior
+// terminó mal con cambios sin guardar, ofrece restaurarlos
+func (n *NotePad) checkRecovery(window fyne.Window) {
+	if n.recovered {
+		return
+	}
+	n.recovered = true
+
+	rec, ok, err := readRecovery(recoveryFile, n.key)
+	if err != nil {
+		log.Printf("Error leyendo %s: %v", recoveryFile, err)
+		discardRecovery()
+		return
+	}
+	if !ok {
+		return
+	}
+	var key []byte
+	if !rec.Externo {
+		key = n.key
+	}
+	saved := ""
+	if data, err := readNoteFile(rec.Ruta, key); err == nil {
+		saved = stripSaveHeader(string(data))
+	}
+	if saved == rec.Texto {
+		discardRecovery()
+		return
+	}
+
+	message := widget.NewLabel(fmt.Sprintf("El programa se cerró sin guardar los cambios de %s del %s. "+
+		"En rojo lo que tiene el archivo y en verde lo que se recupera.", recentLabel(rec.Ruta, notesDir), rec.Fecha.Format("02/01/2006 15:04:05")))
+	message.Wrapping = fyne.TextWrapWord
+	content := container.NewBorder(message, nil, nil, nil, container.NewScroll(diffView(diffLines(saved, rec.Texto))))
+
+	d := dialog.NewCustomConfirm("🛟 Cambios sin guardar", "Restaurar", "Descartar", content, func(restore bool) {
+		if restore {
+			n.restoreRecovery(rec)
+		}
+		discardRecovery()
+	}, window)
+	d.Resize(fyne.NewSize(700, 480))
+	d.Show()
+}
+
+// restoreRecovery abre la nota o el archivo de la copia y pone lo recuperado en el editor;
+// Ctrl+Z vuelve a lo que había en el archivo
+func (n *NotePad) restoreRecovery(rec noteRecovery) {
+	if name, ok := recentNoteName(rec.Ruta, notesDir); ok && !rec.Externo {
+		n.switchNote(name)
+		n.selectNoteInList()
+	} else if current, _ := n.currentFile(); !samePath(current, rec.Ruta) {
+		n.openExternal(rec.Ruta)
+	}
+	n.applyEdit(historyRescue, rec.Texto)
+	n.statusLabel.SetText("Estado: 🛟 Cambios sin guardar restaurados")
+}
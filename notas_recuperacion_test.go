@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteReadRecovery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, recoveryFile)
+	if _, ok, err := readRecovery(path, nil); ok || err != nil {
+		t.Fatalf("sin copia ok debería ser false y sin error: %v, %v", ok, err)
+	}
+
+	_, key, err := newVault("clave-segura")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := noteRecovery{
+		Ruta:  filepath.Join(dir, "General.txt"),
+		Fecha: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		Texto: "......0154 LGARCIA 09:00 JRIOS\nclave 1234",
+	}
+	for _, k := range [][]byte{nil, key} {
+		if err := writeRecovery(path, rec, k); err != nil {
+			t.Fatal(err)
+		}
+		got, ok, err := readRecovery(path, k)
+		if err != nil || !ok || got.Ruta != rec.Ruta || got.Texto != rec.Texto || !got.Fecha.Equal(rec.Fecha) {
+			t.Errorf("readRecovery = %+v, %v, %v", got, ok, err)
+		}
+	}
+	if data, _ := ioutil.ReadFile(path); bytes.Contains(data, []byte("clave 1234")) {
+		t.Error("con clave la copia debería quedar cifrada")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("{roto"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := readRecovery(path, nil); ok || err == nil {
+		t.Error("una copia dañada debería dar error")
+	}
+}
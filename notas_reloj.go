@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const clockSettingsFile = "hora_notas.json" // Junto al programa: depende de lo rápido que sea cada equipo
+
+// clockCadences son las frecuencias que se pueden elegir, en segundos. En los equipos lentos
+// reescribir la nota cada segundo traba el editor; con 60 la hora igual cambia al empezar
+// cada minuto, que es lo que muestran las horas HH:mm.
+var clockCadences = []int{1, 10, 60}
+
+// NoteClockSettings controla la actualización automática de horas y fechas en la nota
+type NoteClockSettings struct {
+	Activo bool
+	Cada   int // Segundos entre actualizaciones, uno de clockCadences
+}
+
+func defaultClockSettings() NoteClockSettings {
+	return NoteClockSettings{Activo: true, Cada: 1}
+}
+
+func (s NoteClockSettings) validate() error {
+	for _, c := range clockCadences {
+		if s.Cada == c {
+			return nil
+		}
+	}
+	return fmt.Errorf("la hora se actualiza cada %v segundos, no cada %d", clockCadences, s.Cada)
+}
+
+func loadClockSettings() NoteClockSettings {
+	settings := defaultClockSettings()
+	data, err := ioutil.ReadFile(clockSettingsFile)
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", clockSettingsFile, err)
+		return defaultClockSettings()
+	}
+	if err := settings.validate(); err != nil {
+		log.Printf("Actualización de hora inválida, se usa la predeterminada: %v", err)
+		return defaultClockSettings()
+	}
+	return settings
+}
+
+func saveClockSettings(settings NoteClockSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(clockSettingsFile, data, 0644)
+}
+
+// clockDue indica si toca actualizar: cuando now cae en otro tramo de every que last, así
+// con 60 segundos se actualiza justo al cambiar el minuto y no 60 segundos después de abrir
+func clockDue(last, now time.Time, every time.Duration) bool {
+	return last.IsZero() || !now.Truncate(every).Equal(last.Truncate(every))
+}
+
+func cadenceLabel(seconds int) string {
+	return fmt.Sprintf("Cada %d s", seconds)
+}
+
+// createClockControls devuelve la casilla para pausar la hora automática y la lista con la
+// frecuencia; los cambios se guardan en este equipo
+func (n *NotePad) createClockControls() fyne.CanvasObject {
+	labels := make([]string, len(clockCadences))
+	for i, c := range clockCadences {
+		labels[i] = cadenceLabel(c)
+	}
+	save := func() {
+		if err := saveClockSettings(n.clock); err != nil {
+			log.Printf("Error guardando %s: %v", clockSettingsFile, err)
+		}
+		n.lastClockRun = time.Time{}
+	}
+
+	activeCheck := widget.NewCheck("⏱️ Hora automática", nil)
+	activeCheck.SetChecked(n.clock.Activo)
+	activeCheck.OnChanged = func(on bool) {
+		n.clock.Activo = on
+		save()
+		if on {
+			n.statusLabel.SetText(fmt.Sprintf("Estado: Horas actualizándose %s", cadenceLabel(n.clock.Cada)))
+		} else {
+			n.statusLabel.SetText("Estado: ⏸️ Actualización de horas en pausa")
+		}
+	}
+	cadenceSelect := widget.NewSelect(labels, nil)
+	cadenceSelect.SetSelected(cadenceLabel(n.clock.Cada))
+	cadenceSelect.OnChanged = func(string) {
+		n.clock.Cada = clockCadences[cadenceSelect.SelectedIndex()]
+		save()
+	}
+	return container.NewHBox(activeCheck, cadenceSelect)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockDue(t *testing.T) {
+	base := time.Date(2026, 10, 16, 15, 4, 0, 0, time.Local)
+	tests := []struct {
+		last, now time.Time
+		every     time.Duration
+		want      bool
+	}{
+		{time.Time{}, base, time.Minute, true},
+		{base, base.Add(time.Second), time.Second, true},
+		{base, base.Add(59 * time.Second), time.Minute, false},
+		{base.Add(30 * time.Second), base.Add(60 * time.Second), time.Minute, true},
+		{base.Add(2 * time.Second), base.Add(9 * time.Second), 10 * time.Second, false},
+		{base.Add(9 * time.Second), base.Add(10 * time.Second), 10 * time.Second, true},
+	}
+	for _, tt := range tests {
+		if got := clockDue(tt.last, tt.now, tt.every); got != tt.want {
+			t.Errorf("clockDue(%s, %s, %s) = %v, quería %v", tt.last.Format("15:04:05"), tt.now.Format("15:04:05"), tt.every, got, tt.want)
+		}
+	}
+}
+
+func TestClockSettingsValidate(t *testing.T) {
+	for _, c := range clockCadences {
+		if err := (NoteClockSettings{Cada: c}).validate(); err != nil {
+			t.Errorf("cada %d segundos debería ser válido: %v", c, err)
+		}
+	}
+	for _, c := range []int{0, 5, 3600} {
+		if (NoteClockSettings{Cada: c}).validate() == nil {
+			t.Errorf("cada %d segundos debería ser inválido", c)
+		}
+	}
+}
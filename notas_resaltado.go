@@ -0,0 +1,157 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Tipos de palabra que se resaltan en la vista previa
+const (
+	tokenPlain      = ""
+	tokenTime       = "hora"
+	tokenCode       = "codigo"
+	tokenUser       = "usuario"
+	tokenReposicion = "reposicion"
+	tokenComment    = "comentario"
+	tokenTitle      = "titulo"
+	tokenMisspelled = "ortografia"
+)
+
+// highlightRegex reconoce, en este orden, fechas, horas, códigos con o sin puntos de
+// relleno (......0154, ZET00154) y palabras; highlightLine decide cuáles son usuarios
+var highlightRegex = regexp.MustCompile(
+	`(\b\d{1,2}[/\-.]\d{1,2}[/\-.]\d{2,4}\b|\b\d{1,2}:\d{2}(?::\d{2})?\b)` +
+		`|(\.*\b[A-Za-z]{0,4}\d{3,}\b)` +
+		`|(\p{L}+)`)
+
+// highlightSpan es un trozo de una línea con su tipo
+type highlightSpan struct {
+	Texto string
+	Tipo  string
+}
+
+// highlightLine parte una línea en trozos para colorearla. Los títulos entre asteriscos y
+// los comentarios con # van enteros; REPOSICIÓN se marca aparte de los usuarios.
+func highlightLine(line string) []highlightSpan {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return nil
+	case starTitleRegex.MatchString(trimmed):
+		return []highlightSpan{{line, tokenTitle}}
+	case strings.HasPrefix(trimmed, "#"):
+		return []highlightSpan{{line, tokenComment}}
+	}
+
+	var spans []highlightSpan
+	last := 0
+	for _, m := range highlightRegex.FindAllStringSubmatchIndex(line, -1) {
+		word := line[m[0]:m[1]]
+		kind := tokenPlain
+		switch {
+		case m[2] >= 0:
+			kind = tokenTime
+		case m[4] >= 0:
+			kind = tokenCode
+		case reposicionRegex.MatchString(word):
+			kind = tokenReposicion
+		case isUserWord(word):
+			kind = tokenUser
+		}
+		spans = appendSpan(spans, line[last:m[0]], tokenPlain)
+		spans = appendSpan(spans, word, kind)
+		last = m[1]
+	}
+	return appendSpan(spans, line[last:], tokenPlain)
+}
+
+// appendSpan agrega un trozo juntando el texto sin resaltar con el anterior
+func appendSpan(spans []highlightSpan, text, kind string) []highlightSpan {
+	if text == "" {
+		return spans
+	}
+	if n := len(spans); n > 0 && kind == tokenPlain && spans[n-1].Tipo == tokenPlain {
+		spans[n-1].Texto += text
+		return spans
+	}
+	return append(spans, highlightSpan{text, kind})
+}
+
+// markMisspelled separa de los trozos sin resaltar las palabras que misspelled rechaza
+func markMisspelled(spans []highlightSpan, misspelled func(string) bool) []highlightSpan {
+	var marked []highlightSpan
+	for _, span := range spans {
+		if span.Tipo != tokenPlain {
+			marked = append(marked, span)
+			continue
+		}
+		last := 0
+		for _, m := range spellWordRegex.FindAllStringIndex(span.Texto, -1) {
+			if word := span.Texto[m[0]:m[1]]; misspelled(word) {
+				marked = appendSpan(marked, span.Texto[last:m[0]], tokenPlain)
+				marked = append(marked, highlightSpan{word, tokenMisspelled})
+				last = m[1]
+			}
+		}
+		marked = appendSpan(marked, span.Texto[last:], tokenPlain)
+	}
+	return marked
+}
+
+// isUserWord reconoce las iniciales de usuario: palabras de 3 letras o más en mayúsculas
+func isUserWord(word string) bool {
+	return utf8.RuneCountInString(word) >= 3 && word == strings.ToUpper(word)
+}
+
+// highlightStyle es el estilo de cada tipo de palabra, con los colores del tema para que
+// se lean igual en el modo oscuro
+func highlightStyle(kind string) widget.RichTextStyle {
+	style := widget.RichTextStyle{Inline: true}
+	switch kind {
+	case tokenTime:
+		style.ColorName = theme.ColorNamePrimary
+	case tokenCode:
+		style.ColorName = theme.ColorNameSuccess
+		style.TextStyle = fyne.TextStyle{Bold: true}
+	case tokenUser:
+		style.ColorName = theme.ColorNameWarning
+	case tokenReposicion:
+		style.TextStyle = fyne.TextStyle{Bold: true}
+	case tokenComment:
+		style.ColorName = theme.ColorNamePlaceHolder
+		style.TextStyle = fyne.TextStyle{Italic: true}
+	case tokenMisspelled:
+		style.ColorName = theme.ColorNameError
+		style.TextStyle = fyne.TextStyle{Underline: true}
+	case tokenTitle:
+		style = widget.RichTextStyleSubHeading
+		style.Inline = true
+	}
+	return style
+}
+
+// highlightSegments arma el texto resaltado de la vista previa, una fila por línea. Con
+// misspelled se subrayan además las palabras mal escritas.
+func highlightSegments(text string, misspelled func(string) bool) []widget.RichTextSegment {
+	var segments []widget.RichTextSegment
+	for _, line := range strings.Split(text, "\n") {
+		spans := highlightLine(strings.TrimRight(line, " \t\r"))
+		if misspelled != nil {
+			spans = markMisspelled(spans, misspelled)
+		}
+		if len(spans) == 0 {
+			spans = []highlightSpan{{" ", tokenPlain}}
+		}
+		for i, span := range spans {
+			style := highlightStyle(span.Tipo)
+			style.Inline = i < len(spans)-1 // El último trozo termina la fila
+			segments = append(segments, &widget.TextSegment{Style: style, Text: span.Texto})
+		}
+	}
+	return segments
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHighlightLine(t *testing.T) {
+	want := []highlightSpan{
+		{"......0154", tokenCode},
+		{" ", tokenPlain},
+		{"LGARCIA", tokenUser},
+		{" ", tokenPlain},
+		{"15:04", tokenTime},
+		{" ", tokenPlain},
+		{"MGAVINO", tokenUser},
+		{" ok", tokenPlain},
+	}
+	if got := highlightLine("......0154 LGARCIA 15:04 MGAVINO ok"); !reflect.DeepEqual(got, want) {
+		t.Errorf("highlightLine = %+v", got)
+	}
+
+	got := highlightLine("ZET00154 REPOSICIÓN 16/10/2026 de Ana")
+	kinds := []string{}
+	for _, span := range got {
+		if span.Tipo != tokenPlain {
+			kinds = append(kinds, span.Texto+"="+span.Tipo)
+		}
+	}
+	if !reflect.DeepEqual(kinds, []string{"ZET00154=codigo", "REPOSICIÓN=reposicion", "16/10/2026=hora"}) {
+		t.Errorf("tipos = %v", kinds)
+	}
+
+	if got := highlightLine("***ZETTACOM***"); len(got) != 1 || got[0].Tipo != tokenTitle {
+		t.Errorf("título = %+v", got)
+	}
+	if got := highlightLine("# JRIOS 15:04"); len(got) != 1 || got[0].Tipo != tokenComment {
+		t.Errorf("comentario = %+v", got)
+	}
+	if got := highlightLine("  "); got != nil {
+		t.Errorf("línea vacía = %+v", got)
+	}
+}
+
+func TestMarkMisspelled(t *testing.T) {
+	wrong := func(word string) bool { return word == "ekipo" }
+	got := markMisspelled(highlightLine("......0154 el ekipo 15:04 JRIOS"), wrong)
+	want := []highlightSpan{
+		{"......0154", tokenCode},
+		{" el ", tokenPlain},
+		{"ekipo", tokenMisspelled},
+		{" ", tokenPlain},
+		{"15:04", tokenTime},
+		{" ", tokenPlain},
+		{"JRIOS", tokenUser},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("markMisspelled = %+v", got)
+	}
+}
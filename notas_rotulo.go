@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Etiquetas que se reconocen al principio de una línea de dirección: "Tel: 987 654 321"
+var (
+	labelNameRegex    = regexp.MustCompile(`(?i)^(nombre|destinatario|cliente|para)\s*[:\-]\s*`)
+	labelAddressRegex = regexp.MustCompile(`(?i)^(direcci[oó]n|dir|domicilio)\.?\s*[:\-]\s*`)
+	labelPhoneRegex   = regexp.MustCompile(`(?i)^(tel[eé]fono|telf?|cel(ular)?|m[oó]vil)\.?\s*[:\-]?\s*`)
+)
+
+// Dígitos mínimos de una línea para tomarla como teléfono
+const minPhoneDigits = 6
+
+// addressBlock son los datos del destinatario sacados de un bloque pegado en las notas
+type addressBlock struct {
+	Nombre    string
+	Direccion string
+	Telefono  string
+}
+
+// isPhoneLine indica si la línea es solo un teléfono: dígitos, espacios, +, -, y paréntesis
+func isPhoneLine(line string) bool {
+	digits := 0
+	for _, r := range line {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case strings.ContainsRune(" +-()/.", r):
+		default:
+			return false
+		}
+	}
+	return digits >= minPhoneDigits
+}
+
+// parseAddressBlock lee nombre, dirección y teléfono de las líneas. Las líneas con
+// etiqueta ("Dirección: ...") van a su campo; sin etiqueta la primera es el nombre, una
+// línea de solo números el teléfono y las demás la dirección.
+func parseAddressBlock(text string) addressBlock {
+	var block addressBlock
+	var address []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || starTitleRegex.MatchString(line) {
+			continue
+		}
+		switch {
+		case labelNameRegex.MatchString(line):
+			block.Nombre = strings.TrimSpace(labelNameRegex.ReplaceAllString(line, ""))
+		case labelAddressRegex.MatchString(line):
+			address = append(address, strings.TrimSpace(labelAddressRegex.ReplaceAllString(line, "")))
+		case labelPhoneRegex.MatchString(line) && isPhoneLine(labelPhoneRegex.ReplaceAllString(line, "")):
+			block.Telefono = strings.TrimSpace(labelPhoneRegex.ReplaceAllString(line, ""))
+		case isPhoneLine(line) && block.Telefono == "":
+			block.Telefono = line
+		case block.Nombre == "" && len(address) == 0:
+			block.Nombre = line
+		default:
+			address = append(address, line)
+		}
+	}
+	block.Direccion = strings.Join(address, "\n")
+	return block
+}
+
+// sendToRotulo llena el destinatario del Rótulo con las líneas seleccionadas
+func (n *NotePad) sendToRotulo() {
+	block := parseAddressBlock(n.noteLines())
+	if block.Nombre == "" && block.Direccion == "" && block.Telefono == "" {
+		n.statusLabel.SetText("Estado: Selecciona las líneas con el nombre, la dirección y el teléfono")
+		return
+	}
+	if n.onSendLabel != nil {
+		n.onSendLabel(block)
+	}
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Destinatario %q enviado al Rótulo", block.Nombre))
+}
+
+// fillDestinatario reemplaza los datos del destinatario; los campos que el bloque no
+// trae quedan vacíos para no mezclar con el destinatario anterior
+func (r *RotuloGenerator) fillDestinatario(block addressBlock) {
+	r.inputs["destinatarioNombre"].SetText(block.Nombre)
+	r.inputs["destinatarioDireccion"].SetText(block.Direccion)
+	r.inputs["destinatarioTelefono"].SetText(block.Telefono)
+	r.inputs["destinatarioDNI"].SetText("")
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestParseAddressBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want addressBlock
+	}{
+		{
+			name: "sin etiquetas",
+			text: "Juan Pérez\nAv. Arequipa 123, Lince\nLima\n987 654 321\n",
+			want: addressBlock{Nombre: "Juan Pérez", Direccion: "Av. Arequipa 123, Lince\nLima", Telefono: "987 654 321"},
+		},
+		{
+			name: "con etiquetas en otro orden",
+			text: "Tel: +51 (01) 444-5555\nDirección: Jr. Junín 450\nNombre: ZETTACOM SAC",
+			want: addressBlock{Nombre: "ZETTACOM SAC", Direccion: "Jr. Junín 450", Telefono: "+51 (01) 444-5555"},
+		},
+		{
+			name: "títulos y comentarios se ignoran",
+			text: "***ENVÍO***\n# pegado del correo\nMaría Quintana\n\nCalle Los Pinos 12",
+			want: addressBlock{Nombre: "María Quintana", Direccion: "Calle Los Pinos 12"},
+		},
+		{
+			name: "número corto no es teléfono",
+			text: "Ana Ruiz\n1234",
+			want: addressBlock{Nombre: "Ana Ruiz", Direccion: "1234"},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseAddressBlock(tt.text); got != tt.want {
+			t.Errorf("%s: parseAddressBlock = %+v, quería %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Líneas de reposición como "......0154 LGARCIA 15:04 MGAVINO": puntos de relleno, código,
+// técnico, hora y quién la registró. Las congeladas con ! y las que tienen casilla también
+// cuentan; el ! y la casilla quedan en el prefijo.
+var reposicionLineRegex = regexp.MustCompile(`^(\s*!?\s*(?:\[[ xX]\]\s*)?\.*)(\S+)\s+(\S+)\s+(\d{1,2}:\d{2})\s+(\S+)\s*$`)
+
+// Columnas de la vista de tabla; la sección es el título entre asteriscos y no se edita
+var reposicionColumns = []string{"Sección", "Código", "Técnico", "Hora", "Registrado por"}
+
+// reposicionRow es una línea de reposición separada en columnas; Linea es su posición en
+// el texto (desde 0)
+type reposicionRow struct {
+	Linea      int
+	Seccion    string
+	Prefijo    string
+	Codigo     string
+	Tecnico    string
+	Hora       string
+	Registrado string
+}
+
+// parseReposicionTable separa en columnas las líneas de reposición del texto; los títulos,
+// comentarios y líneas vacías se dejan fuera de la tabla pero siguen en el texto
+func parseReposicionTable(text string) []reposicionRow {
+	var rows []reposicionRow
+	section := ""
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if title := starTitleRegex.FindStringSubmatch(trimmed); title != nil {
+			section = title[1]
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		m := reposicionLineRegex.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+		if m == nil {
+			continue
+		}
+		rows = append(rows, reposicionRow{
+			Linea: i, Seccion: section,
+			Prefijo: m[1], Codigo: m[2], Tecnico: m[3], Hora: m[4], Registrado: m[5],
+		})
+	}
+	return rows
+}
+
+// String vuelve a armar la línea de texto conservando los puntos de relleno
+func (r reposicionRow) String() string {
+	return r.Prefijo + strings.Join([]string{r.Codigo, r.Tecnico, r.Hora, r.Registrado}, " ")
+}
+
+// field devuelve la columna col de la tabla
+func (r *reposicionRow) field(col int) *string {
+	return []*string{&r.Seccion, &r.Codigo, &r.Tecnico, &r.Hora, &r.Registrado}[col]
+}
+
+// setField cambia una columna editable y devuelve la línea nueva; falla si la línea dejaría
+// de leerse como reposición (columnas vacías, con espacios o una hora inválida)
+func (r *reposicionRow) setField(col int, value string) (string, error) {
+	if col == 0 {
+		return "", fmt.Errorf("la sección se cambia en el título de la nota")
+	}
+	value = strings.TrimSpace(value)
+	changed := *r
+	*changed.field(col) = value
+	line := changed.String()
+	if value == "" || strings.ContainsAny(value, " \t") || !reposicionLineRegex.MatchString(line) {
+		return "", fmt.Errorf("%s no es válido en la línea %d", reposicionColumns[col], r.Linea+1)
+	}
+	*r = changed
+	return line, nil
+}
+
+// replaceLine cambia la línea index del texto por line
+func replaceLine(text string, index int, line string) string {
+	lines := strings.Split(text, "\n")
+	if index < 0 || index >= len(lines) {
+		return text
+	}
+	lines[index] = line
+	return strings.Join(lines, "\n")
+}
+
+// updateTable vuelve a leer las filas si la tabla está abierta
+func (n *NotePad) updateTable() {
+	if n.repTable == nil || !n.tableBox.Visible() {
+		return
+	}
+	n.tableRows = parseReposicionTable(n.multiLine.Text)
+	n.tableLabel.SetText(fmt.Sprintf("%d líneas de reposición. Edita una celda y el texto de la nota se actualiza solo.", len(n.tableRows)))
+	n.repTable.Refresh()
+}
+
+// editTableCell pasa al texto de la nota lo escrito en una celda
+func (n *NotePad) editTableCell(row, col int, value string) {
+	if row >= len(n.tableRows) {
+		return
+	}
+	line, err := n.tableRows[row].setField(col, value)
+	if err != nil {
+		n.statusLabel.SetText("Estado: " + err.Error())
+		return
+	}
+	n.applyEdit(historyTable, replaceLine(n.multiLine.Text, n.tableRows[row].Linea, line))
+}
+
+// createTablePane arma la vista de tabla, que ocupa el lugar del editor mientras la casilla
+// está marcada. El archivo sigue siendo el texto de la nota.
+func (n *NotePad) createTablePane(editor fyne.CanvasObject) (fyne.CanvasObject, *widget.Check) {
+	n.repTable = widget.NewTableWithHeaders(
+		func() (int, int) {
+			return len(n.tableRows), len(reposicionColumns)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewEntry()
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			cell := obj.(*widget.Entry)
+			cell.OnChanged = nil
+			if id.Row >= len(n.tableRows) {
+				return
+			}
+			row := n.tableRows[id.Row]
+			if value := *row.field(id.Col); cell.Text != value {
+				cell.SetText(value)
+			}
+			if id.Col == 0 {
+				cell.Disable()
+			} else {
+				cell.Enable()
+			}
+			cell.OnChanged = func(value string) {
+				n.editTableCell(id.Row, id.Col, value)
+			}
+		},
+	)
+	n.repTable.ShowHeaderColumn = false
+	n.repTable.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	n.repTable.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		if id.Col >= 0 {
+			obj.(*widget.Label).SetText(reposicionColumns[id.Col])
+		}
+	}
+	for col, width := range []float32{160, 90, 140, 80, 140} {
+		n.repTable.SetColumnWidth(col, width)
+	}
+
+	n.tableLabel = widget.NewLabel("")
+	n.tableBox = container.NewBorder(n.tableLabel, nil, nil, nil, n.repTable)
+	n.tableBox.Hide()
+
+	toggle := widget.NewCheck("📋 Tabla", func(on bool) {
+		if on {
+			editor.Hide()
+			n.tableBox.Show()
+			n.updateTable()
+		} else {
+			n.tableBox.Hide()
+			editor.Show()
+		}
+	})
+	return container.NewStack(editor, n.tableBox), toggle
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReposicionTable(t *testing.T) {
+	text := "*****LISTA REPOSICIÓN*****\n......9999 REPOSICION 15:04 JRIOS\n\n***ZETTACOM***\n......0154 LGARCIA 15:04 MGAVINO\n# 0083 comentario 10:00 X\n!......0017 NCRISOSTOMO 9:30 JRIOS"
+
+	want := []reposicionRow{
+		{Linea: 1, Seccion: "LISTA REPOSICIÓN", Prefijo: "......", Codigo: "9999", Tecnico: "REPOSICION", Hora: "15:04", Registrado: "JRIOS"},
+		{Linea: 4, Seccion: "ZETTACOM", Prefijo: "......", Codigo: "0154", Tecnico: "LGARCIA", Hora: "15:04", Registrado: "MGAVINO"},
+		{Linea: 6, Seccion: "ZETTACOM", Prefijo: "!......", Codigo: "0017", Tecnico: "NCRISOSTOMO", Hora: "9:30", Registrado: "JRIOS"},
+	}
+	if got := parseReposicionTable(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseReposicionTable = %+v", got)
+	}
+}
+
+func TestReposicionRowSetField(t *testing.T) {
+	text := "***ZETTACOM***\n......0154 LGARCIA 15:04 MGAVINO\nfin"
+	row := parseReposicionTable(text)[0]
+
+	line, err := row.setField(2, " JVILCATOMA ")
+	if err != nil || line != "......0154 JVILCATOMA 15:04 MGAVINO" {
+		t.Errorf("setField = %q, %v", line, err)
+	}
+	if got := replaceLine(text, row.Linea, line); got != "***ZETTACOM***\n......0154 JVILCATOMA 15:04 MGAVINO\nfin" {
+		t.Errorf("replaceLine = %q", got)
+	}
+	for col, value := range map[int]string{0: "OTRA", 1: "", 2: "J VILCA", 3: "tarde"} {
+		if _, err := row.setField(col, value); err == nil {
+			t.Errorf("columna %d con %q debería dar error", col, value)
+		}
+	}
+	if row.Tecnico != "JVILCATOMA" {
+		t.Errorf("un cambio inválido no debería modificar la fila: %+v", row)
+	}
+}
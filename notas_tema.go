@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"image/color"
+	"io/ioutil"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const themeSettingsFile = "tema_notas.json" // Junto al programa, como la letra del editor
+
+// NoteThemeSettings guarda si la pestaña de notas usa el modo oscuro
+type NoteThemeSettings struct {
+	Oscuro bool
+}
+
+func loadThemeSettings() NoteThemeSettings {
+	var settings NoteThemeSettings
+	data, err := ioutil.ReadFile(themeSettingsFile)
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("Error leyendo %s: %v", themeSettingsFile, err)
+		return NoteThemeSettings{}
+	}
+	return settings
+}
+
+func saveThemeSettings(settings NoteThemeSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(themeSettingsFile, data, 0644)
+}
+
+// noteTheme es el tema de la pestaña de notas. Con dark usa los colores oscuros aunque el
+// resto del programa siga claro; textSize cambia solo la letra (0 deja la normal).
+type noteTheme struct {
+	textSize float32
+	dark     bool
+}
+
+func (t *noteTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if t.dark {
+		variant = theme.VariantDark
+	}
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+func (t *noteTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+func (t *noteTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+func (t *noteTheme) Size(name fyne.ThemeSizeName) float32 {
+	if name == theme.SizeNameText && t.textSize > 0 {
+		return t.textSize
+	}
+	return theme.DefaultTheme().Size(name)
+}
+
+// backgroundColor es el fondo de la pestaña; las pestañas no pintan fondo propio
+func (t *noteTheme) backgroundColor() color.Color {
+	return t.Color(theme.ColorNameBackground, fyne.CurrentApp().Settings().ThemeVariant())
+}
+
+// createThemeToggle devuelve la casilla del modo oscuro con el valor guardado en este equipo
+func (n *NotePad) createThemeToggle() *widget.Check {
+	dark := loadThemeSettings().Oscuro
+	n.tabTheme = &noteTheme{dark: dark}
+	n.editorTheme.dark = dark
+
+	toggle := widget.NewCheck("🌙 Modo oscuro", nil)
+	toggle.SetChecked(dark)
+	toggle.OnChanged = func(on bool) {
+		n.setDarkMode(on)
+		if err := saveThemeSettings(NoteThemeSettings{Oscuro: on}); err != nil {
+			log.Printf("Error guardando %s: %v", themeSettingsFile, err)
+		}
+	}
+	return toggle
+}
+
+// wrapTabTheme aplica el tema de las notas a toda la pestaña
+func (n *NotePad) wrapTabTheme(content fyne.CanvasObject) fyne.CanvasObject {
+	n.tabBackdrop = canvas.NewRectangle(n.tabTheme.backgroundColor())
+	n.tabBox = container.NewThemeOverride(container.NewStack(n.tabBackdrop, content), n.tabTheme)
+	return n.tabBox
+}
+
+// setDarkMode cambia entre el modo oscuro y los colores del resto del programa
+func (n *NotePad) setDarkMode(dark bool) {
+	n.tabTheme.dark = dark
+	n.editorTheme.dark = dark
+	n.tabBackdrop.FillColor = n.tabTheme.backgroundColor()
+	n.tabBox.Refresh()
+	n.themeBox.Refresh()
+	if dark {
+		n.statusLabel.SetText("Estado: Modo oscuro")
+	} else {
+		n.statusLabel.SetText("Estado: Modo oscuro desactivado")
+	}
+}
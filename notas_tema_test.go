@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestThemeSettings(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if loadThemeSettings().Oscuro {
+		t.Error("sin tema_notas.json no debería usarse el modo oscuro")
+	}
+	if err := saveThemeSettings(NoteThemeSettings{Oscuro: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !loadThemeSettings().Oscuro {
+		t.Error("el modo oscuro guardado debería recordarse")
+	}
+	os.WriteFile(themeSettingsFile, []byte("{"), 0644)
+	if loadThemeSettings().Oscuro {
+		t.Error("un archivo dañado debería volver al modo normal")
+	}
+}
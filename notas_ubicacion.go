@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	defaultNotesDir   = "notas"
+	notesLocationFile = "ubicacion_notas.json" // Junto al programa: cada equipo elige su carpeta
+	saveHeaderPrefix  = "# Guardado:"
+)
+
+// notesDir es la carpeta de las notas; puede ser una unidad de red compartida por el equipo
+var notesDir = defaultNotesDir
+
+type notesLocation struct {
+	Carpeta string
+}
+
+func loadNotesLocation() string {
+	data, err := ioutil.ReadFile(notesLocationFile)
+	if err != nil {
+		return defaultNotesDir
+	}
+	var location notesLocation
+	if err := json.Unmarshal(data, &location); err != nil || strings.TrimSpace(location.Carpeta) == "" {
+		log.Printf("Error leyendo %s, se usa la carpeta %q: %v", notesLocationFile, defaultNotesDir, err)
+		return defaultNotesDir
+	}
+	return location.Carpeta
+}
+
+func saveNotesLocation(dir string) error {
+	data, err := json.MarshalIndent(notesLocation{Carpeta: dir}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(notesLocationFile, data, 0644)
+}
+
+// checkNotesDir comprueba que dir sea una carpeta donde se pueda escribir
+func checkNotesDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("no se puede abrir la carpeta %s: %v", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s no es una carpeta", dir)
+	}
+	f, err := ioutil.TempFile(dir, ".prueba-*")
+	if err != nil {
+		return fmt.Errorf("no se puede escribir en %s: %v", dir, err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// stripSaveHeader quita la línea "# Guardado: ..." que saveContent agrega al principio
+func stripSaveHeader(content string) string {
+	if strings.HasPrefix(content, saveHeaderPrefix) {
+		if i := strings.Index(content, "\n"); i >= 0 {
+			return content[i+1:]
+		}
+		return ""
+	}
+	return content
+}
+
+// remoteChanged indica si el archivo fue modificado por otro equipo después de la última
+// vez que este lo leyó o escribió, y con qué fecha
+func remoteChanged(path string, lastWrite time.Time) (time.Time, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), info.ModTime().After(lastWrite)
+}
+
+// markWritten recuerda la fecha del archivo recién leído o escrito; se llama con saveMu tomado
+func (n *NotePad) markWritten(path string) {
+	if info, err := os.Stat(path); err == nil {
+		n.lastWrite = info.ModTime()
+	}
+	n.dirty = false
+	n.conflict = false
+}
+
+func (n *NotePad) markDirty() {
+	n.saveMu.Lock()
+	n.dirty = true
+	n.saveMu.Unlock()
+}
+
+func (n *NotePad) isDirty() bool {
+	n.saveMu.Lock()
+	defer n.saveMu.Unlock()
+	return n.dirty
+}
+
+// syncRemoteChanges trae los cambios que otro equipo guardó en la carpeta compartida. Si
+// aquí también hay cambios sin guardar no se pisa ninguno: la versión del otro equipo queda
+// en 🕘 Versiones y se pregunta si combinar, guardar la propia o cargar la suya.
+func (n *NotePad) syncRemoteChanges() {
+	n.saveMu.Lock()
+	path, key := n.currentFile()
+	lastWrite, dirty := n.lastWrite, n.dirty
+	skip := n.current == "" || n.locked || n.conflict
+	n.saveMu.Unlock()
+
+	if skip {
+		return
+	}
+	_, changed := remoteChanged(path, lastWrite)
+	if !changed {
+		return
+	}
+	if !dirty {
+		fyne.Do(func() {
+			n.loadContent()
+			n.statusLabel.SetText("Estado: Nota actualizada con los cambios de otro equipo")
+		})
+		return
+	}
+
+	data, err := readNoteFile(path, key)
+	if err != nil {
+		log.Printf("Error leyendo los cambios de otro equipo: %v", err)
+		return
+	}
+	theirs := stripSaveHeader(string(data))
+	n.snapshotVersion(theirs, true)
+	n.saveMu.Lock()
+	n.conflict = true
+	n.saveMu.Unlock()
+	fyne.Do(func() {
+		n.showConflict(theirs)
+	})
+}
+
+// changeNotesDir pasa a usar las notas de otra carpeta
+func (n *NotePad) changeNotesDir(dir string, window fyne.Window) {
+	if err := checkNotesDir(dir); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	if err := saveNotesLocation(dir); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	n.saveContent()
+
+	n.saveMu.Lock()
+	notesDir = dir
+	n.key = nil
+	n.saveMu.Unlock()
+
+	n.keepVersions = loadVersionSettings().Conservar
+	n.archive = loadSnapshotSettings()
+	n.autoSave.set(loadSaveSettings())
+	n.daily = loadDailySettings().Activo
+	n.setLocked(false)
+	n.loadNotes()
+	n.loadContent()
+	n.selectNoteInList()
+	n.initEncryption(window)
+	n.rememberRecent()
+	n.statusLabel.SetText(fmt.Sprintf("Estado: Notas de %s", dir))
+}
+
+// showNotesLocation permite elegir la carpeta de las notas
+func (n *NotePad) showNotesLocation(window fyne.Window) {
+	current := notesDir
+	if abs, err := filepath.Abs(current); err == nil {
+		current = abs
+	}
+	label := widget.NewLabel(fmt.Sprintf("Carpeta actual: %s", current))
+	label.Wrapping = fyne.TextWrapWord
+	help := widget.NewLabel("Elige una carpeta compartida (por ejemplo una unidad de red) para que todo el equipo use las mismas notas. " +
+		"Los cambios que guarde otro equipo se cargan solos mientras no estés escribiendo.")
+	help.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	chooseButton := widget.NewButton("📁 Elegir carpeta...", func() {
+		d.Hide()
+		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if uri != nil {
+				n.changeNotesDir(uri.Path(), window)
+			}
+		}, window)
+	})
+	localButton := widget.NewButton("💻 Usar la carpeta local", func() {
+		d.Hide()
+		os.MkdirAll(defaultNotesDir, 0755)
+		n.changeNotesDir(defaultNotesDir, window)
+	})
+
+	d = dialog.NewCustom("📁 Ubicación de las notas", "Cerrar",
+		container.NewVBox(label, help, container.NewHBox(chooseButton, localButton)), window)
+	d.Resize(fyne.NewSize(520, 220))
+	d.Show()
+}
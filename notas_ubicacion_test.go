@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStripSaveHeader(t *testing.T) {
+	tests := []struct {
+		content, want string
+	}{
+		{"# Guardado: 2026-10-16 09:00:00\nLISTA\n0154", "LISTA\n0154"},
+		{"# Guardado: 2026-10-16 09:00:00", ""},
+		{"LISTA\n# Guardado: no es encabezado", "LISTA\n# Guardado: no es encabezado"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := stripSaveHeader(tt.content); got != tt.want {
+			t.Errorf("stripSaveHeader(%q) = %q, se esperaba %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestCheckNotesDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := checkNotesDir(dir); err != nil {
+		t.Errorf("una carpeta con permisos debería aceptarse: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("la prueba de escritura no debería dejar archivos: %v", entries)
+	}
+	if err := checkNotesDir(filepath.Join(dir, "no-existe")); err == nil {
+		t.Error("una carpeta inexistente debería rechazarse")
+	}
+	file := filepath.Join(dir, "nota.txt")
+	os.WriteFile(file, nil, 0644)
+	if err := checkNotesDir(file); err == nil {
+		t.Error("un archivo no debería aceptarse como carpeta")
+	}
+}
+
+func TestRemoteChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "General.txt")
+	if _, changed := remoteChanged(path, time.Time{}); changed {
+		t.Error("un archivo inexistente no cuenta como cambio")
+	}
+	os.WriteFile(path, []byte("nota"), 0644)
+	modified, changed := remoteChanged(path, time.Time{})
+	if !changed {
+		t.Error("un archivo más nuevo que la última escritura debería contar como cambio")
+	}
+	if _, changed := remoteChanged(path, modified); changed {
+		t.Error("el archivo ya leído no debería contar como cambio")
+	}
+	later := modified.Add(time.Minute)
+	os.Chtimes(path, later, later)
+	if _, changed := remoteChanged(path, modified); !changed {
+		t.Error("una escritura posterior debería contar como cambio")
+	}
+}
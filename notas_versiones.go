@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	versionsDir          = ".versiones"        // Dentro de la carpeta de notas, una subcarpeta por nota
+	versionsSettingsFile = "versiones.json"    // Cantidad de versiones a conservar
+	versionTimeLayout    = "2006-01-02_150405" // Nombre de cada versión
+	versionInterval      = time.Minute         // Como mucho una versión por minuto al guardar solo
+	defaultKeepVersions  = 50
+)
+
+// noteVersion es una copia de una nota tal como estaba en un momento dado
+type noteVersion struct {
+	Path  string
+	Fecha time.Time
+}
+
+// versionSettings se guarda en la carpeta de notas
+type versionSettings struct {
+	Conservar int
+}
+
+func noteVersionsDir(name string) string {
+	return filepath.Join(notesDir, versionsDir, name)
+}
+
+// listVersions devuelve las versiones de dir, la más reciente primero
+func listVersions(dir string) ([]noteVersion, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	var versions []noteVersion
+	for _, f := range files {
+		when, err := time.ParseInLocation(versionTimeLayout, strings.TrimSuffix(filepath.Base(f), ".txt"), time.Local)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, noteVersion{Path: f, Fecha: when})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Fecha.After(versions[j].Fecha)
+	})
+	return versions, nil
+}
+
+// writeVersion guarda content como versión de dir si cambió desde la última. Sin force
+// respeta versionInterval para no llenar la carpeta con el guardado automático. Con key
+// la versión se cifra igual que la nota.
+func writeVersion(dir, content string, when time.Time, force bool, key []byte) (bool, error) {
+	versions, err := listVersions(dir)
+	if err != nil {
+		return false, err
+	}
+	if len(versions) > 0 {
+		latest := versions[0]
+		if !force && when.Sub(latest.Fecha) < versionInterval {
+			return false, nil
+		}
+		if data, err := readNoteFile(latest.Path, key); err == nil && string(data) == content {
+			return false, nil
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, err
+	}
+	path := filepath.Join(dir, when.Format(versionTimeLayout)+".txt")
+	if err := writeNoteFile(path, []byte(content), key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// pruneVersions borra las versiones más antiguas hasta dejar keep
+func pruneVersions(dir string, keep int) error {
+	versions, err := listVersions(dir)
+	if err != nil || len(versions) <= keep {
+		return err
+	}
+	for _, v := range versions[keep:] {
+		if err := os.Remove(v.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tipos de línea de diffLines
+const (
+	diffSame    = ' '
+	diffAdded   = '+'
+	diffRemoved = '-'
+)
+
+type diffLine struct {
+	Tipo  rune
+	Texto string
+}
+
+// diffLines compara dos textos línea a línea (subsecuencia común más larga) y devuelve
+// los cambios para pasar de before a after
+func diffLines(before, after string) []diffLine {
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, diffLine{diffSame, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, diffLine{diffRemoved, a[i]})
+			i++
+		default:
+			diff = append(diff, diffLine{diffAdded, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, diffLine{diffRemoved, a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, diffLine{diffAdded, b[j]})
+	}
+	return diff
+}
+
+func loadVersionSettings() versionSettings {
+	settings := versionSettings{Conservar: defaultKeepVersions}
+	data, err := ioutil.ReadFile(filepath.Join(notesDir, versionsSettingsFile))
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil || settings.Conservar < 1 {
+		log.Printf("Configuración de versiones inválida, se usan %d: %v", defaultKeepVersions, err)
+		settings.Conservar = defaultKeepVersions
+	}
+	return settings
+}
+
+func saveVersionSettings(settings versionSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(notesDir, versionsSettingsFile), data, 0644)
+}
+
+// snapshotVersion guarda la nota abierta como versión y recorta las más antiguas
+func (n *NotePad) snapshotVersion(content string, force bool) {
+	if n.current == "" || n.external || content == "" {
+		return
+	}
+	dir := noteVersionsDir(n.current)
+	written, err := writeVersion(dir, content, time.Now(), force, n.key)
+	if err == nil && written {
+		err = pruneVersions(dir, n.keepVersions)
+	}
+	if err != nil {
+		log.Printf("Error guardando versión de %q: %v", n.current, err)
+	}
+}
+
+// diffView muestra los cambios de una versión respecto del editor: en rojo lo que la
+// versión tenía y ya no está, en verde lo que se agregó después
+func diffView(diff []diffLine) *widget.RichText {
+	segments := make([]widget.RichTextSegment, 0, len(diff))
+	for _, line := range diff {
+		style := widget.RichTextStyle{TextStyle: fyne.TextStyle{Monospace: true}}
+		switch line.Tipo {
+		case diffAdded:
+			style.ColorName = theme.ColorNameSuccess
+		case diffRemoved:
+			style.ColorName = theme.ColorNameError
+		}
+		segments = append(segments, &widget.TextSegment{Style: style, Text: string(line.Tipo) + " " + line.Texto})
+	}
+	return widget.NewRichText(segments...)
+}
+
+// showVersions abre el diálogo "Versiones anteriores" de la nota actual
+func (n *NotePad) showVersions(window fyne.Window) {
+	if n.external {
+		dialog.ShowInformation("🕘 Versiones", "Los archivos abiertos con 📂 Abrir no guardan versiones; solo las notas de la carpeta.", window)
+		return
+	}
+	versions, err := listVersions(noteVersionsDir(n.current))
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	selected := -1
+	diffScroll := container.NewScroll(widget.NewLabel("Elige una versión para ver qué cambió."))
+	list := widget.NewList(
+		func() int {
+			return len(versions)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(versions[id].Fecha.Format("02/01/2006 15:04:05"))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		data, err := readNoteFile(versions[id].Path, n.key)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		diffScroll.Content = diffView(diffLines(string(data), n.noteText()))
+		diffScroll.Refresh()
+	}
+
+	restoreButton := widget.NewButton("↩️ Restaurar", func() {
+		if selected < 0 {
+			return
+		}
+		v := versions[selected]
+		dialog.ShowConfirm("Restaurar versión",
+			fmt.Sprintf("¿Reemplazar la nota %q por la versión del %s? El contenido actual se guarda como versión.", n.current, v.Fecha.Format("02/01/2006 15:04:05")),
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				data, err := readNoteFile(v.Path, n.key)
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+				n.snapshotVersion(n.noteText(), true)
+				n.applyEdit(historyRestore, string(data))
+				n.saveContent()
+				n.statusLabel.SetText(fmt.Sprintf("Estado: Restaurada la versión del %s", v.Fecha.Format("02/01/2006 15:04:05")))
+			}, window)
+	})
+
+	keepInput := widget.NewEntry()
+	keepInput.SetText(strconv.Itoa(n.keepVersions))
+	keepButton := widget.NewButton("Aplicar", func() {
+		keep, err := strconv.Atoi(strings.TrimSpace(keepInput.Text))
+		if err != nil || keep < 1 {
+			dialog.ShowError(fmt.Errorf("la cantidad de versiones debe ser un número mayor a 0"), window)
+			return
+		}
+		n.keepVersions = keep
+		if err := saveVersionSettings(versionSettings{Conservar: keep}); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if err := pruneVersions(noteVersionsDir(n.current), keep); err != nil {
+			dialog.ShowError(err, window)
+		}
+	})
+
+	listScroll := container.NewScroll(list)
+	listScroll.SetMinSize(fyne.NewSize(170, 300))
+
+	content := container.NewBorder(
+		nil,
+		container.NewBorder(nil, nil, widget.NewLabel("Conservar las últimas"), container.NewHBox(widget.NewLabel("versiones"), keepButton), keepInput),
+		container.NewBorder(nil, restoreButton, nil, nil, listScroll),
+		nil,
+		diffScroll,
+	)
+	if len(versions) == 0 {
+		diffScroll.Content = widget.NewLabel("Todavía no hay versiones de esta nota. Se guarda una cada vez que cambia (como mucho una por minuto).")
+	}
+
+	d := dialog.NewCustom(fmt.Sprintf("🕘 Versiones anteriores de %q", n.current), "Cerrar", content, window)
+	d.Resize(fyne.NewSize(760, 480))
+	d.Show()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteVersion(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "General")
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+
+	if written, err := writeVersion(dir, "uno", start, false, nil); err != nil || !written {
+		t.Fatalf("la primera versión debería guardarse: %v, %v", written, err)
+	}
+	if written, _ := writeVersion(dir, "dos", start.Add(30*time.Second), false, nil); written {
+		t.Error("sin force no debería guardarse otra versión antes de versionInterval")
+	}
+	if written, _ := writeVersion(dir, "uno", start.Add(2*time.Minute), false, nil); written {
+		t.Error("un contenido igual al de la última versión no debería guardarse")
+	}
+	if written, _ := writeVersion(dir, "dos", start.Add(2*time.Minute), false, nil); !written {
+		t.Error("un contenido distinto pasado el intervalo debería guardarse")
+	}
+	if written, _ := writeVersion(dir, "tres", start.Add(2*time.Minute+time.Second), true, nil); !written {
+		t.Error("con force debería guardarse aunque no haya pasado el intervalo")
+	}
+
+	versions, err := listVersions(dir)
+	if err != nil || len(versions) != 3 {
+		t.Fatalf("listVersions = %v, %v", versions, err)
+	}
+	if !versions[0].Fecha.Equal(start.Add(2*time.Minute + time.Second)) {
+		t.Errorf("la primera versión debería ser la más reciente: %v", versions[0].Fecha)
+	}
+	if data, _ := os.ReadFile(versions[0].Path); string(data) != "tres" {
+		t.Errorf("contenido de la última versión = %q", data)
+	}
+}
+
+func TestPruneVersions(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	for i := 0; i < 5; i++ {
+		if _, err := writeVersion(dir, string(rune('a'+i)), start.Add(time.Duration(i)*time.Hour), false, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "notas.txt"), nil, 0644)
+
+	if err := pruneVersions(dir, 2); err != nil {
+		t.Fatal(err)
+	}
+	versions, _ := listVersions(dir)
+	if len(versions) != 2 || !versions[1].Fecha.Equal(start.Add(3*time.Hour)) {
+		t.Errorf("deberían quedar las 2 más recientes: %v", versions)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "notas.txt")); err != nil {
+		t.Error("los archivos que no son versiones no deberían borrarse")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	before := "LISTA\n0154 LGARCIA\n0083 JVILCATOMA\nfin"
+	after := "LISTA\n0154 LGARCIA\n0017 NCRISOSTOMO\nfin\nextra"
+
+	want := []diffLine{
+		{diffSame, "LISTA"},
+		{diffSame, "0154 LGARCIA"},
+		{diffRemoved, "0083 JVILCATOMA"},
+		{diffAdded, "0017 NCRISOSTOMO"},
+		{diffSame, "fin"},
+		{diffAdded, "extra"},
+	}
+	if got := diffLines(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines = %v", got)
+	}
+	for _, line := range diffLines(before, before) {
+		if line.Tipo != diffSame {
+			t.Errorf("dos textos iguales no deberían tener cambios: %v", line)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Títulos como "*****LISTA REPOSICIÓN*****" y separadores como "**********"
+var (
+	starTitleRegex = regexp.MustCompile(`^\*{3,}\s*(.*?)\s*\*{3,}$`)
+	separatorRegex = regexp.MustCompile(`^[*=_-]{3,}$`)
+)
+
+// previewMarkdown adapta el texto de una nota para mostrarlo como markdown: los títulos
+// entre asteriscos pasan a encabezados, los separadores a líneas y cada línea queda en
+// su propio párrafo para que los reportes no se junten en uno solo
+func previewMarkdown(text string) string {
+	var blocks []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case separatorRegex.MatchString(trimmed):
+			blocks = append(blocks, "---")
+		case starTitleRegex.MatchString(trimmed):
+			blocks = append(blocks, "## "+starTitleRegex.FindStringSubmatch(trimmed)[1])
+		default:
+			blocks = append(blocks, line)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// updatePreview vuelve a dibujar la vista previa si está abierta
+func (n *NotePad) updatePreview() {
+	if n.preview == nil || !n.previewBox.Visible() {
+		return
+	}
+	if n.highlight {
+		n.preview.Segments = highlightSegments(n.multiLine.Text, n.spellChecker())
+		n.preview.Refresh()
+		return
+	}
+	n.preview.ParseMarkdown(previewMarkdown(n.multiLine.Text))
+}
+
+// createPreviewPane arma el panel de vista previa (oculto) y la casilla que lo muestra.
+// Con "Resaltar" se ven las líneas tal cual, con horas, códigos y usuarios en colores.
+func (n *NotePad) createPreviewPane() (fyne.CanvasObject, *widget.Check) {
+	n.preview = widget.NewRichText()
+	n.preview.Wrapping = fyne.TextWrapWord
+	previewScroll := container.NewScroll(n.preview)
+	previewScroll.SetMinSize(fyne.NewSize(350, 300))
+
+	n.highlight = true
+	highlightCheck := widget.NewCheck("🎨 Resaltar horas, códigos, usuarios y ortografía", func(on bool) {
+		n.highlight = on
+		n.updatePreview()
+	})
+	highlightCheck.SetChecked(true)
+
+	n.previewBox = container.NewBorder(highlightCheck, nil, nil, nil, previewScroll)
+	n.previewBox.Hide()
+
+	toggle := widget.NewCheck("👁️ Vista previa", func(on bool) {
+		if on {
+			n.previewBox.Show()
+			n.updatePreview()
+		} else {
+			n.previewBox.Hide()
+		}
+	})
+	return n.previewBox, toggle
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestPreviewMarkdown(t *testing.T) {
+	text := "***********LISTA REPOSICIÓN*********\n" +
+		"......9999 REPOSICION 15:04 JRIOS\n" +
+		"......9999 REPOSICION 15:04 BTAIPE   \n" +
+		"\n" +
+		"**********\n" +
+		"# Notas del día\n" +
+		"- pendiente"
+
+	want := "## LISTA REPOSICIÓN\n\n" +
+		"......9999 REPOSICION 15:04 JRIOS\n\n" +
+		"......9999 REPOSICION 15:04 BTAIPE\n\n" +
+		"---\n\n" +
+		"# Notas del día\n\n" +
+		"- pendiente"
+	if got := previewMarkdown(text); got != want {
+		t.Errorf("previewMarkdown =\n%s\nse esperaba\n%s", got, want)
+	}
+	if got := previewMarkdown("\n\n"); got != "" {
+		t.Errorf("un texto vacío debería quedar vacío: %q", got)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !ocr
+
+package main
+
+import "fmt"
+
+// ocrAvailable indica si el binario se compiló con soporte OCR (-tags ocr)
+const ocrAvailable = false
+
+func recognizeText(path string) (string, error) {
+	return "", fmt.Errorf("OCR no disponible: compila con -tags ocr y Tesseract instalado")
+}
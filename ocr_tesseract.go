@@ -0,0 +1,17 @@
+//go:build ocr
+
+package main
+
+import "github.com/otiai10/gosseract"
+
+// ocrAvailable indica si el binario se compiló con soporte OCR (-tags ocr)
+const ocrAvailable = true
+
+// recognizeText reconoce el texto de una imagen con Tesseract
+func recognizeText(path string) (string, error) {
+	client := gosseract.NewClient()
+	defer client.Close()
+
+	client.SetImage(path)
+	return client.Text()
+}
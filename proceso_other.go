@@ -0,0 +1,55 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+// hideConsoleWindow no hace nada fuera de Windows
+func hideConsoleWindow(cmd *exec.Cmd) {}
+
+// screenLocked indica si la sesión está bloqueada. En Linux se consulta a systemd-logind;
+// en macOS no se detecta.
+func screenLocked() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+
+	session := os.Getenv("XDG_SESSION_ID")
+	if session == "" {
+		session = "auto"
+	}
+	out, err := exec.Command("loginctl", "show-session", session, "-p", "LockedHint", "--value").Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "yes"
+}
+
+// setAlwaysOnTop deja la ventana por encima de las demás aplicaciones. En X11 se pide al
+// gestor de ventanas con wmctrl, si está instalado; en macOS no está disponible.
+func setAlwaysOnTop(w fyne.Window) {
+	native, ok := w.(driver.NativeWindow)
+	if !ok {
+		return
+	}
+	native.RunNative(func(context any) {
+		ctx, ok := context.(driver.X11WindowContext)
+		if !ok || ctx.WindowHandle == 0 {
+			return
+		}
+		id := fmt.Sprintf("0x%x", ctx.WindowHandle)
+		if err := exec.Command("wmctrl", "-i", "-r", id, "-b", "add,above").Start(); err != nil {
+			log.Printf("No se pudo dejar la ventana encima: %v", err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+var (
+	user32                   = syscall.NewLazyDLL("user32.dll")
+	procOpenInputDesktop     = user32.NewProc("OpenInputDesktop")
+	procCloseDesktop         = user32.NewProc("CloseDesktop")
+	procSystemParametersInfo = user32.NewProc("SystemParametersInfoW")
+	procSetWindowPos         = user32.NewProc("SetWindowPos")
+)
+
+const (
+	desktopSwitchDesktop     = 0x0100
+	spiGetScreenSaverRunning = 0x0072
+
+	// SetWindowPos: siempre encima, sin mover, redimensionar ni activar
+	hwndTopmost    = ^uintptr(0) // HWND_TOPMOST (-1)
+	swpTopmostOnly = 0x0001 | 0x0002 | 0x0010
+)
+
+// hideConsoleWindow evita que los comandos auxiliares abran una consola visible
+func hideConsoleWindow(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+}
+
+// screenLocked indica si la sesión está bloqueada o con el protector de pantalla activo.
+// Con la sesión bloqueada el escritorio de entrada no se puede abrir.
+func screenLocked() bool {
+	desktop, _, _ := procOpenInputDesktop.Call(0, 0, desktopSwitchDesktop)
+	if desktop == 0 {
+		return true
+	}
+	procCloseDesktop.Call(desktop)
+
+	var running int32
+	procSystemParametersInfo.Call(spiGetScreenSaverRunning, 0, uintptr(unsafe.Pointer(&running)), 0)
+	return running != 0
+}
+
+// setAlwaysOnTop deja la ventana por encima de las demás aplicaciones
+func setAlwaysOnTop(w fyne.Window) {
+	native, ok := w.(driver.NativeWindow)
+	if !ok {
+		return
+	}
+	native.RunNative(func(context any) {
+		if ctx, ok := context.(driver.WindowsWindowContext); ok && ctx.HWND != 0 {
+			procSetWindowPos.Call(ctx.HWND, hwndTopmost, 0, 0, 0, 0, swpTopmostOnly)
+		}
+	})
+}
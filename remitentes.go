@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// mainRemitente es el remitente con los datos de la propia empresa, que siempre existe
+const mainRemitente = "Principal"
+
+// Remitente es otra sucursal o contacto que envía rótulos a nombre de una empresa
+type Remitente struct {
+	Sucursal  string // Cómo aparece en la lista, por ejemplo "Lima" o "Almacén"
+	Nombre    string
+	Direccion string
+	Telefono  string
+}
+
+// remitentes devuelve los remitentes de e empezando por el principal
+func (e Empresa) remitentes() []Remitente {
+	return append([]Remitente{{Sucursal: mainRemitente, Nombre: e.Nombre, Direccion: e.Direccion, Telefono: e.Telefono}}, e.Remitentes...)
+}
+
+func remitenteNames(e Empresa) []string {
+	var names []string
+	for _, rem := range e.remitentes() {
+		names = append(names, rem.Sucursal)
+	}
+	return names
+}
+
+func findRemitente(e Empresa, sucursal string) (Remitente, bool) {
+	for _, rem := range e.remitentes() {
+		if strings.EqualFold(rem.Sucursal, sucursal) {
+			return rem, true
+		}
+	}
+	return Remitente{}, false
+}
+
+func validateRemitente(rem Remitente) error {
+	if strings.TrimSpace(rem.Sucursal) == "" {
+		return fmt.Errorf("el remitente necesita el nombre de la sucursal")
+	}
+	if strings.EqualFold(rem.Sucursal, mainRemitente) {
+		return fmt.Errorf("%s son los datos de la empresa; cámbialos en 🏢 Empresas", mainRemitente)
+	}
+	if strings.TrimSpace(rem.Nombre) == "" {
+		return fmt.Errorf("el remitente de %s no tiene nombre", rem.Sucursal)
+	}
+	return nil
+}
+
+// upsertRemitente agrega rem a la empresa o reemplaza la sucursal con el mismo nombre
+func upsertRemitente(e Empresa, rem Remitente) (Empresa, error) {
+	if err := validateRemitente(rem); err != nil {
+		return e, err
+	}
+	list := append([]Remitente{}, e.Remitentes...)
+	for i, old := range list {
+		if strings.EqualFold(old.Sucursal, rem.Sucursal) {
+			list[i] = rem
+			e.Remitentes = list
+			return e, nil
+		}
+	}
+	e.Remitentes = append(list, rem)
+	return e, nil
+}
+
+func deleteRemitente(e Empresa, sucursal string) Empresa {
+	var list []Remitente
+	for _, rem := range e.Remitentes {
+		if !strings.EqualFold(rem.Sucursal, sucursal) {
+			list = append(list, rem)
+		}
+	}
+	e.Remitentes = list
+	return e
+}
+
+// refreshRemitentes llena la lista de sucursales de la empresa elegida y pone la principal
+func (r *RotuloGenerator) refreshRemitentes() {
+	r.sucursal.Options = remitenteNames(empresasData[r.data.Empresa])
+	r.sucursal.SetSelected(mainRemitente)
+}
+
+// applyRemitente llena los datos del remitente con la sucursal elegida
+func (r *RotuloGenerator) applyRemitente(sucursal string) {
+	rem, ok := findRemitente(empresasData[r.data.Empresa], sucursal)
+	if !ok {
+		return
+	}
+	r.inputs["remitenteNombre"].SetText(rem.Nombre)
+	r.inputs["remitenteDireccion"].SetText(rem.Direccion)
+	r.inputs["remitenteTelefono"].SetText(rem.Telefono)
+}
+
+// saveRemitente guarda los datos escritos del remitente como una sucursal de la empresa
+func (r *RotuloGenerator) saveRemitente(window fyne.Window) {
+	sucursalInput := widget.NewEntry()
+	sucursalInput.SetPlaceHolder("Lima, Almacén, Ventas...")
+	if r.sucursal.Selected != mainRemitente {
+		sucursalInput.SetText(r.sucursal.Selected)
+	}
+	items := []*widget.FormItem{widget.NewFormItem("Sucursal", sucursalInput)}
+	dialog.ShowForm("💾 Guardar remitente de "+r.data.Empresa, "Guardar", "Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		rem := Remitente{
+			Sucursal:  strings.TrimSpace(sucursalInput.Text),
+			Nombre:    strings.TrimSpace(r.data.RemitenteNombre),
+			Direccion: strings.TrimSpace(r.data.RemitenteDireccion),
+			Telefono:  strings.TrimSpace(r.data.RemitenteTelefono),
+		}
+		e, err := upsertRemitente(empresasData[r.data.Empresa], rem)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		old := empresasData[r.data.Empresa]
+		empresasData[r.data.Empresa] = e
+		if err := saveEmpresas(empresasData); err != nil {
+			empresasData[r.data.Empresa] = old
+			dialog.ShowError(err, window)
+			return
+		}
+		r.sucursal.Options = remitenteNames(e)
+		r.sucursal.SetSelected(rem.Sucursal)
+	}, window)
+}
+
+// deleteSelectedRemitente quita la sucursal elegida; la principal no se puede quitar
+func (r *RotuloGenerator) deleteSelectedRemitente(window fyne.Window) {
+	sucursal := r.sucursal.Selected
+	if sucursal == "" || sucursal == mainRemitente {
+		dialog.ShowInformation("Eliminar remitente", "Elige una sucursal; los datos principales se cambian en 🏢 Empresas.", window)
+		return
+	}
+	dialog.ShowConfirm("Eliminar remitente", fmt.Sprintf("¿Eliminar el remitente %s de %s?", sucursal, r.data.Empresa), func(ok bool) {
+		if !ok {
+			return
+		}
+		old := empresasData[r.data.Empresa]
+		empresasData[r.data.Empresa] = deleteRemitente(old, sucursal)
+		if err := saveEmpresas(empresasData); err != nil {
+			empresasData[r.data.Empresa] = old
+			dialog.ShowError(err, window)
+			return
+		}
+		r.refreshRemitentes()
+	}, window)
+}
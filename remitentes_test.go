@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRemitentes(t *testing.T) {
+	e := Empresa{Nombre: "ACME S.A.C", Direccion: "Av. Giraldez 242", Telefono: "064 123 456"}
+	if got := remitenteNames(e); !reflect.DeepEqual(got, []string{mainRemitente}) {
+		t.Errorf("sin sucursales = %q", got)
+	}
+
+	lima := Remitente{Sucursal: "Lima", Nombre: "ACME Lima", Direccion: "Av. Arequipa 123", Telefono: "01 555 444"}
+	e, err := upsertRemitente(e, lima)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ = upsertRemitente(e, Remitente{Sucursal: "Almacén", Nombre: "Juan Pérez"})
+	if got := remitenteNames(e); !reflect.DeepEqual(got, []string{mainRemitente, "Lima", "Almacén"}) {
+		t.Errorf("con sucursales = %q", got)
+	}
+	if rem, ok := findRemitente(e, mainRemitente); !ok || rem.Nombre != "ACME S.A.C" || rem.Direccion != "Av. Giraldez 242" {
+		t.Errorf("principal = %v", rem)
+	}
+
+	// Guardar otra vez la misma sucursal reemplaza sus datos sin tocar la empresa original
+	before := e
+	moved, _ := upsertRemitente(e, Remitente{Sucursal: "LIMA", Nombre: "ACME Lima", Direccion: "Av. Brasil 900"})
+	if rem, _ := findRemitente(moved, "lima"); len(moved.Remitentes) != 2 || rem.Direccion != "Av. Brasil 900" {
+		t.Errorf("reemplazo = %v", moved.Remitentes)
+	}
+	if rem, _ := findRemitente(before, "Lima"); rem != lima {
+		t.Errorf("upsertRemitente cambió la empresa original: %v", rem)
+	}
+
+	for _, bad := range []Remitente{{Nombre: "Sin sucursal"}, {Sucursal: "principal", Nombre: "X"}, {Sucursal: "Cusco"}} {
+		if _, err := upsertRemitente(e, bad); err == nil {
+			t.Errorf("se aceptó %v", bad)
+		}
+	}
+
+	e = deleteRemitente(e, "lima")
+	if got := remitenteNames(e); !reflect.DeepEqual(got, []string{mainRemitente, "Almacén"}) {
+		t.Errorf("tras eliminar = %q", got)
+	}
+	e.Remitentes = append(e.Remitentes, Remitente{Sucursal: "almacén", Nombre: "Otro"})
+	if validateEmpresa("ACME", e) == nil {
+		t.Error("se aceptó una sucursal repetida")
+	}
+}
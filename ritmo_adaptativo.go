@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"log"
+	"time"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// Formas de esperar a la aplicación destino después de cada tecla o clic
+const (
+	pacingFixed  = "Pausas fijas"
+	pacingTitle  = "Esperar cambio de título"
+	pacingRegion = "Esperar cambio en región"
+)
+
+var pacingOptions = []string{pacingFixed, pacingTitle, pacingRegion}
+
+const (
+	// Tiempo máximo que se espera a que la pantalla responda antes de seguir igual
+	pacingTimeout      = 3 * time.Second
+	pacingPollInterval = 50 * time.Millisecond
+)
+
+// adaptivePacing indica si el trabajo espera a la aplicación en lugar de usar pausas fijas
+func (job *AutocopyJob) adaptivePacing() bool {
+	return job.Ritmo == pacingTitle || job.Ritmo == pacingRegion
+}
+
+// imageSignature resume los pixeles de una captura para detectar cambios
+func imageSignature(img image.Image) string {
+	h := fnv.New64a()
+	bounds := img.Bounds()
+	buf := make([]byte, 0, 8)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			buf = append(buf[:0], byte(r>>8), byte(g>>8), byte(b>>8))
+			h.Write(buf)
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// pacingSnapshot devuelve la huella de lo que se observa: el título de la ventana activa
+// o el contenido de la región
+func pacingSnapshot(job *AutocopyJob) string {
+	if job.Ritmo == pacingTitle {
+		return robotgo.GetTitle()
+	}
+
+	r := job.RegionRitmo
+	img, err := robotgo.CaptureImg(r.X, r.Y, r.Ancho, r.Alto)
+	if err != nil {
+		log.Printf("Error capturando la región de ritmo %s: %v", r, err)
+		return ""
+	}
+	return imageSignature(img)
+}
+
+// waitForResponse espera a que la huella cambie respecto a before. Si se agota el tiempo
+// sigue igual (la aplicación pudo no cambiar nada visible); devuelve false si se cancela.
+func waitForResponse(job *AutocopyJob, before string) bool {
+	deadline := time.Now().Add(pacingTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-cancel:
+			return false
+		default:
+		}
+
+		if pacingSnapshot(job) != before {
+			return true
+		}
+		time.Sleep(pacingPollInterval)
+	}
+	log.Printf("La aplicación destino no cambió en %s, se continúa", pacingTimeout)
+	return true
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestImageSignature(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	same := image.NewRGBA(image.Rect(10, 10, 14, 13))
+	base := imageSignature(img)
+
+	if got := imageSignature(same); got != base {
+		t.Errorf("imágenes iguales con distinto origen: %s != %s", got, base)
+	}
+
+	img.Set(2, 1, color.RGBA{R: 255, A: 255})
+	if got := imageSignature(img); got == base {
+		t.Error("la huella no cambió al modificar un pixel")
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"github.com/jung-kurt/gofpdf"
+)
+
+// sheetLayout reparte varios rótulos en una hoja A4 vertical. Scale es la escala del diseño
+// en cada recuadro, como la de A5 para los de media hoja.
+type sheetLayout struct {
+	Name       string
+	Cols, Rows int
+	Scale      float64
+}
+
+// sheetLayouts son las distribuciones que se pueden elegir; la primera es un rótulo por hoja
+// con el tamaño y la orientación del formulario
+var sheetLayouts = []sheetLayout{
+	{Name: "1 por hoja", Cols: 1, Rows: 1},
+	{Name: "2 por A4 (2x1)", Cols: 1, Rows: 2, Scale: 0.7},
+	{Name: "4 por A4 (2x2)", Cols: 2, Rows: 2, Scale: 0.5},
+}
+
+func sheetLayoutNames() []string {
+	names := make([]string, len(sheetLayouts))
+	for i, l := range sheetLayouts {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// findSheetLayout devuelve la distribución llamada name, o un rótulo por hoja si no existe
+func findSheetLayout(name string) sheetLayout {
+	for _, l := range sheetLayouts {
+		if l.Name == name {
+			return l
+		}
+	}
+	return sheetLayouts[0]
+}
+
+func (l sheetLayout) perSheet() int {
+	return l.Cols * l.Rows
+}
+
+// labelCell es el recuadro de un rótulo en la hoja, en mm
+type labelCell struct {
+	X, Y, W, H float64
+}
+
+// cells divide una hoja de width x height en los recuadros de l, por filas de izquierda a
+// derecha
+func (l sheetLayout) cells(width, height float64) []labelCell {
+	w, h := width/float64(l.Cols), height/float64(l.Rows)
+	var cells []labelCell
+	for row := 0; row < l.Rows; row++ {
+		for col := 0; col < l.Cols; col++ {
+			cells = append(cells, labelCell{X: float64(col) * w, Y: float64(row) * h, W: w, H: h})
+		}
+	}
+	return cells
+}
+
+// drawCutMarks dibuja con línea discontinua los bordes entre los recuadros, por donde se
+// corta la hoja
+func (l sheetLayout) drawCutMarks(pdf *gofpdf.Fpdf, width, height float64) {
+	pdf.SetDrawColor(150, 150, 150)
+	pdf.SetLineWidth(0.2)
+	pdf.SetDashPattern([]float64{3, 2}, 0)
+	for col := 1; col < l.Cols; col++ {
+		x := width * float64(col) / float64(l.Cols)
+		pdf.Line(x, 0, x, height)
+	}
+	for row := 1; row < l.Rows; row++ {
+		y := height * float64(row) / float64(l.Rows)
+		pdf.Line(0, y, width, y)
+	}
+	pdf.SetDashPattern([]float64{}, 0)
+	pdf.SetDrawColor(0, 0, 0)
+}
+
+// drawLabelSheets pone los rótulos de labels en hojas A4 según l, empezando una hoja nueva
+// cuando se llena la anterior. Cada rótulo se dibuja en su recuadro como si fuera su página.
+func drawLabelSheets(pdf *gofpdf.Fpdf, fontFamily string, l sheetLayout, labels []RotuloData) error {
+	width, height := paperSizes["A4"].Width, paperSizes["A4"].Height
+	cells := l.cells(width, height)
+	for i := range labels {
+		if i%len(cells) == 0 {
+			pdf.AddPage()
+			l.drawCutMarks(pdf, width, height)
+		}
+		cell := cells[i%len(cells)]
+		pdf.TransformBegin()
+		pdf.TransformTranslate(cell.X, cell.Y)
+		err := drawLabelPage(pdf, fontFamily, cell.W, cell.H, l.Scale, &labels[i])
+		pdf.TransformEnd()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSheetLayoutCells(t *testing.T) {
+	got := findSheetLayout("4 por A4 (2x2)").cells(210, 297)
+	want := []labelCell{{0, 0, 105, 148.5}, {105, 0, 105, 148.5}, {0, 148.5, 105, 148.5}, {105, 148.5, 105, 148.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("2x2 = %v", got)
+	}
+	got = findSheetLayout("2 por A4 (2x1)").cells(210, 297)
+	want = []labelCell{{0, 0, 210, 148.5}, {0, 148.5, 210, 148.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("2x1 = %v", got)
+	}
+	if l := findSheetLayout(""); l.perSheet() != 1 {
+		t.Errorf("sin distribución = %v", l)
+	}
+}
+
+func TestCreateLabelsPDFPerSheet(t *testing.T) {
+	base := RotuloData{Empresa: "COMSITEC", RemitenteNombre: "COMSITEC S.A.C", TamanoHoja: "A5", Orientacion: "Horizontal",
+		Distribucion: "4 por A4 (2x2)", FechaEnvio: time.Now()}
+	rows := make([]RotuloData, 5)
+	for i := range rows {
+		rows[i].DestinatarioNombre = "Destinatario"
+	}
+	// Cinco rótulos de cuatro por hoja ocupan dos hojas A4 verticales
+	data, err := createLabelsPDF(batchLabels(rows, base, time.Now(), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages := bytes.Count(data, []byte("/Type /Page\n")); pages != 2 {
+		t.Errorf("5 rótulos de 4 por hoja ocupan %d páginas", pages)
+	}
+	if !bytes.Contains(data, []byte("/MediaBox [0 0 595.28 841.89]")) {
+		t.Error("varios por hoja deberían ir en A4 vertical")
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Separadores de columnas de un registro pegado desde Excel (tabulador) o CSV
+const recordSeparators = "\t;,"
+
+// SerieRecord es un registro a teclear: la serie y las fechas de sus otras columnas
+type SerieRecord struct {
+	Serie  string
+	Fechas []string      // Ya normalizadas a DDMMAAAA
+	Espera time.Duration // Pausa propia del registro (cero = la de la velocidad)
+}
+
+// Columna de pausa de un registro: "800ms", "2s" o "1.5s"
+var entryDelayRegex = regexp.MustCompile(`^\d+(\.\d+)?(ms|s)$`)
+
+// Pausa máxima por registro, para que un error de tipeo no congele el proceso
+const maxEntryDelay = time.Minute
+
+// Máximo de series que genera un rango de una vez
+const maxRangeCount = 5000
+
+// seriesRange genera count series consecutivas desde start con el prefijo y los ceros
+// a la izquierda indicados: seriesRange("ZET", 100, 3, 5) = ZET00100, ZET00101, ZET00102
+func seriesRange(prefix string, start, count, padding int) ([]string, error) {
+	if start < 0 {
+		return nil, fmt.Errorf("el número inicial no puede ser negativo")
+	}
+	if count <= 0 || count > maxRangeCount {
+		return nil, fmt.Errorf("la cantidad debe estar entre 1 y %d", maxRangeCount)
+	}
+	if padding < 0 || padding > 18 {
+		return nil, fmt.Errorf("los dígitos deben estar entre 0 y 18")
+	}
+
+	out := make([]string, count)
+	for i := range out {
+		out[i] = fmt.Sprintf("%s%0*d", prefix, padding, start+i)
+	}
+	return out, nil
+}
+
+// splitRecords separa el texto en registros. Una línea con columnas es un registro;
+// las demás se separan por espacios como una lista de series sueltas.
+func splitRecords(text string) []string {
+	var records []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.ContainsAny(line, recordSeparators) {
+			records = append(records, line)
+			continue
+		}
+		records = append(records, strings.Fields(line)...)
+	}
+	return records
+}
+
+// parseRecord separa la serie de sus columnas de fecha y de la pausa opcional
+func parseRecord(record string) (SerieRecord, error) {
+	var columns []string
+	for _, col := range strings.FieldsFunc(record, func(r rune) bool {
+		return strings.ContainsRune(recordSeparators, r)
+	}) {
+		if col = strings.TrimSpace(col); col != "" {
+			columns = append(columns, col)
+		}
+	}
+	if len(columns) == 0 {
+		return SerieRecord{}, fmt.Errorf("registro sin serie: %q", record)
+	}
+
+	rec := SerieRecord{Serie: columns[0]}
+	for _, col := range columns[1:] {
+		if entryDelayRegex.MatchString(strings.ToLower(col)) {
+			if rec.Espera != 0 {
+				return SerieRecord{}, fmt.Errorf("serie %s: tiene más de una pausa", rec.Serie)
+			}
+			delay, err := time.ParseDuration(strings.ToLower(col))
+			if err != nil || delay <= 0 || delay > maxEntryDelay {
+				return SerieRecord{}, fmt.Errorf("serie %s: pausa inválida %q (entre 1ms y %s)", rec.Serie, col, maxEntryDelay)
+			}
+			rec.Espera = delay
+			continue
+		}
+
+		date, err := normalizeDate(col)
+		if err != nil {
+			return SerieRecord{}, fmt.Errorf("serie %s: %v", rec.Serie, err)
+		}
+		rec.Fechas = append(rec.Fechas, date)
+	}
+	return rec, nil
+}
+
+// parseRecords interpreta los registros y comprueba que cada uno tenga las fechas que
+// usa la secuencia; {fecha} puede salir de la fecha general si el registro no trae una.
+func parseRecords(records []string, dateColumns int, hasDefaultDate bool) ([]SerieRecord, error) {
+	out := make([]SerieRecord, 0, len(records))
+	for _, r := range records {
+		rec, err := parseRecord(r)
+		if err != nil {
+			return nil, err
+		}
+		available := len(rec.Fechas)
+		if available == 0 && hasDefaultDate {
+			available = 1
+		}
+		if available < dateColumns {
+			if dateColumns == 1 {
+				return nil, fmt.Errorf("debes ingresar una fecha (la serie %s no trae la suya)", rec.Serie)
+			}
+			return nil, fmt.Errorf("la serie %s tiene %d fecha(s) pero la secuencia usa {fecha%d}", rec.Serie, len(rec.Fechas), dateColumns)
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+// Opciones de orden de la lista de series
+const (
+	orderOriginal   = "Mantener orden original"
+	orderAscending  = "Ascendente"
+	orderDescending = "Descendente"
+	orderReverse    = "Invertir orden"
+)
+
+var seriesOrderOptions = []string{orderOriginal, orderAscending, orderDescending, orderReverse}
+
+// orderSeries devuelve una copia de las series en el orden pedido
+func orderSeries(series []string, order string) []string {
+	out := append([]string(nil), series...)
+
+	switch order {
+	case orderAscending:
+		sort.SliceStable(out, func(i, j int) bool {
+			return compareSeries(out[i], out[j]) < 0
+		})
+	case orderDescending:
+		sort.SliceStable(out, func(i, j int) bool {
+			return compareSeries(out[i], out[j]) > 0
+		})
+	case orderReverse:
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+	return out
+}
+
+// records devuelve los registros del trabajo en el orden en que se escribirán
+func (job *AutocopyJob) records() ([]SerieRecord, error) {
+	steps, err := parseMacro(job.Macro)
+	if err != nil {
+		return nil, fmt.Errorf("secuencia inválida: %v", err)
+	}
+	terminator, err := parseTerminator(job.Terminador, job.TerminadorPersonalizado)
+	if err != nil {
+		return nil, err
+	}
+	series := job.seriesList()
+	if len(series) == 0 {
+		return nil, fmt.Errorf("todas las series quedaron fuera por los filtros")
+	}
+	return parseRecords(series, macroDateColumns(append(steps, terminator...)), job.Fecha != "")
+}
+
+// seriesList devuelve los registros en bruto, filtrados y en el orden en que se escribirán
+func (job *AutocopyJob) seriesList() []string {
+	return orderSeries(filterSeries(splitRecords(job.Series), job.Incluir, job.Excluir), job.Orden)
+}
+
+// appendSeries agrega series al final de la lista sin perder lo que ya había
+func (a *Autocopiador) appendSeries(series []string) {
+	current := a.seriesInput.Text
+	if current != "" && !strings.HasSuffix(current, "\n") {
+		current += "\n"
+	}
+	a.seriesInput.SetText(current + strings.Join(series, "\n"))
+}
+
+// iterations devuelve cuántas veces se escribe la lista completa
+func (job *AutocopyJob) iterations() int {
+	if job.Repeticiones < 1 {
+		return 1
+	}
+	return job.Repeticiones
+}
+
+// repeatList devuelve la lista repetida n veces seguidas
+func repeatList[T any](items []T, n int) []T {
+	out := make([]T, 0, len(items)*n)
+	for i := 0; i < n; i++ {
+		out = append(out, items...)
+	}
+	return out
+}
+
+// progressText describe el avance; con repeticiones separa la iteración de la serie
+func progressText(copied, total, iterations int) string {
+	if iterations <= 1 || total == 0 {
+		return fmt.Sprintf("Copiadas: %d / %d", copied, total)
+	}
+	perIteration := total / iterations
+	iteration, entry := 1, 0
+	if copied > 0 {
+		iteration = (copied-1)/perIteration + 1
+		entry = (copied-1)%perIteration + 1
+	}
+	return fmt.Sprintf("Iteración %d / %d · Serie %d / %d · Total %d / %d",
+		iteration, iterations, entry, perIteration, copied, total)
+}
+
+// recordWarnings marca las series que parecen mal pegadas: con caracteres que no son
+// letras, números, guion o guion bajo, o con un largo distinto al de la mayoría
+func recordWarnings(records []SerieRecord) []string {
+	lengths := map[int]int{}
+	for _, rec := range records {
+		lengths[utf8.RuneCountInString(rec.Serie)]++
+	}
+	common, best := 0, 0
+	for length, n := range lengths {
+		if n > best || (n == best && length < common) {
+			common, best = length, n
+		}
+	}
+
+	warnings := make([]string, len(records))
+	for i, rec := range records {
+		switch {
+		case strings.IndexFunc(rec.Serie, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+		}) >= 0:
+			warnings[i] = "Caracteres extraños"
+		case len(lengths) > 1 && utf8.RuneCountInString(rec.Serie) != common:
+			warnings[i] = fmt.Sprintf("Largo distinto (%d)", utf8.RuneCountInString(rec.Serie))
+		}
+	}
+	return warnings
+}
+
+// compareSeries compara de forma natural: los tramos numéricos por valor ("ZET9" < "ZET10")
+func compareSeries(a, b string) int {
+	for a != "" && b != "" {
+		ca, cb := leadingChunk(a), leadingChunk(b)
+		a, b = a[len(ca):], b[len(cb):]
+
+		if isDigits(ca) && isDigits(cb) {
+			if c := compareNumeric(ca, cb); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(ca, cb); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// leadingChunk devuelve el tramo inicial de dígitos o de no dígitos
+func leadingChunk(s string) string {
+	digit := s[0] >= '0' && s[0] <= '9'
+	i := 1
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9') == digit {
+		i++
+	}
+	return s[:i]
+}
+
+// compareNumeric compara dos cadenas de dígitos por su valor, sin límite de tamaño
+func compareNumeric(a, b string) int {
+	ta, tb := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(ta) != len(tb) {
+		if len(ta) < len(tb) {
+			return -1
+		}
+		return 1
+	}
+	if c := strings.Compare(ta, tb); c != 0 {
+		return c
+	}
+	// Mismo valor: el de menos ceros a la izquierda va primero
+	return len(a) - len(b)
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrderSeries(t *testing.T) {
+	input := []string{"ZET10", "0154", "ZET9", "83", "ABC"}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{orderOriginal, []string{"ZET10", "0154", "ZET9", "83", "ABC"}},
+		{orderAscending, []string{"83", "0154", "ABC", "ZET9", "ZET10"}},
+		{orderDescending, []string{"ZET10", "ZET9", "ABC", "0154", "83"}},
+		{orderReverse, []string{"ABC", "83", "ZET9", "0154", "ZET10"}},
+	}
+
+	for _, tt := range tests {
+		got := orderSeries(input, tt.order)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("orderSeries(%q) = %v, se esperaba %v", tt.order, got, tt.want)
+		}
+	}
+
+	if input[0] != "ZET10" {
+		t.Error("orderSeries modificó la lista original")
+	}
+}
+
+func TestCompareSeries(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9", "10", -1},
+		{"010", "9", 1},
+		{"10", "010", -1},
+		{"ZET2", "ZET10", -1},
+		{"ZET", "ZET1", -1},
+		{"A1B2", "A1B10", -1},
+		{"abc", "abc", 0},
+	}
+
+	for _, tt := range tests {
+		got := compareSeries(tt.a, tt.b)
+		if (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) {
+			t.Errorf("compareSeries(%q, %q) = %d, se esperaba signo de %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSplitRecords(t *testing.T) {
+	input := "0154 0155\n  \nZET001\t15/03/2024\t15/03/2026\nZET002;2024-03-16\n0156"
+	want := []string{"0154", "0155", "ZET001\t15/03/2024\t15/03/2026", "ZET002;2024-03-16", "0156"}
+
+	if got := splitRecords(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("splitRecords = %q, se esperaba %q", got, want)
+	}
+}
+
+func TestParseRecords(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     []string
+		dateColumns int
+		hasDefault  bool
+		want        []SerieRecord
+		wantErr     bool
+	}{
+		{
+			name:        "series sueltas con fecha general",
+			records:     []string{"0154", "0155"},
+			dateColumns: 1,
+			hasDefault:  true,
+			want:        []SerieRecord{{Serie: "0154"}, {Serie: "0155"}},
+		},
+		{
+			name:        "columnas de compra y vencimiento",
+			records:     []string{"ZET001\t15/03/2024\t15/03/2026", "ZET002; 16-03-24 ;2026-03-16;"},
+			dateColumns: 2,
+			want: []SerieRecord{
+				{Serie: "ZET001", Fechas: []string{"15032024", "15032026"}},
+				{Serie: "ZET002", Fechas: []string{"16032024", "16032026"}},
+			},
+		},
+		{
+			name:        "pausa propia en cualquier columna",
+			records:     []string{"ZET001;15/03/2024;800ms", "ZET002\t1.5S", "ZET003"},
+			dateColumns: 1,
+			hasDefault:  true,
+			want: []SerieRecord{
+				{Serie: "ZET001", Fechas: []string{"15032024"}, Espera: 800 * time.Millisecond},
+				{Serie: "ZET002", Espera: 1500 * time.Millisecond},
+				{Serie: "ZET003"},
+			},
+		},
+		{name: "pausa excesiva", records: []string{"ZET001;90s"}, wantErr: true},
+		{name: "dos pausas", records: []string{"ZET001;1s;2s"}, wantErr: true},
+		{name: "sin fecha general ni propia", records: []string{"0154"}, dateColumns: 1, wantErr: true},
+		{name: "falta la segunda fecha", records: []string{"ZET001;15/03/2024"}, dateColumns: 2, hasDefault: true, wantErr: true},
+		{name: "fecha inválida", records: []string{"ZET001;31/02/2024"}, dateColumns: 1, wantErr: true},
+		{name: "registro sin serie", records: []string{";;"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRecords(tt.records, tt.dateColumns, tt.hasDefault)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseRecords(%q) = %v, se esperaba error", tt.records, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRecords(%q): %v", tt.records, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRecords(%q) = %+v, se esperaba %+v", tt.records, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeriesRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		start   int
+		count   int
+		padding int
+		want    []string
+		wantErr bool
+	}{
+		{name: "con ceros", prefix: "ZET", start: 100, count: 3, padding: 5, want: []string{"ZET00100", "ZET00101", "ZET00102"}},
+		{name: "sin prefijo ni ceros", start: 9, count: 2, want: []string{"9", "10"}},
+		{name: "el número supera los dígitos", prefix: "A", start: 99, count: 2, padding: 2, want: []string{"A99", "A100"}},
+		{name: "cantidad cero", count: 0, wantErr: true},
+		{name: "cantidad excesiva", count: maxRangeCount + 1, wantErr: true},
+		{name: "inicio negativo", start: -1, count: 1, wantErr: true},
+		{name: "dígitos fuera de rango", count: 1, padding: 19, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seriesRange(tt.prefix, tt.start, tt.count, tt.padding)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("seriesRange = %v, se esperaba error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("seriesRange: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seriesRange = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordWarnings(t *testing.T) {
+	records := []SerieRecord{
+		{Serie: "ZET001"},
+		{Serie: "ZET002"},
+		{Serie: "ZET003ZET004"},
+		{Serie: "ZET00?"},
+		{Serie: "ZET-05"},
+	}
+	want := []string{"", "", "Largo distinto (12)", "Caracteres extraños", ""}
+
+	if got := recordWarnings(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("recordWarnings = %q, se esperaba %q", got, want)
+	}
+
+	uniform := []SerieRecord{{Serie: "0154"}, {Serie: "0155"}}
+	if got := recordWarnings(uniform); !reflect.DeepEqual(got, []string{"", ""}) {
+		t.Errorf("recordWarnings(uniforme) = %q, no se esperaban avisos", got)
+	}
+}
+
+func TestRepeatList(t *testing.T) {
+	got := repeatList([]string{"A", "B"}, 3)
+	want := []string{"A", "B", "A", "B", "A", "B"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("repeatList = %v, se esperaba %v", got, want)
+	}
+}
+
+func TestProgressText(t *testing.T) {
+	tests := []struct {
+		copied, total, iterations int
+		want                      string
+	}{
+		{0, 10, 1, "Copiadas: 0 / 10"},
+		{4, 10, 1, "Copiadas: 4 / 10"},
+		{0, 12, 3, "Iteración 1 / 3 · Serie 0 / 4 · Total 0 / 12"},
+		{4, 12, 3, "Iteración 1 / 3 · Serie 4 / 4 · Total 4 / 12"},
+		{5, 12, 3, "Iteración 2 / 3 · Serie 1 / 4 · Total 5 / 12"},
+		{12, 12, 3, "Iteración 3 / 3 · Serie 4 / 4 · Total 12 / 12"},
+	}
+
+	for _, tt := range tests {
+		if got := progressText(tt.copied, tt.total, tt.iterations); got != tt.want {
+			t.Errorf("progressText(%d, %d, %d) = %q, se esperaba %q", tt.copied, tt.total, tt.iterations, got, tt.want)
+		}
+	}
+}
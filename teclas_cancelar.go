@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	hook "github.com/robotn/gohook"
+)
+
+// Combinación de cancelación por defecto (el comportamiento original)
+const defaultCancelKeys = "esc"
+
+var (
+	hookMu       sync.Mutex
+	hookStarted  bool
+	cancelCombos [][]string // Combinaciones registradas en el listener
+
+	// hookObserver recibe todos los eventos globales (lo usa el grabador de secuencias)
+	observerMu   sync.Mutex
+	hookObserver func(hook.Event)
+)
+
+func setHookObserver(observer func(hook.Event)) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	hookObserver = observer
+}
+
+func notifyHookObserver(ev hook.Event) {
+	observerMu.Lock()
+	observer := hookObserver
+	observerMu.Unlock()
+	if observer != nil {
+		observer(ev)
+	}
+}
+
+// parseCancelKeys interpreta una lista separada por comas como "esc, ctrl+alt+x". Cada
+// combinación usa los mismos nombres que las teclas de la secuencia; las letras y números
+// necesitan un modificador para que el propio tecleo no cancele el proceso.
+func parseCancelKeys(text string) ([][]string, error) {
+	var combos [][]string
+	for _, spec := range strings.Split(text, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		step, err := parseKeyStep(spec)
+		if err != nil {
+			return nil, err
+		}
+		combos = append(combos, append(append([]string(nil), step.Args...), step.Valor))
+	}
+	if len(combos) == 0 {
+		return nil, fmt.Errorf("indica al menos una tecla de cancelación")
+	}
+	return combos, nil
+}
+
+// listenCancelKeys (re)inicia el listener global de teclado con las combinaciones dadas
+func listenCancelKeys(combos [][]string) {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+
+	if hookStarted {
+		hook.End()
+	}
+	cancelCombos = combos
+	for _, combo := range combos {
+		name := strings.Join(combo, "+")
+		hook.Register(hook.KeyDown, combo, func(e hook.Event) {
+			select {
+			case <-cancel:
+			default:
+				close(cancel)
+				fmt.Printf("Cancelado con %s.\n", name)
+			}
+		})
+	}
+
+	s := hook.Start()
+	hookStarted = true
+
+	// Los eventos pasan primero por el observador y luego por las combinaciones registradas
+	events := make(chan hook.Event, 1024)
+	go func() {
+		for ev := range s {
+			notifyHookObserver(ev)
+			events <- ev
+		}
+		close(events)
+	}()
+	go func() {
+		<-hook.Process(events)
+	}()
+	fmt.Printf("Listener global de cancelación activado: %s\n", formatCancelKeys(combos))
+}
+
+// activeCancelKeys devuelve las combinaciones de cancelación en uso
+func activeCancelKeys() [][]string {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+	return cancelCombos
+}
+
+func formatCancelKeys(combos [][]string) string {
+	names := make([]string, len(combos))
+	for i, combo := range combos {
+		names[i] = strings.Join(combo, "+")
+	}
+	return strings.Join(names, ", ")
+}
+
+// applyCancelKeys activa las teclas del formulario; si no son válidas vuelve a ESC
+func (a *Autocopiador) applyCancelKeys() error {
+	combos, err := parseCancelKeys(a.cancelKeysInput.Text)
+	if err != nil {
+		log.Printf("Teclas de cancelación inválidas (%v), se usa %s", err, defaultCancelKeys)
+		combos, _ = parseCancelKeys(defaultCancelKeys)
+	}
+	listenCancelKeys(combos)
+	return err
+}
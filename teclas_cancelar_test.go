@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCancelKeys(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    [][]string
+		wantErr bool
+	}{
+		{input: "esc", want: [][]string{{"esc"}}},
+		{input: " esc , ctrl+alt+x ,", want: [][]string{{"esc"}, {"ctrl", "alt", "x"}}},
+		{input: "shift+F12", want: [][]string{{"shift", "f12"}}},
+		{input: "x", wantErr: true},
+		{input: "ctrl+hyper", wantErr: true},
+		{input: " , ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCancelKeys(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseCancelKeys(%q) = %v, se esperaba error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCancelKeys(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCancelKeys(%q) = %v, se esperaba %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// encodeJob serializa un trabajo para compartirlo. La hora programada no se exporta
+// porque es una fecha absoluta de la máquina que lo preparó.
+func encodeJob(job AutocopyJob) ([]byte, error) {
+	job.Programado = time.Time{}
+	return json.MarshalIndent(job, "", "  ")
+}
+
+// decodeJob lee un trabajo exportado y comprueba que traiga series
+func decodeJob(data []byte) (*AutocopyJob, error) {
+	var job AutocopyJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("el archivo no es un trabajo válido: %v", err)
+	}
+	if len(splitRecords(job.Series)) == 0 {
+		return nil, fmt.Errorf("el trabajo no contiene series")
+	}
+	job.Programado = time.Time{}
+	return &job, nil
+}
+
+// exportJob valida el formulario y lo guarda como un archivo .json
+func (a *Autocopiador) exportJob() {
+	job, err := a.buildJob()
+	if err != nil {
+		dialog.ShowError(err, a.window)
+		return
+	}
+	data, err := encodeJob(*job)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error serializando el trabajo: %v", err), a.window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(
+		func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+
+			if _, err := writer.Write(data); err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			a.statusLabel.SetText(fmt.Sprintf("Estado: Trabajo exportado a %s", writer.URI().Name()))
+		},
+		a.window)
+
+	saveDialog.SetFileName(fmt.Sprintf("trabajo_autocopiado_%s.json", time.Now().Format("20060102_1504")))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	saveDialog.Show()
+}
+
+// importJob carga en el formulario un trabajo exportado en otra máquina
+func (a *Autocopiador) importJob() {
+	openDialog := dialog.NewFileOpen(
+		func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+
+			data, err := ioutil.ReadAll(reader)
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			job, err := decodeJob(data)
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			a.loadJob(job)
+			a.statusLabel.SetText(fmt.Sprintf("Estado: Trabajo importado (%d series)", len(splitRecords(job.Series))))
+		},
+		a.window)
+
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	openDialog.Show()
+}
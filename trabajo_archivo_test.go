@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeJob(t *testing.T) {
+	job := AutocopyJob{
+		Series:     "ZET001\nZET002",
+		Fecha:      "05/03/2024",
+		Velocidad:  "Normal",
+		Variacion:  40 * time.Millisecond,
+		PausarCada: 10,
+		Programado: time.Date(2024, 3, 5, 18, 5, 0, 0, time.Local),
+		Macro:      "{serie}[tab]{fecha}",
+		Ventanas:   []VentanaDestino{{Pid: 10, Titulo: "ERP 1"}, {Pid: 20, Titulo: "ERP 2"}},
+		RegionOCR:  ScreenRegion{X: 1, Y: 2, Ancho: 3, Alto: 4},
+	}
+
+	data, err := encodeJob(job)
+	if err != nil {
+		t.Fatalf("encodeJob() error = %v", err)
+	}
+	got, err := decodeJob(data)
+	if err != nil {
+		t.Fatalf("decodeJob() error = %v", err)
+	}
+
+	want := job
+	want.Programado = time.Time{}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("decodeJob(encodeJob()) = %+v, se esperaba %+v", *got, want)
+	}
+}
+
+func TestDecodeJobErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"no es json",
+		`{"Fecha": "05/03/2024"}`,
+		`{"Series": "  \n "}`,
+	}
+
+	for _, data := range tests {
+		if _, err := decodeJob([]byte(data)); err == nil {
+			t.Errorf("decodeJob(%q) debería fallar", data)
+		}
+	}
+}
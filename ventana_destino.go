@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// targetWindow identifica la ventana que tenía el foco al iniciar el tecleo
+type targetWindow struct {
+	pid   int
+	title string
+}
+
+func activeWindow() targetWindow {
+	return targetWindow{pid: robotgo.GetPid(), title: robotgo.GetTitle()}
+}
+
+// guardFocus comprueba que la ventana activa siga siendo la de destino. Si cambió,
+// pausa hasta que el usuario confirme y vuelva a enfocarla; devuelve false si se cancela.
+func (a *Autocopiador) guardFocus(target targetWindow) bool {
+	for {
+		current := activeWindow()
+		if current.pid == target.pid {
+			return true
+		}
+
+		a.statusLabel.SetText(fmt.Sprintf("Pausado: la ventana activa cambió a %q", current.title))
+		if !waitConfirmation("⚠️ Ventana destino sin foco",
+			fmt.Sprintf("El foco pasó de %q a %q, así que el autocopiado se pausó para no escribir en otra aplicación.\n\n"+
+				"Al continuar tendrás %d segundos para volver a la ventana destino con el cursor en el campo correcto.\n¿Continuar?",
+				target.title, current.title, resumeCountdown),
+			a.window, a.statusLabel) {
+			return false
+		}
+		if !runCountdown(resumeCountdown, a.statusLabel) {
+			return false
+		}
+		a.statusLabel.SetText("Copiando...")
+	}
+}
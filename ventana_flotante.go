@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const overlayTopmostDelay = 300 * time.Millisecond
+
+// autocopyOverlay es la ventana pequeña que queda encima de la aplicación destino
+// mientras se escribe, porque la ventana principal suele quedar tapada
+type autocopyOverlay struct {
+	window   fyne.Window
+	serie    *widget.Label
+	progress *widget.Label
+}
+
+// cancelRun cancela el trabajo en curso, igual que las teclas de cancelación
+func (a *Autocopiador) cancelRun() {
+	select {
+	case <-cancel:
+	default:
+		close(cancel)
+		a.statusLabel.SetText("Estado: Cancelado manualmente.")
+	}
+}
+
+// showOverlay abre la ventana flotante. Se llama al lanzar el trabajo, antes de la cuenta
+// regresiva, para que no le quite el foco a la aplicación destino ya iniciado el tecleo.
+func (a *Autocopiador) showOverlay() {
+	if !a.overlayCheck.Checked || a.overlay != nil {
+		return
+	}
+
+	o := &autocopyOverlay{
+		window:   fyne.CurrentApp().NewWindow("Autocopiando"),
+		serie:    widget.NewLabelWithStyle("Esperando...", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+		progress: widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{}),
+	}
+	cancelButton := widget.NewButton("⏹️ Cancelar", a.cancelRun)
+	cancelButton.Importance = widget.DangerImportance
+
+	o.window.SetContent(container.NewVBox(o.serie, o.progress, cancelButton))
+	o.window.Resize(fyne.NewSize(260, 120))
+	o.window.SetFixedSize(true)
+	// Cerrarla a mano no cancela el proceso, solo la oculta
+	o.window.SetCloseIntercept(func() {
+		o.window.Hide()
+	})
+	o.window.Show()
+	a.overlay = o
+
+	// La ventana nativa termina de crearse después de Show
+	go func() {
+		time.Sleep(overlayTopmostDelay)
+		fyne.Do(func() {
+			setAlwaysOnTop(o.window)
+		})
+	}()
+}
+
+// updateOverlay muestra la serie que se está escribiendo; se puede llamar desde cualquier goroutine
+func (a *Autocopiador) updateOverlay(serie string, index, total int) {
+	fyne.Do(func() {
+		if a.overlay == nil {
+			return
+		}
+		a.overlay.serie.SetText(serie)
+		a.overlay.progress.SetText(fmt.Sprintf("Serie %d de %d", index, total))
+	})
+}
+
+func (a *Autocopiador) closeOverlay() {
+	if a.overlay == nil {
+		return
+	}
+	a.overlay.window.Close()
+	a.overlay = nil
+}
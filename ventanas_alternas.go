@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/go-vgo/robotgo"
+)
+
+// Tiempo para que la ventana activada reciba el foco antes de teclear
+const windowSwitchSettle = 400 * time.Millisecond
+
+// VentanaDestino identifica una ventana elegida para alternar los registros
+type VentanaDestino struct {
+	Pid    int
+	Titulo string
+}
+
+func (v VentanaDestino) target() targetWindow {
+	return targetWindow{pid: v.Pid, title: v.Titulo}
+}
+
+func (v VentanaDestino) String() string {
+	if v.Pid == 0 {
+		return "Sin elegir"
+	}
+	return fmt.Sprintf("%s (PID %d)", v.Titulo, v.Pid)
+}
+
+// validateAlternateWindows comprueba que las dos ventanas estén elegidas y se puedan
+// distinguir: el foco se cambia por proceso, así que deben ser programas distintos
+func validateAlternateWindows(windows []VentanaDestino) error {
+	if len(windows) != 2 || windows[0].Pid == 0 || windows[1].Pid == 0 {
+		return fmt.Errorf("elige las dos ventanas entre las que se alternan los registros")
+	}
+	if windows[0].Pid == windows[1].Pid {
+		return fmt.Errorf("las dos ventanas pertenecen al mismo programa (PID %d); abre cada sesión en una instancia distinta", windows[0].Pid)
+	}
+	return nil
+}
+
+// windowForRecord devuelve la ventana que recibe el registro i
+func windowForRecord(windows []VentanaDestino, i int) VentanaDestino {
+	return windows[i%len(windows)]
+}
+
+// activateWindow pone el foco en la ventana indicada; falla si el programa ya se cerró
+func activateWindow(v VentanaDestino) error {
+	if exists, err := robotgo.PidExists(v.Pid); err != nil || !exists {
+		return fmt.Errorf("la ventana %q ya no existe", v.Titulo)
+	}
+	// Algunos sistemas simulan teclas para cambiar el foco
+	userInput.beginSynthetic()
+	defer userInput.endSynthetic()
+	if err := robotgo.ActivePid(v.Pid); err != nil {
+		return fmt.Errorf("no se pudo activar %q: %v", v.Titulo, err)
+	}
+	time.Sleep(windowSwitchSettle)
+	return nil
+}
+
+// pickAlternateWindow toma como ventana del lugar slot la que esté activa tras una
+// cuenta regresiva, para dar tiempo a hacer clic en ella
+func (a *Autocopiador) pickAlternateWindow(slot int, label *widget.Label) {
+	go func() {
+		for i := 3; i > 0; i-- {
+			remaining := i
+			fyne.Do(func() {
+				a.statusLabel.SetText(fmt.Sprintf("Haz clic en la ventana %d... %d", slot+1, remaining))
+			})
+			time.Sleep(time.Second)
+		}
+		current := activeWindow()
+
+		fyne.Do(func() {
+			a.altWindows[slot] = VentanaDestino{Pid: current.pid, Titulo: current.title}
+			label.SetText(a.altWindows[slot].String())
+			a.statusLabel.SetText(fmt.Sprintf("Estado: Ventana %d elegida", slot+1))
+		})
+	}()
+}
+
+func (a *Autocopiador) createAlternateWindowsPanel() fyne.CanvasObject {
+	rows := container.NewVBox()
+	for slot := range a.altWindows {
+		label := widget.NewLabel(a.altWindows[slot].String())
+		a.altWindowLabels[slot] = label
+		button := widget.NewButton(fmt.Sprintf("🎯 Ventana %d", slot+1), func() {
+			a.pickAlternateWindow(slot, label)
+		})
+		a.runControls = append(a.runControls, button)
+		rows.Add(container.NewBorder(nil, nil, button, nil, label))
+	}
+	rows.Hide()
+
+	a.altCheck = widget.NewCheck("Alternar entre dos ventanas", func(checked bool) {
+		if checked {
+			rows.Show()
+		} else {
+			rows.Hide()
+		}
+	})
+
+	return container.NewVBox(a.altCheck, rows)
+}
+
+// loadAlternateWindows vuelca en el formulario las ventanas de un trabajo guardado
+func (a *Autocopiador) loadAlternateWindows(windows []VentanaDestino) {
+	a.altWindows = [2]VentanaDestino{}
+	copy(a.altWindows[:], windows)
+	for slot, label := range a.altWindowLabels {
+		label.SetText(a.altWindows[slot].String())
+	}
+	a.altCheck.SetChecked(len(windows) > 0)
+}
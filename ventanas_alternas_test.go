@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestValidateAlternateWindows(t *testing.T) {
+	erp1 := VentanaDestino{Pid: 10, Titulo: "ERP 1"}
+	erp2 := VentanaDestino{Pid: 20, Titulo: "ERP 2"}
+
+	tests := []struct {
+		name    string
+		windows []VentanaDestino
+		wantErr bool
+	}{
+		{"dos programas", []VentanaDestino{erp1, erp2}, false},
+		{"sin elegir", []VentanaDestino{{}, {}}, true},
+		{"falta una", []VentanaDestino{erp1, {}}, true},
+		{"mismo programa", []VentanaDestino{erp1, {Pid: 10, Titulo: "ERP 1 (2)"}}, true},
+		{"una sola", []VentanaDestino{erp1}, true},
+	}
+
+	for _, tt := range tests {
+		err := validateAlternateWindows(tt.windows)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWindowForRecord(t *testing.T) {
+	windows := []VentanaDestino{{Pid: 10}, {Pid: 20}}
+	want := []int{10, 20, 10, 20, 10}
+	for i, pid := range want {
+		if got := windowForRecord(windows, i).Pid; got != pid {
+			t.Errorf("windowForRecord(%d) = %d, se esperaba %d", i, got, pid)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/go-vgo/robotgo"
+)
+
+const ocrLogFile = "verificacion_ocr.log"
+
+// ScreenRegion es un rectángulo de la pantalla en píxeles
+type ScreenRegion struct {
+	X, Y, Ancho, Alto int
+}
+
+func (r ScreenRegion) String() string {
+	return fmt.Sprintf("%d,%d,%d,%d", r.X, r.Y, r.Ancho, r.Alto)
+}
+
+// parseRegion interpreta "X,Y,Ancho,Alto"
+func parseRegion(text string) (ScreenRegion, error) {
+	parts := strings.Split(text, ",")
+	if len(parts) != 4 {
+		return ScreenRegion{}, fmt.Errorf("la región debe tener el formato X,Y,Ancho,Alto")
+	}
+
+	var values [4]int
+	for i, p := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || v < 0 {
+			return ScreenRegion{}, fmt.Errorf("valor inválido en la región: %q", strings.TrimSpace(p))
+		}
+		values[i] = v
+	}
+	if values[2] == 0 || values[3] == 0 {
+		return ScreenRegion{}, fmt.Errorf("el ancho y el alto de la región deben ser mayores a 0")
+	}
+	return ScreenRegion{X: values[0], Y: values[1], Ancho: values[2], Alto: values[3]}, nil
+}
+
+// ocrConfusions agrupa caracteres que el OCR suele confundir
+var ocrConfusions = strings.NewReplacer(
+	"O", "0", "Q", "0", "D", "0",
+	"I", "1", "L", "1", "|", "1",
+	"S", "5", "B", "8", "Z", "2",
+)
+
+// normalizeOCR deja solo letras y números en mayúsculas, unificando las confusiones típicas
+func normalizeOCR(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(text) {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || r == '|' {
+			b.WriteRune(r)
+		}
+	}
+	return ocrConfusions.Replace(b.String())
+}
+
+// ocrMatches indica si el texto leído en pantalla contiene el valor esperado
+func ocrMatches(expected, read string) bool {
+	want := normalizeOCR(expected)
+	if want == "" {
+		return true
+	}
+	return strings.Contains(normalizeOCR(read), want)
+}
+
+// readScreenRegion captura la región y devuelve el texto reconocido
+func readScreenRegion(region ScreenRegion) (string, error) {
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("autocopiador_ocr_%d.png", time.Now().UnixNano()))
+	defer os.Remove(path)
+
+	if err := robotgo.SaveCapture(path, region.X, region.Y, region.Ancho, region.Alto); err != nil {
+		return "", fmt.Errorf("error capturando la pantalla: %v", err)
+	}
+	return recognizeText(path)
+}
+
+// logOCRMismatch agrega la discrepancia al registro de verificación
+func logOCRMismatch(serie, read string) {
+	f, err := os.OpenFile(ocrLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("Error abriendo registro OCR: %v", err)
+		return
+	}
+	defer f.Close()
+
+	read = strings.Join(strings.Fields(read), " ")
+	fmt.Fprintf(f, "%s\tesperado=%s\tleído=%q\n", time.Now().Format("2006-01-02 15:04:05"), serie, read)
+}
+
+// verifyEntry comprueba con OCR que la serie quedó escrita; si no coincide la registra y pausa
+func (a *Autocopiador) verifyEntry(region ScreenRegion, serie string) bool {
+	// Dar tiempo a que la aplicación destino dibuje el valor
+	time.Sleep(150 * time.Millisecond)
+
+	read, err := readScreenRegion(region)
+	if err == nil && ocrMatches(serie, read) {
+		return true
+	}
+
+	detail := strings.Join(strings.Fields(read), " ")
+	if err != nil {
+		detail = err.Error()
+	}
+	logOCRMismatch(serie, detail)
+	a.stats.addError()
+	a.refreshStats()
+
+	a.statusLabel.SetText(fmt.Sprintf("Pausado: no se pudo verificar la serie %s", serie))
+	if !waitConfirmation("⚠️ Verificación OCR",
+		fmt.Sprintf("No se encontró la serie %s en la región verificada.\n\nLeído: %q\n\nCorrige el registro en la aplicación destino si es necesario.\n¿Continuar con la siguiente serie?", serie, detail),
+		a.window, a.statusLabel) {
+		return false
+	}
+	return runCountdown(resumeCountdown, a.statusLabel)
+}
+
+// pickRegionFromMouse toma la posición del mouse tras una cuenta regresiva como esquina de la región
+func (a *Autocopiador) pickRegionFromMouse(regionInput *widget.Entry) {
+	go func() {
+		for i := 3; i > 0; i-- {
+			a.statusLabel.SetText(fmt.Sprintf("Coloca el mouse sobre la esquina superior izquierda del campo... %d", i))
+			time.Sleep(time.Second)
+		}
+		x, y := robotgo.Location()
+
+		fyne.Do(func() {
+			region := ScreenRegion{X: x, Y: y, Ancho: 200, Alto: 30}
+			if current, err := parseRegion(regionInput.Text); err == nil {
+				region.Ancho, region.Alto = current.Ancho, current.Alto
+			}
+			regionInput.SetText(region.String())
+			a.statusLabel.SetText("Estado: Región de verificación actualizada")
+		})
+	}()
+}
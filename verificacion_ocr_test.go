@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ScreenRegion
+		wantErr bool
+	}{
+		{"100,200,300,40", ScreenRegion{100, 200, 300, 40}, false},
+		{" 0, 0 ,50 , 20 ", ScreenRegion{0, 0, 50, 20}, false},
+		{"100,200,300", ScreenRegion{}, true},
+		{"100,200,0,40", ScreenRegion{}, true},
+		{"-5,200,300,40", ScreenRegion{}, true},
+		{"a,b,c,d", ScreenRegion{}, true},
+		{"", ScreenRegion{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRegion(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseRegion(%q) = %v, se esperaba error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRegion(%q) error inesperado: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseRegion(%q) = %v, se esperaba %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestOCRMatches(t *testing.T) {
+	tests := []struct {
+		expected string
+		read     string
+		want     bool
+	}{
+		{"ZET00154", "ZET00154", true},
+		{"ZET00154", "Serie: zet 00154\n", true},
+		{"ZET00154", "ZETOO154", true},
+		{"10154", "I0I54", true},
+		{"ZET00154", "ZET00155", false},
+		{"ZET00154", "", false},
+		{"", "cualquier cosa", true},
+	}
+
+	for _, tt := range tests {
+		if got := ocrMatches(tt.expected, tt.read); got != tt.want {
+			t.Errorf("ocrMatches(%q, %q) = %v, se esperaba %v", tt.expected, tt.read, got, tt.want)
+		}
+	}
+}
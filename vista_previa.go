@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showPreview muestra en una tabla cada registro tal como se va a escribir y llama a
+// onConfirm solo si el usuario acepta
+func (a *Autocopiador) showPreview(records []SerieRecord, job *AutocopyJob, onConfirm func()) {
+	defaultDate := job.Fecha
+	dateColumns := 1
+	hasDelays := false
+	for _, rec := range records {
+		if len(rec.Fechas) > dateColumns {
+			dateColumns = len(rec.Fechas)
+		}
+		hasDelays = hasDelays || rec.Espera > 0
+	}
+	warnings := recordWarnings(records)
+	flagged := 0
+	for _, w := range warnings {
+		if w != "" {
+			flagged++
+		}
+	}
+
+	headers := []string{"#", "Serie"}
+	for i := 1; i <= dateColumns; i++ {
+		if i == 1 {
+			headers = append(headers, "Fecha")
+		} else {
+			headers = append(headers, fmt.Sprintf("Fecha %d", i))
+		}
+	}
+	delayCol := -1
+	if hasDelays {
+		delayCol = len(headers)
+		headers = append(headers, "Pausa")
+	}
+	headers = append(headers, "Aviso")
+
+	// cellText devuelve el valor de la celda; {fecha} cae en la fecha general si el registro no trae
+	cellText := func(row, col int) string {
+		rec := records[row]
+		switch {
+		case col == 0:
+			return strconv.Itoa(row + 1)
+		case col == 1:
+			return rec.Serie
+		case col == len(headers)-1:
+			return warnings[row]
+		case col == delayCol:
+			if rec.Espera > 0 {
+				return rec.Espera.String()
+			}
+			return ""
+		}
+		i := col - 2
+		if i < len(rec.Fechas) {
+			return rec.Fechas[i]
+		}
+		if i == 0 && len(rec.Fechas) == 0 {
+			return defaultDate
+		}
+		return ""
+	}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(records), len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			label.SetText(cellText(id.Row, id.Col))
+			if warnings[id.Row] != "" {
+				label.Importance = widget.WarningImportance
+			} else {
+				label.Importance = widget.MediumImportance
+			}
+			label.Refresh()
+		},
+	)
+	table.ShowHeaderRow = true
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		if id.Col >= 0 {
+			obj.(*widget.Label).SetText(headers[id.Col])
+		}
+	}
+	table.SetColumnWidth(0, 50)
+	table.SetColumnWidth(1, 160)
+	for col := 2; col < len(headers)-1; col++ {
+		table.SetColumnWidth(col, 100)
+	}
+	table.SetColumnWidth(len(headers)-1, 180)
+
+	text := fmt.Sprintf("Se escribirán %d registros", len(records))
+	if skipped := len(splitRecords(job.Series)) - len(records); skipped > 0 {
+		text += fmt.Sprintf(" (%d quedaron fuera por los filtros)", skipped)
+	}
+	if n := job.iterations(); n > 1 {
+		text += fmt.Sprintf(", repetidos %d veces (%d en total)", n, n*len(records))
+	}
+	summary := widget.NewLabel(text + ".")
+	if flagged > 0 {
+		summary.SetText(fmt.Sprintf("%s; %d parecen tener errores (ver columna Aviso).", text, flagged))
+		summary.Importance = widget.WarningImportance
+	}
+
+	preview := dialog.NewCustomConfirm("👀 Vista previa", "Iniciar", "Cancelar",
+		container.NewBorder(summary, nil, nil, nil, table),
+		func(confirmed bool) {
+			if confirmed {
+				onConfirm()
+			}
+		}, a.window)
+	preview.Resize(fyne.NewSize(640, 480))
+	preview.Show()
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	pdfPreviewDelay = 500 * time.Millisecond // Espera a que se deje de escribir antes de dibujar
+	pdfPreviewDPI   = 72                     // Una hoja A4 queda de unos 600x840 puntos, suficiente para la vista previa
+)
+
+// pdfRasterizers son los programas que pueden pasar la primera página de un PDF a PNG, en
+// orden de preferencia: pdftoppm de Poppler, mutool de MuPDF y Ghostscript. Go no trae cómo
+// dibujar un PDF, así que la vista previa usa el que esté instalado.
+var pdfRasterizers = []string{"pdftoppm", "mutool", "gswin64c", "gswin32c", "gs"}
+
+// rasterizeCommand arma los argumentos para que tool dibuje la primera página de in en out
+// a dpi puntos por pulgada
+func rasterizeCommand(tool, in, out string, dpi int) []string {
+	res := strconv.Itoa(dpi)
+	switch tool {
+	case "pdftoppm":
+		// pdftoppm agrega la extensión .png al nombre que recibe
+		return []string{"-png", "-r", res, "-f", "1", "-l", "1", "-singlefile", in, strings.TrimSuffix(out, ".png")}
+	case "mutool":
+		return []string{"draw", "-r", res, "-o", out, in, "1"}
+	default:
+		return []string{"-q", "-dSAFER", "-dBATCH", "-dNOPAUSE", "-sDEVICE=png16m", "-r" + res,
+			"-dFirstPage=1", "-dLastPage=1", "-sOutputFile=" + out, in}
+	}
+}
+
+// findRasterizer busca uno de pdfRasterizers junto al programa o en el PATH; devuelve el
+// nombre y la ruta, o vacíos si no hay ninguno
+func findRasterizer() (tool, path string) {
+	for _, name := range pdfRasterizers {
+		if runtime.GOOS == "windows" {
+			if _, err := os.Stat(name + ".exe"); err == nil {
+				return name, name + ".exe"
+			}
+		}
+		if path, err := exec.LookPath(name); err == nil {
+			return name, path
+		}
+	}
+	return "", ""
+}
+
+// renderPDFPage dibuja la primera página de data con el programa tool que está en path
+func renderPDFPage(tool, path string, data []byte, dpi int) (image.Image, error) {
+	dir, err := ioutil.TempDir("", "rotulo-vista-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in, out := filepath.Join(dir, "rotulo.pdf"), filepath.Join(dir, "rotulo.png")
+	if err := ioutil.WriteFile(in, data, 0644); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(path, rasterizeCommand(tool, in, out, dpi)...)
+	hideConsoleWindow(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s no pudo dibujar el PDF: %v %s", tool, err, strings.TrimSpace(string(output)))
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+// schedulePDFPreview vuelve a dibujar el PDF del rótulo un momento después del último
+// cambio. El PDF se arma en el hilo de la interfaz, porque lee las empresas que se editan
+// ahí, y solo el programa externo corre aparte; si mientras tanto llega otro cambio, la
+// imagen vieja se descarta.
+func (r *RotuloGenerator) schedulePDFPreview(guia string) {
+	if r.rasterTool == "" || r.data.Empresa == "" {
+		return
+	}
+	data := *r.data
+	data.NumeroGuia = guia
+	if data.FechaEnvio.IsZero() {
+		data.FechaEnvio = time.Now()
+	}
+	r.pdfSeq++
+	seq := r.pdfSeq
+	if r.pdfTimer != nil {
+		r.pdfTimer.Stop()
+	}
+	r.pdfTimer = time.AfterFunc(pdfPreviewDelay, func() {
+		fyne.Do(func() {
+			if seq != r.pdfSeq {
+				return
+			}
+			pdfData, err := createRotuloPDF(data)
+			if err != nil {
+				r.pdfPreview.SetText("⚠️ " + err.Error())
+				return
+			}
+			tool, path := r.rasterTool, r.rasterPath
+			go func() {
+				img, err := renderPDFPage(tool, path, pdfData, pdfPreviewDPI)
+				fyne.Do(func() {
+					if seq != r.pdfSeq {
+						return
+					}
+					if err != nil {
+						r.pdfPreview.SetText("⚠️ " + err.Error())
+						return
+					}
+					r.pdfPreview.SetText(fmt.Sprintf("📄 PDF real · %s - %s · dibujado con %s", data.TamanoHoja, data.Orientacion, tool))
+					r.pdfImage.Image = img
+					r.pdfImage.Refresh()
+				})
+			}()
+		})
+	})
+}
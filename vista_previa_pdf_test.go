@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRasterizeCommand(t *testing.T) {
+	tests := map[string][]string{
+		"pdftoppm": {"-png", "-r", "72", "-f", "1", "-l", "1", "-singlefile", "in.pdf", "out"},
+		"mutool":   {"draw", "-r", "72", "-o", "out.png", "in.pdf", "1"},
+		"gswin64c": {"-q", "-dSAFER", "-dBATCH", "-dNOPAUSE", "-sDEVICE=png16m", "-r72", "-dFirstPage=1", "-dLastPage=1", "-sOutputFile=out.png", "in.pdf"},
+	}
+	for tool, want := range tests {
+		if got := rasterizeCommand(tool, "in.pdf", "out.png", 72); !reflect.DeepEqual(got, want) {
+			t.Errorf("rasterizeCommand(%s) = %q; quería %q", tool, got, want)
+		}
+	}
+}
+
+// TestRenderPDFPage dibuja un rótulo con el programa instalado; sin ninguno no hay qué probar
+func TestRenderPDFPage(t *testing.T) {
+	tool, path := findRasterizer()
+	if tool == "" {
+		t.Skip("no hay pdftoppm, mutool ni Ghostscript instalados")
+	}
+	label := RotuloData{Empresa: "COMSITEC", RemitenteNombre: "COMSITEC S.A.C", DestinatarioNombre: "María",
+		NumeroGuia: "COM-000001", TamanoHoja: "A5", Orientacion: "Vertical", FechaEnvio: time.Now()}
+	data, err := createRotuloPDF(label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := renderPDFPage(tool, path, data, 72)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A5 mide 148x210 mm: a 72 dpi unos 420x595 puntos
+	if b := img.Bounds(); b.Dx() < 410 || b.Dx() > 430 || b.Dy() < 585 || b.Dy() > 605 {
+		t.Errorf("la página dibujada mide %v", b)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// zplEscape prepara texto para un campo con ^FH: los caracteres de control de ZPL y el
+// propio indicador _ van en hexadecimal
+func zplEscape(text string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E", "\n", " ").Replace(text)
+}
+
+// zplWriter arma los comandos ZPL de las Zebra. El texto va en UTF-8 (^CI28) con la letra
+// escalable 0.
+type zplWriter struct {
+	labelDots
+}
+
+func (z *zplWriter) begin(width, height float64) {
+	fmt.Fprintf(&z.b, "^XA\n^CI28\n^PW%d\n^LL%d\n", z.dots(width), z.dots(height))
+}
+
+func (z *zplWriter) text(x, y, size, width float64, lines int, text string) {
+	fmt.Fprintf(&z.b, "^FO%d,%d^A0N,%d,%d", z.dots(x), z.dots(y), z.dots(size), z.dots(size))
+	if lines > 1 {
+		fmt.Fprintf(&z.b, "^FB%d,%d,0,L,0", z.dots(width), lines)
+	}
+	fmt.Fprintf(&z.b, "^FH^FD%s^FS\n", zplEscape(text))
+}
+
+func (z *zplWriter) box(x, y, width, height, thickness float64) {
+	fmt.Fprintf(&z.b, "^FO%d,%d^GB%d,%d,%d^FS\n", z.dots(x), z.dots(y), z.dots(width), z.dots(height), z.dots(thickness))
+}
+
+func (z *zplWriter) barcode(x, y, height float64, text string) {
+	fmt.Fprintf(&z.b, "^FO%d,%d^BY%d^BCN,%d,Y,N,N^FH^FD%s^FS\n", z.dots(x), z.dots(y), barcodeModule(text, z.dpmm), z.dots(height), zplEscape(text))
+}
+
+func (z *zplWriter) qr(x, y float64, text string) {
+	fmt.Fprintf(&z.b, "^FO%d,%d^BQN,2,%d^FDQA,%s^FS\n", z.dots(x), z.dots(y), z.dpmm/2, text)
+}
+
+func (z *zplWriter) end(copies int) string {
+	if copies > 1 {
+		fmt.Fprintf(&z.b, "^PQ%d\n", copies)
+	}
+	z.b.WriteString("^XZ\n")
+	return z.b.String()
+}
+
+// zplLabel arma el rótulo de data en ZPL para una etiqueta de 10x15 cm a dpmm puntos por
+// milímetro. La impresora dibuja el Code 128 y el QR, así salen nítidos a su resolución.
+func zplLabel(data RotuloData, empresa Empresa, dpmm, copies int) string {
+	return thermalLabel(&zplWriter{labelDots{dpmm: dpmm}}, data, empresa, copies)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZPLLabel(t *testing.T) {
+	data := RotuloData{RemitenteNombre: "ZETTACOM S.A.C", DestinatarioNombre: "María_González ^test~",
+		DestinatarioDNI: "45678912", NumeroGuia: "ZET123456", FechaEnvio: time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)}
+	empresa := Empresa{Nombre: "COMSITEC S.A.C", NeedQR: true}
+
+	zpl := zplLabel(data, empresa, 8, 2)
+	for _, want := range []string{
+		"^XA\n^CI28\n^PW800\n^LL1200\n",
+		"^FH^FDMaría_5FGonzález _5Etest_7E^FS",
+		"^FH^FDDNI: 45678912^FS",
+		"^FH^FDFecha: 05/03/2024 09:30^FS",
+		"^BY3^BCN,144,Y,N,N^FH^FDZET123456^FS",
+		"^BQN,2,4^FDQA,https://www.comsitec.techZET123456^FS",
+		"^PQ2\n^XZ\n",
+	} {
+		if !strings.Contains(zpl, want) {
+			t.Errorf("falta %q en:\n%s", want, zpl)
+		}
+	}
+	if strings.Contains(zpl, "Peso:") || strings.Contains(zpl, "Obs:") {
+		t.Error("los campos vacíos no deberían imprimirse")
+	}
+
+	// A 300 dpi las medidas crecen y sin QR ni copias no van esos comandos
+	empresa.NeedQR = false
+	zpl = zplLabel(data, empresa, 12, 1)
+	if !strings.Contains(zpl, "^PW1200\n^LL1800\n") || !strings.Contains(zpl, "^BY4^BCN,216") {
+		t.Errorf("a 300 dpi:\n%s", zpl)
+	}
+	if strings.Contains(zpl, "^BQ") || strings.Contains(zpl, "^PQ") {
+		t.Errorf("no debería llevar QR ni copias:\n%s", zpl)
+	}
+}